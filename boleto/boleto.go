@@ -0,0 +1,290 @@
+// Package boleto parses and validates boleto barcodes and digitable lines offline, for
+// both bank slips (boletos bancários) and utility bills (contas de concessionárias,
+// convênios and taxes), following the FEBRABAN layouts.
+//
+// Use it to catch typos before calling GetBillInfo, PayBill or PayBillBatch.
+package boleto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// Kind distinguishes bank slips from utility bills
+type Kind string
+
+const (
+	KindBankSlip Kind = "BANK_SLIP" // Boleto bancário: 44-digit barcode, 47-digit line
+	KindUtility  Kind = "UTILITY"   // Concessionária/convênio: 44-digit barcode, 48-digit line
+)
+
+const (
+	barcodeLength        = 44
+	bankSlipLineLength   = 47
+	utilityLineLength    = 48
+	bankSlipCurrencyReal = '9'
+)
+
+// Boleto is a validated barcode with the data it carries
+type Boleto struct {
+	Kind          Kind
+	Barcode       string      // 44 digits
+	DigitableLine string      // 47 digits for bank slips, 48 for utility bills, without separators
+	Amount        types.Money // Zero when the bill does not fix the amount
+
+	// Bank slips only
+	BankCode  string // 3-digit code of the issuing bank
+	DueFactor int    // Days since the factor base date; 0 when the slip has no due date
+	FreeField string // 25 digits defined by the issuing bank
+
+	// Utility bills only
+	Segment        int    // 1-9, e.g. 2 for sanitation and 3 for energy and gas
+	ReferenceValue bool   // The value digits hold a reference quantity instead of an amount in reais
+	CompanyID      string // 4-digit company code, or the 8-digit CNPJ root in segment 6
+}
+
+// Parse validates a barcode or a digitable line and extracts its data. Printed lines may
+// keep their separators (spaces, dots and hyphens). For digitable lines, the check digit
+// of each block is verified before the general check digit, so that a *CheckDigitError
+// points to the block with the typo.
+func Parse(s string) (*Boleto, error) {
+	digits, err := normalize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(digits) {
+	case barcodeLength:
+		return parseBarcode(digits)
+	case bankSlipLineLength:
+		barcode, err := bankSlipLineToBarcode(digits)
+		if err != nil {
+			return nil, err
+		}
+		return parseBarcode(barcode)
+	case utilityLineLength:
+		barcode, err := utilityLineToBarcode(digits)
+		if err != nil {
+			return nil, err
+		}
+		return parseBarcode(barcode)
+	default:
+		return nil, fmt.Errorf("%w: %d digits, want 44, 47 or 48", ErrInvalidLength, len(digits))
+	}
+}
+
+// DueDate returns the due date of a bank slip, or false when it has none. See DueDateFromFactor.
+func (b *Boleto) DueDate(ref time.Time) (time.Time, bool) {
+	if b.Kind != KindBankSlip || b.DueFactor == 0 {
+		return time.Time{}, false
+	}
+	return DueDateFromFactor(b.DueFactor, ref), true
+}
+
+// factorBase is the date of due factor 0. Factors run from 1000 to 9999 and then restart:
+// 9999 is 2025-02-21 and 1000 is again 2025-02-22, so each factor repeats every 9000 days.
+var factorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+// factorCycle is the number of days after which due factors repeat
+const factorCycle = 9000
+
+// DueDateFromFactor converts a due factor to a date (midnight UTC). Since factors repeat
+// every 9000 days, it returns the date closest to ref, which is usually time.Now(); this
+// resolves slips issued before and after the 2025 factor rollover.
+func DueDateFromFactor(factor int, ref time.Time) time.Time {
+	refDays := int(ref.Sub(factorBase).Hours() / 24)
+	cycle := max((refDays-factor+factorCycle/2)/factorCycle, 0)
+	return factorBase.AddDate(0, 0, factor+cycle*factorCycle)
+}
+
+// parseBarcode validates a 44-digit barcode and extracts its fields
+func parseBarcode(barcode string) (*Boleto, error) {
+	if barcode[0] == '8' {
+		return parseUtilityBarcode(barcode)
+	}
+	return parseBankSlipBarcode(barcode)
+}
+
+// parseBankSlipBarcode parses a bank slip barcode: bank (3), currency (1), check digit (1),
+// due factor (4), amount in cents (10) and free field (25)
+func parseBankSlipBarcode(barcode string) (*Boleto, error) {
+	if barcode[3] != bankSlipCurrencyReal {
+		return nil, fmt.Errorf("%w: %c", ErrInvalidCurrency, barcode[3])
+	}
+	if want := bankSlipMod11(barcode[:4] + barcode[5:]); barcode[4] != want {
+		return nil, &CheckDigitError{Field: "general", Position: 5, Got: barcode[4], Want: want}
+	}
+
+	factor, _ := strconv.Atoi(barcode[5:9])
+	cents, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	free := barcode[19:]
+	field1 := barcode[:4] + free[:5]
+	field2 := free[5:15]
+	field3 := free[15:]
+	line := field1 + string(mod10(field1)) +
+		field2 + string(mod10(field2)) +
+		field3 + string(mod10(field3)) +
+		barcode[4:5] + barcode[5:19]
+
+	return &Boleto{
+		Kind:          KindBankSlip,
+		Barcode:       barcode,
+		DigitableLine: line,
+		Amount:        types.MoneyFromCents(cents),
+		BankCode:      barcode[:3],
+		DueFactor:     factor,
+		FreeField:     free,
+	}, nil
+}
+
+// parseUtilityBarcode parses a utility bill barcode: product (1, always 8), segment (1),
+// value identifier (1), check digit (1), value (11), company (4 or 8) and free field
+func parseUtilityBarcode(barcode string) (*Boleto, error) {
+	checkDigit, err := utilityCheckDigit(barcode[2])
+	if err != nil {
+		return nil, err
+	}
+	if want := checkDigit(barcode[:3] + barcode[4:]); barcode[3] != want {
+		return nil, &CheckDigitError{Field: "general", Position: 4, Got: barcode[3], Want: want}
+	}
+
+	var line strings.Builder
+	for i := 0; i < barcodeLength; i += 11 {
+		block := barcode[i : i+11]
+		line.WriteString(block)
+		line.WriteByte(checkDigit(block))
+	}
+
+	b := &Boleto{
+		Kind:           KindUtility,
+		Barcode:        barcode,
+		DigitableLine:  line.String(),
+		Segment:        int(barcode[1] - '0'),
+		ReferenceValue: barcode[2] == '7' || barcode[2] == '9',
+		CompanyID:      barcode[15:19],
+	}
+	if b.Segment == 6 {
+		b.CompanyID = barcode[15:23]
+	}
+	if !b.ReferenceValue {
+		cents, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+		b.Amount = types.MoneyFromCents(cents)
+	}
+	return b, nil
+}
+
+// bankSlipLineToBarcode checks the block digits of a 47-digit line and rearranges it into
+// a barcode. The line holds bank and currency (4) and free field 1-5, a check digit,
+// free field 6-15, a check digit, free field 16-25, a check digit, the general check
+// digit, and the due factor and amount (14).
+func bankSlipLineToBarcode(line string) (string, error) {
+	for i, block := range []struct{ start, end int }{{0, 9}, {10, 20}, {21, 31}} {
+		if want := mod10(line[block.start:block.end]); line[block.end] != want {
+			return "", &CheckDigitError{
+				Field: fmt.Sprintf("field %d", i+1), Position: block.end + 1, Got: line[block.end], Want: want,
+			}
+		}
+	}
+	return line[:4] + line[32:47] + line[4:9] + line[10:20] + line[21:31], nil
+}
+
+// utilityLineToBarcode checks the block digits of a 48-digit line, four blocks of 11
+// barcode digits each followed by a check digit, and joins the blocks into a barcode
+func utilityLineToBarcode(line string) (string, error) {
+	if line[0] != '8' {
+		return "", fmt.Errorf("%w: 48-digit lines must start with 8", ErrInvalidLength)
+	}
+	checkDigit, err := utilityCheckDigit(line[2])
+	if err != nil {
+		return "", err
+	}
+
+	var barcode strings.Builder
+	for i := range 4 {
+		block := line[i*12 : i*12+11]
+		if want := checkDigit(block); line[i*12+11] != want {
+			return "", &CheckDigitError{
+				Field: fmt.Sprintf("field %d", i+1), Position: i*12 + 12, Got: line[i*12+11], Want: want,
+			}
+		}
+		barcode.WriteString(block)
+	}
+	return barcode.String(), nil
+}
+
+// utilityCheckDigit returns the check digit function selected by the value identifier:
+// module 10 for 6 and 7, module 11 for 8 and 9
+func utilityCheckDigit(valueID byte) (func(string) byte, error) {
+	switch valueID {
+	case '6', '7':
+		return mod10, nil
+	case '8', '9':
+		return utilityMod11, nil
+	default:
+		return nil, fmt.Errorf("%w: %c", ErrInvalidValueID, valueID)
+	}
+}
+
+// normalize removes separators and checks that only digits remain
+func normalize(s string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '.' || r == '-':
+		default:
+			return "", fmt.Errorf("%w: %q at position %d", ErrInvalidCharacter, r, i+1)
+		}
+	}
+	return b.String(), nil
+}
+
+// mod10 computes a module 10 check digit: digits are multiplied by 2, 1, 2, ... from the
+// right, products above 9 are replaced by the sum of their digits
+func mod10(digits string) byte {
+	sum := 0
+	for i := range len(digits) {
+		n := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// mod11Remainder returns the weighted sum of digits, with weights 2 to 9 from the right, modulo 11
+func mod11Remainder(digits string) int {
+	sum := 0
+	for i := range len(digits) {
+		sum += int(digits[len(digits)-1-i]-'0') * (2 + i%8)
+	}
+	return sum % 11
+}
+
+// bankSlipMod11 computes the general check digit of a bank slip, where 0, 10 and 11 become 1
+func bankSlipMod11(digits string) byte {
+	dv := 11 - mod11Remainder(digits)
+	if dv >= 10 {
+		return '1'
+	}
+	return byte('0' + dv)
+}
+
+// utilityMod11 computes a module 11 check digit of a utility bill, where remainders 0 and
+// 1 give 0 and remainder 10 gives 1
+func utilityMod11(digits string) byte {
+	rem := mod11Remainder(digits)
+	if rem <= 1 {
+		return '0'
+	}
+	return byte('0' + 11 - rem)
+}
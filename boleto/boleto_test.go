@@ -0,0 +1,123 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+const (
+	bankSlipBarcode = "00193373700000001000500940144816060680935031"
+	bankSlipLine    = "00190500954014481606906809350314337370000000100"
+	utilityBarcode  = "83620000000667800481001809756573100158963608"
+	utilityLine     = "836200000005667800481000180975657313001589636081"
+)
+
+func TestParseBankSlip(t *testing.T) {
+	for _, input := range []string{bankSlipBarcode, bankSlipLine, "00190.50095 40144.816069 06809.350314 3 37370000000100"} {
+		b, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %v", input, err)
+		}
+		if b.Kind != KindBankSlip || b.Barcode != bankSlipBarcode || b.DigitableLine != bankSlipLine {
+			t.Errorf("Parse(%q) = %s, %s, %s", input, b.Kind, b.Barcode, b.DigitableLine)
+		}
+		if b.BankCode != "001" || b.DueFactor != 3737 || b.Amount != types.Real || b.FreeField != "0500940144816060680935031" {
+			t.Errorf("Parse(%q) = %+v", input, *b)
+		}
+	}
+}
+
+func TestParseUtility(t *testing.T) {
+	for _, input := range []string{utilityBarcode, utilityLine, "836200000005 667800481000 180975657313 001589636081"} {
+		b, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %v", input, err)
+		}
+		if b.Kind != KindUtility || b.Barcode != utilityBarcode || b.DigitableLine != utilityLine {
+			t.Errorf("Parse(%q) = %s, %s, %s", input, b.Kind, b.Barcode, b.DigitableLine)
+		}
+		if b.Segment != 3 || b.ReferenceValue || b.Amount != types.MustParseMoney("66.78") || b.CompanyID != "0048" {
+			t.Errorf("Parse(%q) = %+v", input, *b)
+		}
+		if _, ok := b.DueDate(time.Now()); ok {
+			t.Error("utility bills have no due factor")
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     error
+		position int
+	}{
+		{"letter", "0019O", ErrInvalidCharacter, 0},
+		{"short", bankSlipBarcode[:43], ErrInvalidLength, 0},
+		{"barcode digit", "00194" + bankSlipBarcode[5:], ErrCheckDigit, 5},
+		{"line field 1", "00190500964014481606906809350314337370000000100", ErrCheckDigit, 10},
+		{"line field 3", "00190500954014481606906809350324337370000000100", ErrCheckDigit, 32},
+		{"line amount", "00190500954014481606906809350314337370000000200", ErrCheckDigit, 5},
+		{"currency", "0010" + bankSlipBarcode[4:], ErrInvalidCurrency, 0},
+		{"utility value id", "835" + utilityBarcode[3:], ErrInvalidValueID, 0},
+		{"utility field 2", "836200000005667800481001180975657313001589636081", ErrCheckDigit, 24},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: Parse = %v; want %v", tt.name, err, tt.want)
+			continue
+		}
+		var cdErr *CheckDigitError
+		if tt.position > 0 && (!errors.As(err, &cdErr) || cdErr.Position != tt.position) {
+			t.Errorf("%s: Parse = %v; want check digit error at position %d", tt.name, err, tt.position)
+		}
+	}
+}
+
+func TestUtilityMod11(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{"1", '9'},  // remainder 2
+		{"5", '1'},  // remainder 10
+		{"00", '0'}, // remainder 0
+		{"6", '0'},  // remainder 1
+	}
+
+	for _, tt := range tests {
+		if got := utilityMod11(tt.digits); got != tt.want {
+			t.Errorf("utilityMod11(%q) = %c; want %c", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestDueDateFromFactor(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	tests := []struct {
+		factor int
+		ref    string
+		want   string
+	}{
+		{1000, "2000-07-01", "2000-07-03"},
+		{9999, "2025-02-20", "2025-02-21"},
+		{1000, "2025-02-20", "2025-02-22"},
+		{1001, "2025-03-01", "2025-02-23"},
+		{9990, "2025-03-01", "2025-02-12"},
+		{3737, "2008-01-01", "2007-12-31"},
+	}
+
+	for _, tt := range tests {
+		if got := DueDateFromFactor(tt.factor, date(tt.ref)).Format(time.DateOnly); got != tt.want {
+			t.Errorf("DueDateFromFactor(%d, %s) = %s; want %s", tt.factor, tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidCharacter is returned for input with characters other than digits and the
+	// separators used in printed digitable lines (spaces, dots and hyphens)
+	ErrInvalidCharacter = errors.New("boleto: invalid character")
+
+	// ErrInvalidLength is returned for input that is not a 44-digit barcode, a 47-digit bank
+	// slip line or a 48-digit utility bill line
+	ErrInvalidLength = errors.New("boleto: invalid length")
+
+	// ErrCheckDigit is matched by the CheckDigitError returned when a check digit is wrong
+	ErrCheckDigit = errors.New("boleto: invalid check digit")
+
+	// ErrInvalidCurrency is returned for bank slips whose currency code is not 9 (real)
+	ErrInvalidCurrency = errors.New("boleto: invalid currency code")
+
+	// ErrInvalidValueID is returned for utility bills whose value identifier is not 6, 7, 8 or 9
+	ErrInvalidValueID = errors.New("boleto: invalid value identifier")
+)
+
+// CheckDigitError is returned when a check digit does not match the digits it protects,
+// usually because of a typo
+type CheckDigitError struct {
+	// Field names the check digit: "general" for the barcode digit, or "field 1" to
+	// "field 4" for the digits of the digitable line blocks
+	Field string
+
+	// Position is the 1-based position of the check digit in the input, ignoring separators
+	Position int
+
+	Got  byte
+	Want byte
+}
+
+// Error implements the error interface
+func (e *CheckDigitError) Error() string {
+	return fmt.Sprintf("%s: %s digit at position %d is %c, want %c", ErrCheckDigit, e.Field, e.Position, e.Got, e.Want)
+}
+
+// Unwrap returns ErrCheckDigit
+func (e *CheckDigitError) Unwrap() error {
+	return ErrCheckDigit
+}
@@ -0,0 +1,174 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/client"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/evertectest"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// offlineURL is a base URL that cannot be reached, to prove replays stay off the network
+const offlineURL = "https://api.invalid"
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "accounts.json")
+	ctx := context.Background()
+
+	srv := evertectest.NewServer(t)
+	payer := srv.AddAccount(evertectest.Account{Name: "Maria", Document: "52998224725", Balance: 100 * types.Real})
+	payee := srv.AddAccount(evertectest.Account{Name: "Loja", Document: "11222333000181"})
+
+	rec, err := New(path, ModeRecord, WithRealTransport(&http.Transport{TLSClientConfig: srv.ClientTLSConfig()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.New(srv.URL, srv.APIKey(), nil, client.WithTransport(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAccount(ctx, payer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InternalTransfer(ctx, payer, &types.InternalTransferRequest{RecipientAccountID: payee, TransferAmount: 2550}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{srv.APIKey(), "52998224725", "11222333000181"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	replay := func(t *testing.T, matcher Matcher) *client.Client {
+		t.Helper()
+		rec, err := New(path, ModeReplay, WithMatcher(matcher))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := client.New(offlineURL, "other-key", nil, client.WithTransport(rec), client.WithoutRetries())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		c := replay(t, Strict)
+		account, err := c.GetAccount(ctx, payer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.Name != "Maria" || account.Document != "***.982.247-**" {
+			t.Errorf("account = %+v", account)
+		}
+		if _, err := c.GetAccount(ctx, payer); !errors.Is(err, ErrNoInteraction) {
+			t.Errorf("second GetAccount = %v; want ErrNoInteraction", err)
+		}
+		_, err = c.InternalTransfer(ctx, payer, &types.InternalTransferRequest{RecipientAccountID: payee, TransferAmount: 100})
+		if !errors.Is(err, ErrNoInteraction) {
+			t.Errorf("InternalTransfer with another amount = %v; want ErrNoInteraction", err)
+		}
+		transfer, err := c.InternalTransfer(ctx, payer, &types.InternalTransferRequest{RecipientAccountID: payee, TransferAmount: 2550})
+		if err != nil || transfer.Amount != 2550 {
+			t.Errorf("InternalTransfer = %+v, %v", transfer, err)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		c := replay(t, Lenient)
+		if _, err := c.GetAccount(ctx, payer+1000); err != nil {
+			t.Errorf("GetAccount for another account = %v", err)
+		}
+		if _, err := c.InternalTransfer(ctx, payee, &types.InternalTransferRequest{RecipientAccountID: payer, TransferAmount: 1}); err != nil {
+			t.Errorf("InternalTransfer with another body = %v", err)
+		}
+	})
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("New = %v; want os.ErrNotExist", err)
+	}
+}
+
+func TestRecordRedactsResponseHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret-session"})
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "headers.json")
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rec.RoundTrip(httptest.NewRequest(http.MethodGet, srv.URL+"/accounts/1", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	header := rec.cassette.Interactions[0].Response.Header
+	if got := header.Get("Set-Cookie"); got != Redacted {
+		t.Errorf("Set-Cookie = %q; want %q", got, Redacted)
+	}
+	if got := header.Get("X-Request-Id"); got != "req-1" {
+		t.Errorf("X-Request-Id = %q; want it kept", got)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-session") {
+		t.Error("cassette contains the session cookie")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		value  string
+		quoted bool
+		want   string
+	}{
+		{`{"pan":"4111111111111111","ts":1700000000003}`, true, `{"pan":"************1111","ts":1700000000003}`},
+		{`{"pan":"4111111111111112"}`, true, `{"pan":"4111111111111112"}`},
+		{`{"document":"52998224725"}`, true, `{"document":"***.982.247-**"}`},
+		{`{"barcode":"00193373700000001000500940144816060680935031"}`, true, `{"barcode":"00193373700000001000500940144816060680935031"}`},
+		{"/cards/5555555555554444/block", false, "/cards/************4444/block"},
+		{"document=529.982.247-25&max=50", false, "document=***.982.247-**&max=50"},
+	}
+
+	for _, tt := range tests {
+		if got := redact(tt.value, tt.quoted); got != tt.want {
+			t.Errorf("redact(%q) = %q; want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestStrictIgnoresFormatting(t *testing.T) {
+	a := &Request{Method: "GET", Path: "/x", Query: "a=1&b=2", Body: []byte(`{"a":1,"b":[2]}`)}
+	b := &Request{Method: "GET", Path: "/x", Query: "b=2&a=1", Body: []byte(`{ "b": [2], "a": 1 }`)}
+	if !Strict(a, b) {
+		t.Error("Strict = false; want true")
+	}
+}
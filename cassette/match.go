@@ -0,0 +1,49 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"reflect"
+)
+
+// Matcher reports whether an incoming request, redacted as it would be recorded,
+// matches a recorded one
+type Matcher func(req, recorded *Request) bool
+
+// Strict matches requests with the same method, path, query and body. Query parameter
+// order and JSON formatting and key order are ignored.
+func Strict(req, recorded *Request) bool {
+	return req.Method == recorded.Method &&
+		req.Path == recorded.Path &&
+		sameQuery(req.Query, recorded.Query) &&
+		sameBody(joinBody(req.Body, req.RawBody), joinBody(recorded.Body, recorded.RawBody))
+}
+
+// Lenient matches requests with the same method and templated path, so a cassette
+// recorded for one account or transaction also answers requests for another
+func Lenient(req, recorded *Request) bool {
+	return req.Method == recorded.Method && req.Route == recorded.Route
+}
+
+// sameQuery compares query strings as parameter sets
+func sameQuery(a, b string) bool {
+	if a == b {
+		return true
+	}
+	va, errA := url.ParseQuery(a)
+	vb, errB := url.ParseQuery(b)
+	return errA == nil && errB == nil && reflect.DeepEqual(va, vb)
+}
+
+// sameBody compares JSON bodies by value and other bodies byte by byte
+func sameBody(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,204 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/internal/route"
+)
+
+// ErrNoInteraction is returned in replay mode when no unused recorded interaction
+// matches a request
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// Mode selects whether a Recorder records or replays traffic
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette file, without network access
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real API and records them
+	ModeRecord
+)
+
+// String returns the mode name
+func (m Mode) String() string {
+	if m == ModeRecord {
+		return "record"
+	}
+	return "replay"
+}
+
+// Option is a functional option for configuring a Recorder
+type Option func(*Recorder)
+
+// WithRealTransport sets the transport used to reach the API in ModeRecord, typically an
+// *http.Transport carrying the mTLS configuration (defaults to http.DefaultTransport)
+func WithRealTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatcher sets how requests are matched to recorded interactions in ModeReplay
+// (defaults to Strict)
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette. Install it with
+// client.WithTransport. It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette file at path. In ModeReplay the file is loaded
+// and must exist; in ModeRecord it is overwritten by Stop.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   Strict,
+		cassette:  &Cassette{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop saves the recorded interactions in ModeRecord. It does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read request body: %w", err)
+	}
+	recorded := newRequest(req, body)
+
+	if r.mode == ModeRecord {
+		return r.record(req, body, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// record forwards the request to the API and appends the interaction to the cassette
+func (r *Recorder) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	// The stored body is reindented, so its original length no longer applies
+	response := Response{StatusCode: resp.StatusCode, Header: redactHeader(resp.Header)}
+	response.Header.Del("Content-Length")
+	response.Body, response.RawBody = splitBody([]byte(redact(string(data), true)))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers the request with the first unused matching interaction
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if r.used[i] || !r.matcher(&recorded, &interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body := joinBody(interaction.Response.Body, interaction.Response.RawBody)
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+}
+
+// readBody reads and closes the request body, if any
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// newRequest returns the redacted form of a request, as stored in a cassette
+func newRequest(req *http.Request, body []byte) Request {
+	path := redact(req.URL.Path, false)
+	recorded := Request{
+		Method: req.Method,
+		Path:   path,
+		Route:  route.Template(path),
+		Query:  redact(req.URL.RawQuery, false),
+		Header: redactHeader(req.Header),
+	}
+	recorded.Body, recorded.RawBody = splitBody([]byte(redact(strings.TrimSpace(string(body)), true)))
+	return recorded
+}
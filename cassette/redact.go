@@ -0,0 +1,88 @@
+package cassette
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// Redacted replaces the values of sensitive headers in cassettes
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are request and response headers whose values are never written to a cassette
+var sensitiveHeaders = []string{"X-Api-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+// digitRun matches runs of digits that may hold a card PAN
+var digitRun = regexp.MustCompile(`\d{13,}`)
+
+// redact masks documents and card PANs in a path, query or body. In bodies (quoted
+// true) only PANs in JSON strings are masked, so numbers such as timestamps are kept.
+func redact(s string, quoted bool) string {
+	s = types.RedactDocuments(s)
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range digitRun.FindAllStringIndex(s, -1) {
+		start, end := loc[0], loc[1]
+		if !isPAN(s[start:end]) || !bounded(s, start, end, quoted) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(strings.Repeat("*", end-start-4))
+		b.WriteString(s[end-4 : end])
+		last = end
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// bounded reports whether the digits at s[start:end] stand alone: delimited by quotes in
+// a JSON body, or by any non-alphanumeric character in a path or query
+func bounded(s string, start, end int, quoted bool) bool {
+	if quoted {
+		return start > 0 && end < len(s) && s[start-1] == '"' && s[end] == '"'
+	}
+	return (start == 0 || !isAlnum(s[start-1])) && (end == len(s) || !isAlnum(s[end]))
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isPAN reports whether digits have the length of a card PAN and a valid Luhn check digit
+func isPAN(digits string) bool {
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// redactHeader returns a copy of h with sensitive values replaced
+func redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = []string{Redacted}
+		}
+	}
+	return out
+}
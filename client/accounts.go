@@ -107,8 +107,8 @@ func (c *Client) GetTransactionDetails(ctx context.Context, accountID, transacti
 }
 
 // GetCorporateAccounts retrieves corporate accounts by document
-func (c *Client) GetCorporateAccounts(ctx context.Context, document string) (*types.CorporateAccountsResponse, error) {
-	path := fmt.Sprintf("/accounts/%s/corporate", document)
+func (c *Client) GetCorporateAccounts(ctx context.Context, document types.Document) (*types.CorporateAccountsResponse, error) {
+	path := fmt.Sprintf("/accounts/%s/corporate", document.Canonical())
 	var response types.CorporateAccountsResponse
 	if err := c.get(ctx, path, &response); err != nil {
 		return nil, err
@@ -137,7 +137,7 @@ func TestCreateAccount(t *testing.T) {
 	requestData := types.ProposalAccountRequest{
 		PersonalName:           "New User",
 		PersonalEmail:          "newuser@example.com",
-		PersonalDocument:       "12345678901",
+		PersonalDocument:       "52998224725",
 		BirthDate:              "1990-01-01",
 		MotherName:             "Test Mother",
 		ProductID:              1,
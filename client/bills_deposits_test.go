@@ -17,13 +17,13 @@ import (
 func TestPayBill(t *testing.T) {
 	requestData := &types.BillPaymentRequest{
 		Barcode: "34191790010104351004791020150008291070026000",
-		Amount:  intPtr(5000),
+		Amount:  centsPtr(5000),
 	}
 
 	responseData := &types.BillPaymentResponse{
 		Message:            "Payment processed successfully",
 		AuthenticationCode: strPtr("AUTH123456"),
-		AmountPaid:         intPtr(5000),
+		AmountPaid:         centsPtr(5000),
 		PaymentDate:        timePtr(time.Now()),
 	}
 
@@ -68,7 +68,7 @@ func TestPayBill(t *testing.T) {
 func TestPayBillBatch(t *testing.T) {
 	requestData := &types.BillPaymentRequest{
 		Barcode: "34191790010104351004791020150008291070026000",
-		Amount:  intPtr(5000),
+		Amount:  centsPtr(5000),
 	}
 
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -111,7 +111,7 @@ func TestGetBillInfo(t *testing.T) {
 		DueDate:       "2024-12-31",
 		Assignor:      strPtr("Test Company"),
 		Payer:         strPtr("John Doe"),
-		Amount:        intPtr(5000),
+		Amount:        centsPtr(5000),
 	}
 
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -253,7 +253,7 @@ func TestPayBillByAccount(t *testing.T) {
 	accountID := int64(12345)
 	requestData := &types.BillPaymentRequest{
 		Barcode: "34191790010104351004791020150008291070026000",
-		Amount:  intPtr(5000),
+		Amount:  centsPtr(5000),
 	}
 
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -368,7 +368,7 @@ func TestPayBillBatchByAccount(t *testing.T) {
 		Payments: []types.BillPaymentRequest{
 			{
 				Barcode: "34191790010104351004791020150008291070026000",
-				Amount:  intPtr(5000),
+				Amount:  centsPtr(5000),
 			},
 		},
 	}
@@ -914,7 +914,7 @@ func TestCreateBankslipV2(t *testing.T) {
 		AccountID:     12345,
 		Amount:        5000,
 		DueDate:       "2024-12-31",
-		PayerDocument: "12345678901",
+		PayerDocument: "52998224725",
 		PayerName:     "John Doe",
 		Description:   strPtr("Payment for services"),
 		Instructions:  strPtr("Pay before due date"),
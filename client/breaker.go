@@ -0,0 +1,370 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/internal/route"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+)
+
+const (
+	// DefaultCircuitFailureRatio is the default fraction of failed requests that opens a circuit
+	DefaultCircuitFailureRatio = 0.5
+
+	// DefaultCircuitMinRequests is the default number of requests needed before a circuit can open
+	DefaultCircuitMinRequests = 10
+
+	// DefaultCircuitWindow is the default period over which requests are counted
+	DefaultCircuitWindow = time.Minute
+
+	// DefaultCircuitCoolDown is the default time a circuit stays open before probing
+	DefaultCircuitCoolDown = 30 * time.Second
+)
+
+// CircuitState is the state of the circuit breaker of an endpoint group
+type CircuitState = observability.CircuitState
+
+// Circuit breaker states
+const (
+	CircuitClosed   = observability.CircuitClosed
+	CircuitOpen     = observability.CircuitOpen
+	CircuitHalfOpen = observability.CircuitHalfOpen
+)
+
+// CircuitHook is implemented by hooks that observe circuit breaker transitions
+type CircuitHook = observability.CircuitHook
+
+// CircuitStateChange describes a circuit breaker transition passed to CircuitHook
+type CircuitStateChange = observability.CircuitStateChange
+
+// EndpointGroup identifies a set of API endpoints sharing a circuit breaker
+type EndpointGroup string
+
+// Endpoint groups
+const (
+	EndpointGroupPix        EndpointGroup = "pix"
+	EndpointGroupDICT       EndpointGroup = "dict" // PIX key lookups in the DICT
+	EndpointGroupTransfers  EndpointGroup = "transfers"
+	EndpointGroupStatements EndpointGroup = "statements" // statements and balances
+	EndpointGroupCards      EndpointGroup = "cards"
+	EndpointGroupBills      EndpointGroup = "bills"
+	EndpointGroupBackoffice EndpointGroup = "backoffice"
+	EndpointGroupOther      EndpointGroup = "other"
+)
+
+// CircuitBreakerPolicy configures the circuit breakers that fast-fail requests to an
+// endpoint group while the API is failing. Start from DefaultCircuitBreakerPolicy and
+// adjust fields as needed.
+//
+// Only transport errors (including timeouts) and 5xx responses count as failures;
+// 4xx responses such as business rule errors count as successes.
+type CircuitBreakerPolicy struct {
+	// FailureRatio is the fraction (0 to 1) of failed requests in a window that opens the circuit
+	FailureRatio float64
+
+	// MinRequests is the number of requests a window needs before FailureRatio is evaluated
+	MinRequests int
+
+	// Window is the period over which requests are counted while the circuit is closed
+	Window time.Duration
+
+	// CoolDown is how long an open circuit rejects requests before letting a probe through
+	CoolDown time.Duration
+
+	// HalfOpenRequests is the number of probes that must succeed to close the circuit
+	// again (defaults to 1). A failed probe opens it for another CoolDown.
+	HalfOpenRequests int
+
+	// Groups limits the breakers to some endpoint groups; nil protects all of them
+	Groups []EndpointGroup
+}
+
+// DefaultCircuitBreakerPolicy returns the policy used by WithCircuitBreaker with zero fields
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureRatio:     DefaultCircuitFailureRatio,
+		MinRequests:      DefaultCircuitMinRequests,
+		Window:           DefaultCircuitWindow,
+		CoolDown:         DefaultCircuitCoolDown,
+		HalfOpenRequests: 1,
+	}
+}
+
+// CircuitOpenError is returned without sending the request while the circuit of its
+// endpoint group is open
+type CircuitOpenError struct {
+	Group EndpointGroup
+	// RetryAfter is the time left until the circuit lets a probe through
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s endpoints (retry in %s)", e.Group, e.RetryAfter.Round(time.Second))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// CircuitState returns the state of the circuit breaker of an endpoint group for calls
+// made without a tenant. It is CircuitClosed when no breaker is configured for the group.
+func (c *Client) CircuitState(group EndpointGroup) CircuitState {
+	return c.TenantCircuitState("", group)
+}
+
+// TenantCircuitState returns the state of the circuit breaker of an endpoint group for
+// calls made for a tenant set with WithTenant. Each tenant has its own breakers.
+func (c *Client) TenantCircuitState(tenant string, group EndpointGroup) CircuitState {
+	if c.breakers == nil {
+		return CircuitClosed
+	}
+	if b := c.breakers.get(tenant, group); b != nil {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.state
+	}
+	return CircuitClosed
+}
+
+// onCircuitStateChange logs a circuit transition and reports it to metrics and hooks
+func (c *Client) onCircuitStateChange(ctx context.Context, change CircuitStateChange) {
+	c.logger(ctx).Warn("Circuit breaker state changed",
+		"group", change.Group,
+		"from", change.From,
+		"to", change.To,
+	)
+	if c.metrics != nil {
+		c.metrics.RecordCircuitStateChange(ctx, change)
+	}
+	for _, hook := range c.config.Hooks {
+		if h, ok := hook.(CircuitHook); ok {
+			h.OnCircuitStateChange(ctx, change)
+		}
+	}
+}
+
+// endpointGroup returns the endpoint group of a route template
+func endpointGroup(routeTemplate string) EndpointGroup {
+	segments := strings.Split(strings.Trim(routeTemplate, "/"), "/")
+	// Account-scoped routes are grouped by what follows /accounts/{id}
+	if segments[0] == "accounts" {
+		segments = segments[1:]
+		if len(segments) > 0 && segments[0] == route.Param {
+			segments = segments[1:]
+		}
+	}
+	if len(segments) == 0 {
+		return EndpointGroupOther
+	}
+
+	switch segments[0] {
+	case "pix":
+		if len(segments) > 1 && segments[1] == "keys" {
+			return EndpointGroupDICT
+		}
+		return EndpointGroupPix
+	case "qrcode", "createKey", "deleteKey", "getKeys", "createClaim", "getRequestedClaims",
+		"cancelPortability", "completePortability", "confirmPortability":
+		return EndpointGroupPix
+	case "transfer", "transfers", "banktransfer", "cancelTransfer":
+		return EndpointGroupTransfers
+	case "statement", "balance", "dailyStatement":
+		return EndpointGroupStatements
+	case "cards":
+		return EndpointGroupCards
+	case "postpaid":
+		if len(segments) > 1 && segments[1] == "cards" {
+			return EndpointGroupCards
+		}
+	case "bill", "billpayment", "bankslip":
+		return EndpointGroupBills
+	case "backoffice":
+		return EndpointGroupBackoffice
+	}
+	return EndpointGroupOther
+}
+
+// circuitBreakers holds one breaker per tenant and protected endpoint group
+type circuitBreakers struct {
+	policy   CircuitBreakerPolicy
+	notify   func(context.Context, CircuitStateChange)
+	now      func() time.Time
+	mu       sync.Mutex
+	breakers map[breakerKey]*circuitBreaker
+}
+
+// breakerKey identifies the breaker of an endpoint group for a tenant ("" without one)
+type breakerKey struct {
+	tenant string
+	group  EndpointGroup
+}
+
+// newCircuitBreakers returns breakers for the policy, filling zero fields with defaults
+func newCircuitBreakers(policy CircuitBreakerPolicy, notify func(context.Context, CircuitStateChange)) *circuitBreakers {
+	defaults := DefaultCircuitBreakerPolicy()
+	if policy.FailureRatio <= 0 {
+		policy.FailureRatio = defaults.FailureRatio
+	}
+	if policy.MinRequests <= 0 {
+		policy.MinRequests = defaults.MinRequests
+	}
+	if policy.Window <= 0 {
+		policy.Window = defaults.Window
+	}
+	if policy.CoolDown <= 0 {
+		policy.CoolDown = defaults.CoolDown
+	}
+	if policy.HalfOpenRequests <= 0 {
+		policy.HalfOpenRequests = defaults.HalfOpenRequests
+	}
+	return &circuitBreakers{
+		policy:   policy,
+		notify:   notify,
+		now:      time.Now,
+		breakers: make(map[breakerKey]*circuitBreaker),
+	}
+}
+
+// get returns the breaker of a tenant's group, or nil if the group is not protected
+func (cb *circuitBreakers) get(tenant string, group EndpointGroup) *circuitBreaker {
+	if cb.policy.Groups != nil && !slices.Contains(cb.policy.Groups, group) {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	key := breakerKey{tenant: tenant, group: group}
+	b, ok := cb.breakers[key]
+	if !ok {
+		b = &circuitBreaker{tenant: tenant, group: group, set: cb, state: CircuitClosed, windowStart: cb.now()}
+		cb.breakers[key] = b
+	}
+	return b
+}
+
+// circuitBreaker is the state machine of one endpoint group of a tenant
+type circuitBreaker struct {
+	tenant string
+	group  EndpointGroup
+	set    *circuitBreakers
+
+	mu          sync.Mutex
+	state       CircuitState
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+}
+
+// allow reports whether a request may be sent, returning the generation to pass to done
+func (b *circuitBreaker) allow(ctx context.Context) (uint64, error) {
+	var change *CircuitStateChange
+	defer func() { b.set.report(ctx, change) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	policy := b.set.policy
+	now := b.set.now()
+	switch b.state {
+	case CircuitOpen:
+		if wait := b.openedAt.Add(policy.CoolDown).Sub(now); wait > 0 {
+			return 0, &CircuitOpenError{Group: b.group, RetryAfter: wait}
+		}
+		change = b.transition(CircuitHalfOpen, now)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probes >= policy.HalfOpenRequests {
+			return 0, &CircuitOpenError{Group: b.group}
+		}
+		b.probes++
+	default:
+		if now.Sub(b.windowStart) >= policy.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	}
+	return b.generation, nil
+}
+
+// done records the outcome of a request allowed in the given generation.
+// Outcomes of requests sent before the last transition are discarded.
+func (b *circuitBreaker) done(ctx context.Context, generation uint64, result *ResponseInfo) {
+	// Cancellations by the caller say nothing about the API
+	ignored := errors.Is(result.Err, context.Canceled)
+	failed := !ignored && (result.StatusCode == 0 || result.StatusCode >= 500)
+
+	var change *CircuitStateChange
+	defer func() { b.set.report(ctx, change) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation != b.generation {
+		return
+	}
+
+	policy := b.set.policy
+	now := b.set.now()
+	switch b.state {
+	case CircuitHalfOpen:
+		b.probes--
+		switch {
+		case failed:
+			change = b.transition(CircuitOpen, now)
+		case !ignored:
+			b.successes++
+			if b.successes >= policy.HalfOpenRequests {
+				change = b.transition(CircuitClosed, now)
+			}
+		}
+	case CircuitClosed:
+		if ignored {
+			return
+		}
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= policy.MinRequests && float64(b.failures) >= policy.FailureRatio*float64(b.requests) {
+			change = b.transition(CircuitOpen, now)
+		}
+	}
+}
+
+// release frees the probe of a request allowed in the given generation that was
+// aborted before done, such as by a panicking hook, without recording an outcome
+func (b *circuitBreaker) release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation == b.generation && b.state == CircuitHalfOpen {
+		b.probes--
+	}
+}
+
+// transition moves the breaker to a new state and resets its counters; b.mu must be held.
+// The returned change is reported by the caller once the lock is released.
+func (b *circuitBreaker) transition(to CircuitState, now time.Time) *CircuitStateChange {
+	change := &CircuitStateChange{Group: string(b.group), Tenant: b.tenant, From: b.state, To: to}
+	b.state = to
+	b.generation++
+	b.windowStart, b.requests, b.failures = now, 0, 0
+	b.probes, b.successes = 0, 0
+	if to == CircuitOpen {
+		b.openedAt = now
+	}
+	return change
+}
+
+// report passes a state change, if any, to the notify function
+func (cb *circuitBreakers) report(ctx context.Context, change *CircuitStateChange) {
+	if change != nil && cb.notify != nil {
+		cb.notify(ctx, *change)
+	}
+}
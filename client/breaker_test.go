@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// circuitRecorder is a hook that records circuit breaker transitions
+type circuitRecorder struct {
+	NoOpHook
+	mu      sync.Mutex
+	changes []CircuitStateChange
+}
+
+func (h *circuitRecorder) OnCircuitStateChange(ctx context.Context, change CircuitStateChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.changes = append(h.changes, change)
+}
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		path string
+		want EndpointGroup
+	}{
+		{"/pix/transactions/payment", EndpointGroupPix},
+		{"/accounts/12345/createKey", EndpointGroupPix},
+		{"/accounts/12345/pix/limit", EndpointGroupPix},
+		{"/accounts/pix/claim/list", EndpointGroupPix},
+		{"/accounts/12345/transfer/batch", EndpointGroupTransfers},
+		{"/accounts/12345/banktransfer", EndpointGroupTransfers},
+		{"/accounts/12345/cards/67890/block", EndpointGroupCards},
+		{"/postpaid/cards/1/block/2", EndpointGroupCards},
+		{"/bill/payment", EndpointGroupBills},
+		{"/accounts/12345/bankslip", EndpointGroupBills},
+		{"/backoffice/issuer/balance", EndpointGroupBackoffice},
+		{"/pix/keys/12345/user@email.com", EndpointGroupDICT},
+		{"/accounts/12345/statement", EndpointGroupStatements},
+		{"/accounts/12345/balance", EndpointGroupStatements},
+		{"/accounts/balance/12345", EndpointGroupStatements},
+		{"/dailyStatement/list", EndpointGroupStatements},
+		{"/accounts/12345", EndpointGroupOther},
+		{"/postpaid/statements/1", EndpointGroupOther},
+	}
+
+	for _, tt := range tests {
+		if got := endpointGroup(routeTemplate(tt.path)); got != tt.want {
+			t.Errorf("endpointGroup(%q) = %q; want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"message":"status"}`))
+	}))
+	defer server.Close()
+
+	hook := &circuitRecorder{}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithHooks(hook), WithCircuitBreaker(CircuitBreakerPolicy{
+		MinRequests: 4,
+		CoolDown:    time.Minute,
+	}))
+	now := time.Now()
+	c.breakers.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 4 {
+		if err := c.get(ctx, "/pix/psps", nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("get = %v before the circuit opened", err)
+		}
+	}
+	if got := c.CircuitState(EndpointGroupPix); got != CircuitOpen {
+		t.Fatalf("state = %s; want open", got)
+	}
+
+	err := c.get(ctx, "/pix/psps", nil)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Group != EndpointGroupPix || openErr.RetryAfter != time.Minute {
+		t.Errorf("get = %v; want CircuitOpenError for pix", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("server got %d calls; want 4", calls.Load())
+	}
+	if err := c.get(ctx, "/accounts/1/cards", nil); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("cards request = %v; want it sent", err)
+	}
+
+	// After the cool-down a failed probe opens the circuit again, a successful one closes it
+	now = now.Add(time.Minute)
+	if err := c.get(ctx, "/pix/psps", nil); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("probe = %v; want it sent", err)
+	}
+	if got := c.CircuitState(EndpointGroupPix); got != CircuitOpen {
+		t.Errorf("state after failed probe = %s; want open", got)
+	}
+	now = now.Add(time.Minute)
+	status.Store(http.StatusOK)
+	if err := c.get(ctx, "/pix/psps", nil); err != nil {
+		t.Errorf("probe = %v", err)
+	}
+	if got := c.CircuitState(EndpointGroupPix); got != CircuitClosed {
+		t.Errorf("state after successful probe = %s; want closed", got)
+	}
+
+	want := []CircuitStateChange{
+		{Group: "pix", From: CircuitClosed, To: CircuitOpen},
+		{Group: "pix", From: CircuitOpen, To: CircuitHalfOpen},
+		{Group: "pix", From: CircuitHalfOpen, To: CircuitOpen},
+		{Group: "pix", From: CircuitOpen, To: CircuitHalfOpen},
+		{Group: "pix", From: CircuitHalfOpen, To: CircuitClosed},
+	}
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.changes) != len(want) {
+		t.Fatalf("changes = %+v; want %+v", hook.changes, want)
+	}
+	for i := range want {
+		if hook.changes[i] != want[i] {
+			t.Errorf("change %d = %+v; want %+v", i, hook.changes[i], want[i])
+		}
+	}
+}
+
+func TestCircuitBreakerIgnoresBusinessErrors(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"LIMIT_EXCEEDED","message":"daily limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithCircuitBreaker(CircuitBreakerPolicy{MinRequests: 2}))
+	for range 5 {
+		if err := c.post(context.Background(), "/pix/transactions/payment", nil, nil); !errors.Is(err, ErrBusinessRule) {
+			t.Fatalf("post = %v; want ErrBusinessRule", err)
+		}
+	}
+	if got := c.CircuitState(EndpointGroupPix); got != CircuitClosed {
+		t.Errorf("state = %s; want closed", got)
+	}
+}
+
+func TestCircuitBreakerStopsRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 5
+	policy.BaseDelay = time.Millisecond
+	c := newRetryTestClient(t, server, policy, WithCircuitBreaker(CircuitBreakerPolicy{
+		MinRequests: 2,
+		Groups:      []EndpointGroup{EndpointGroupOther},
+	}))
+
+	err := c.get(context.Background(), "/accounts/1", nil)
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, ErrAPI) {
+		t.Errorf("get = %v; want the last API error joined with ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server got %d calls; want 2", calls.Load())
+	}
+	if c.breakers.get("", EndpointGroupPix) != nil {
+		t.Error("pix group has a breaker; want only the configured groups")
+	}
+}
+
+// panicToggleHook panics in BeforeRequest while enabled
+type panicToggleHook struct {
+	NoOpHook
+	enabled atomic.Bool
+}
+
+func (h *panicToggleHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
+	if h.enabled.Load() {
+		panic("hook failure")
+	}
+	return ctx
+}
+
+func TestCircuitBreakerReleasesAbortedProbe(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	hook := &panicToggleHook{}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithHooks(hook), WithCircuitBreaker(CircuitBreakerPolicy{
+		MinRequests: 1,
+		CoolDown:    time.Minute,
+	}))
+	now := time.Now()
+	c.breakers.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := c.get(ctx, "/accounts/1/statement", nil); !errors.Is(err, ErrAPI) {
+		t.Fatalf("get = %v; want ErrAPI", err)
+	}
+	if got := c.CircuitState(EndpointGroupStatements); got != CircuitOpen {
+		t.Fatalf("state = %s; want open", got)
+	}
+
+	// The probe panics in a hook; it must not keep the half-open slot
+	now = now.Add(time.Minute)
+	hook.enabled.Store(true)
+	if err := c.get(ctx, "/accounts/1/statement", nil); !errors.Is(err, ErrPanic) {
+		t.Fatalf("probe = %v; want ErrPanic", err)
+	}
+	hook.enabled.Store(false)
+	status.Store(http.StatusOK)
+	if err := c.get(ctx, "/accounts/1/statement", nil); err != nil {
+		t.Fatalf("probe after panic = %v; want it sent", err)
+	}
+	if got := c.CircuitState(EndpointGroupStatements); got != CircuitClosed {
+		t.Errorf("state = %s; want closed", got)
+	}
+}
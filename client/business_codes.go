@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"strings"
+)
+
+//go:generate go run ../internal/codegen/businesscodes -in business_codes.json -out business_codes_gen.go
+
+// CodeSource is the system that defines a business error code
+type CodeSource string
+
+// Code sources
+const (
+	// CodeSourceEvertec marks codes defined by the Evertec API
+	CodeSourceEvertec CodeSource = "evertec"
+	// CodeSourceDICT marks error types of the BACEN DICT (PIX key directory), passed through by Evertec
+	CodeSourceDICT CodeSource = "dict"
+	// CodeSourceSPI marks rejection reasons of the BACEN SPI (instant payment settlement)
+	CodeSourceSPI CodeSource = "spi"
+)
+
+// BusinessCode is a sentinel for a family of business error codes, such as
+// ErrPixKeyAlreadyRegistered. Use it with errors.Is on any error returned by the client:
+//
+//	if errors.Is(err, client.ErrPixKeyAlreadyRegistered) { ... }
+//
+// The catalog is generated from business_codes.json.
+type BusinessCode struct {
+	// Name is a stable snake_case identifier, e.g. "pix_key_already_registered"
+	Name string
+	// PT is the description in Portuguese
+	PT string
+	// EN is the description in English
+	EN string
+	// Retryable reports whether the same request may succeed later; otherwise the error is permanent
+	Retryable bool
+}
+
+func (c *BusinessCode) Error() string {
+	return c.EN
+}
+
+// businessCodeEntry is a known code and its sentinel
+type businessCodeEntry struct {
+	sentinel *BusinessCode
+	source   CodeSource
+}
+
+// LookupBusinessCode returns the sentinel and source of a business code returned by the API.
+// DICT error types given as URIs (".../error/EntryAlreadyExists") are accepted.
+func LookupBusinessCode(code string) (*BusinessCode, CodeSource, bool) {
+	if i := strings.LastIndexByte(code, '/'); i >= 0 {
+		code = code[i+1:]
+	}
+	entry, ok := businessCodes[code]
+	if !ok {
+		return nil, "", false
+	}
+	return entry.sentinel, entry.source, true
+}
+
+// BusinessCodeOf returns the catalog sentinel matching the code of a typed API error in err's chain
+func BusinessCodeOf(err error) (*BusinessCode, bool) {
+	var coded codedError
+	if !errors.As(err, &coded) {
+		return nil, false
+	}
+	sentinel, _, ok := LookupBusinessCode(coded.errorCode())
+	return sentinel, ok
+}
+
+// codedError is implemented by typed API errors that carry a business code
+type codedError interface {
+	error
+	errorCode() string
+}
+
+// isBusinessCode reports whether target is the catalog sentinel of code
+func isBusinessCode(code string, target error) bool {
+	want, ok := target.(*BusinessCode)
+	if !ok || code == "" {
+		return false
+	}
+	sentinel, _, ok := LookupBusinessCode(code)
+	return ok && sentinel == want
+}
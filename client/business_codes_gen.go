@@ -0,0 +1,70 @@
+// Code generated by internal/codegen/businesscodes from business_codes.json; DO NOT EDIT.
+
+package client
+
+// Business code sentinels. Each matches, with errors.Is, any typed API error whose
+// code is one of the Evertec, DICT or SPI codes listed for it.
+var (
+	// ErrPixKeyAlreadyRegistered matches codes PIX_KEY_ALREADY_EXISTS, EntryAlreadyExists, ClaimResultingEntryAlreadyExists
+	ErrPixKeyAlreadyRegistered = &BusinessCode{Name: "pix_key_already_registered", PT: "Chave PIX já registrada", EN: "PIX key already registered", Retryable: false}
+	// ErrPixKeyOwnedByOtherPSP matches codes EntryKeyInCustodyOfDifferentParticipant
+	ErrPixKeyOwnedByOtherPSP = &BusinessCode{Name: "pix_key_owned_by_other_psp", PT: "Chave PIX registrada em outro participante; é necessária uma reivindicação de portabilidade", EN: "PIX key registered with another participant; a portability claim is required", Retryable: false}
+	// ErrPixKeyOwnedByOtherPerson matches codes EntryKeyOwnedByDifferentPerson
+	ErrPixKeyOwnedByOtherPerson = &BusinessCode{Name: "pix_key_owned_by_other_person", PT: "Chave PIX pertence a outra pessoa; é necessária uma reivindicação de posse", EN: "PIX key owned by another person; an ownership claim is required", Retryable: false}
+	// ErrPixKeyLimitExceeded matches codes PIX_KEY_LIMIT_EXCEEDED, EntryLimitExceeded
+	ErrPixKeyLimitExceeded = &BusinessCode{Name: "pix_key_limit_exceeded", PT: "Limite de chaves PIX da conta atingido", EN: "PIX key limit for the account reached", Retryable: false}
+	// ErrPixKeyLockedByClaim matches codes EntryLockedByClaim
+	ErrPixKeyLockedByClaim = &BusinessCode{Name: "pix_key_locked_by_claim", PT: "Chave PIX bloqueada por reivindicação em andamento", EN: "PIX key locked by an ongoing claim", Retryable: false}
+	// ErrDailyLimitExceeded matches codes LIMIT_EXCEEDED, DAILY_LIMIT_EXCEEDED
+	ErrDailyLimitExceeded = &BusinessCode{Name: "daily_limit_exceeded", PT: "Limite diário de transações excedido", EN: "Daily transaction limit exceeded", Retryable: false}
+	// ErrNightLimitExceeded matches codes NIGHT_LIMIT_EXCEEDED
+	ErrNightLimitExceeded = &BusinessCode{Name: "night_limit_exceeded", PT: "Limite noturno de transações excedido", EN: "Nighttime transaction limit exceeded", Retryable: false}
+	// ErrAccountBlocked matches codes ACCOUNT_BLOCKED, AC06
+	ErrAccountBlocked = &BusinessCode{Name: "account_blocked", PT: "Conta bloqueada", EN: "Account blocked", Retryable: false}
+	// ErrAccountClosed matches codes ACCOUNT_CLOSED, AC07
+	ErrAccountClosed = &BusinessCode{Name: "account_closed", PT: "Conta encerrada", EN: "Account closed", Retryable: false}
+	// ErrInvalidRecipientAccount matches codes AC03, AC14
+	ErrInvalidRecipientAccount = &BusinessCode{Name: "invalid_recipient_account", PT: "Agência ou conta do recebedor inválida", EN: "Invalid recipient branch or account", Retryable: false}
+	// ErrRecipientDocumentMismatch matches codes BE01
+	ErrRecipientDocumentMismatch = &BusinessCode{Name: "recipient_document_mismatch", PT: "CPF/CNPJ não corresponde ao titular da conta do recebedor", EN: "CPF/CNPJ does not match the recipient account holder", Retryable: false}
+	// ErrSettlementTimeout matches codes AB03, AB09, AB11
+	ErrSettlementTimeout = &BusinessCode{Name: "settlement_timeout", PT: "Liquidação não concluída por indisponibilidade ou timeout no SPI ou no PSP recebedor", EN: "Settlement not completed due to an SPI or recipient PSP outage or timeout", Retryable: true}
+	// ErrDuplicateAccount matches codes DUPLICATE_ACCOUNT
+	ErrDuplicateAccount = &BusinessCode{Name: "duplicate_account", PT: "Já existe uma conta para este documento", EN: "An account already exists for this document", Retryable: false}
+	// ErrCardAlreadyActive matches codes CARD_ALREADY_ACTIVE
+	ErrCardAlreadyActive = &BusinessCode{Name: "card_already_active", PT: "Cartão já está ativo", EN: "Card is already active", Retryable: false}
+	// ErrCardNotActive matches codes CARD_NOT_ACTIVE
+	ErrCardNotActive = &BusinessCode{Name: "card_not_active", PT: "Cartão não está ativo", EN: "Card is not active", Retryable: false}
+	// ErrInvalidBarcode matches codes INVALID_BARCODE, INVALID_DIGITABLE_LINE
+	ErrInvalidBarcode = &BusinessCode{Name: "invalid_barcode", PT: "Código de barras ou linha digitável inválida", EN: "Invalid barcode or digitable line", Retryable: false}
+)
+
+// businessCodes maps the codes returned by the API to their sentinel
+var businessCodes = map[string]businessCodeEntry{
+	"PIX_KEY_ALREADY_EXISTS":                  {ErrPixKeyAlreadyRegistered, CodeSourceEvertec},
+	"EntryAlreadyExists":                      {ErrPixKeyAlreadyRegistered, CodeSourceDICT},
+	"ClaimResultingEntryAlreadyExists":        {ErrPixKeyAlreadyRegistered, CodeSourceDICT},
+	"EntryKeyInCustodyOfDifferentParticipant": {ErrPixKeyOwnedByOtherPSP, CodeSourceDICT},
+	"EntryKeyOwnedByDifferentPerson":          {ErrPixKeyOwnedByOtherPerson, CodeSourceDICT},
+	"PIX_KEY_LIMIT_EXCEEDED":                  {ErrPixKeyLimitExceeded, CodeSourceEvertec},
+	"EntryLimitExceeded":                      {ErrPixKeyLimitExceeded, CodeSourceDICT},
+	"EntryLockedByClaim":                      {ErrPixKeyLockedByClaim, CodeSourceDICT},
+	"LIMIT_EXCEEDED":                          {ErrDailyLimitExceeded, CodeSourceEvertec},
+	"DAILY_LIMIT_EXCEEDED":                    {ErrDailyLimitExceeded, CodeSourceEvertec},
+	"NIGHT_LIMIT_EXCEEDED":                    {ErrNightLimitExceeded, CodeSourceEvertec},
+	"ACCOUNT_BLOCKED":                         {ErrAccountBlocked, CodeSourceEvertec},
+	"AC06":                                    {ErrAccountBlocked, CodeSourceSPI},
+	"ACCOUNT_CLOSED":                          {ErrAccountClosed, CodeSourceEvertec},
+	"AC07":                                    {ErrAccountClosed, CodeSourceSPI},
+	"AC03":                                    {ErrInvalidRecipientAccount, CodeSourceSPI},
+	"AC14":                                    {ErrInvalidRecipientAccount, CodeSourceSPI},
+	"BE01":                                    {ErrRecipientDocumentMismatch, CodeSourceSPI},
+	"AB03":                                    {ErrSettlementTimeout, CodeSourceSPI},
+	"AB09":                                    {ErrSettlementTimeout, CodeSourceSPI},
+	"AB11":                                    {ErrSettlementTimeout, CodeSourceSPI},
+	"DUPLICATE_ACCOUNT":                       {ErrDuplicateAccount, CodeSourceEvertec},
+	"CARD_ALREADY_ACTIVE":                     {ErrCardAlreadyActive, CodeSourceEvertec},
+	"CARD_NOT_ACTIVE":                         {ErrCardNotActive, CodeSourceEvertec},
+	"INVALID_BARCODE":                         {ErrInvalidBarcode, CodeSourceEvertec},
+	"INVALID_DIGITABLE_LINE":                  {ErrInvalidBarcode, CodeSourceEvertec},
+}
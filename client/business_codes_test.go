@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestBusinessCodeSentinels(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   *BusinessCode
+	}{
+		{"evertec key conflict", http.StatusConflict, `{"code":"PIX_KEY_ALREADY_EXISTS","message":"key exists"}`, ErrPixKeyAlreadyRegistered},
+		{"dict custody", http.StatusConflict, `{"code":"EntryKeyInCustodyOfDifferentParticipant"}`, ErrPixKeyOwnedByOtherPSP},
+		{"dict error URI", http.StatusConflict, `{"code":"https://dict.pi.rsfn.net.br/api/v2/error/EntryLimitExceeded"}`, ErrPixKeyLimitExceeded},
+		{"daily limit", http.StatusConflict, `{"code":"LIMIT_EXCEEDED","message":"daily limit exceeded"}`, ErrDailyLimitExceeded},
+		{"spi blocked account", http.StatusUnprocessableEntity, `{"code":"AC06"}`, ErrAccountBlocked},
+		{"spi timeout", http.StatusFailedDependency, `{"code":"AB03","service":"SPI"}`, ErrSettlementTimeout},
+		{"invalid barcode", http.StatusBadRequest, `{"code":"INVALID_BARCODE","message":"bad barcode"}`, ErrInvalidBarcode},
+		{"night limit on 402", http.StatusPaymentRequired, `{"code":"NIGHT_LIMIT_EXCEEDED"}`, ErrNightLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", parseErrorResponse(&http.Response{StatusCode: tt.status}, []byte(tt.body)))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("errors.Is(%v, %s) = false", err, tt.want.Name)
+			}
+			if errors.Is(err, ErrCardAlreadyActive) {
+				t.Errorf("errors.Is(%v, ErrCardAlreadyActive) = true", err)
+			}
+			if got, ok := BusinessCodeOf(err); !ok || got != tt.want {
+				t.Errorf("BusinessCodeOf = %v, %v; want %s", got, ok, tt.want.Name)
+			}
+		})
+	}
+
+	// Status sentinels keep working alongside the code sentinels
+	err := parseErrorResponse(&http.Response{StatusCode: http.StatusConflict}, []byte(`{"code":"CARD_ALREADY_ACTIVE"}`))
+	if !errors.Is(err, ErrBusinessRule) || !errors.Is(err, ErrCardAlreadyActive) {
+		t.Errorf("error %v does not match ErrBusinessRule and ErrCardAlreadyActive", err)
+	}
+}
+
+func TestLookupBusinessCode(t *testing.T) {
+	code, source, ok := LookupBusinessCode("EntryAlreadyExists")
+	if !ok || code != ErrPixKeyAlreadyRegistered || source != CodeSourceDICT {
+		t.Errorf("LookupBusinessCode = %v, %s, %v", code, source, ok)
+	}
+	if code.Name != "pix_key_already_registered" || code.PT == "" || code.Error() != code.EN {
+		t.Errorf("sentinel = %+v", code)
+	}
+	if !ErrSettlementTimeout.Retryable || ErrDailyLimitExceeded.Retryable {
+		t.Error("unexpected retryable classification")
+	}
+	if _, _, ok := LookupBusinessCode("UNKNOWN_CODE"); ok {
+		t.Error("LookupBusinessCode(UNKNOWN_CODE) found a sentinel")
+	}
+	if _, ok := BusinessCodeOf(&NotFoundError{StatusCode: 404}); ok {
+		t.Error("BusinessCodeOf(NotFoundError) found a sentinel")
+	}
+}
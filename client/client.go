@@ -4,14 +4,25 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/internal/mtls"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+	"go.opentelemetry.io/otel"
 )
 
 // Client is the Evertec API client
 type Client struct {
-	config *Config
-	http   *http.Client
+	config   *Config
+	http     *http.Client
+	metrics  *observability.Metrics
+	breakers *circuitBreakers
+	limiter  *rateLimiter
+	dict     *dictBudget
+
+	tenantsMu    sync.Mutex
+	tenants      map[string]*tenantClient
+	tenantsClock uint64 // orders tenant pools by last use
 }
 
 // New creates a new Evertec API client with the provided configuration
@@ -36,17 +47,45 @@ func New(baseURL, apiKey string, tlsConfig *tls.Config, opts ...Option) (*Client
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Create HTTP client with TLS config
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
-		Transport: &http.Transport{
+	// Create HTTP client with TLS config, unless a custom transport is provided
+	transport := config.Transport
+	if transport == nil {
+		transport = &http.Transport{
 			TLSClientConfig: config.TLSConfig,
-		},
+		}
+	}
+	httpClient := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
 	}
 
 	client := &Client{
-		config: config,
-		http:   httpClient,
+		config:  config,
+		http:    httpClient,
+		tenants: make(map[string]*tenantClient),
+	}
+
+	if config.CircuitBreaker != nil {
+		client.breakers = newCircuitBreakers(*config.CircuitBreaker, client.onCircuitStateChange)
+	}
+	if config.RateLimit != nil {
+		client.limiter = newRateLimiter(*config.RateLimit)
+	}
+	if config.DictBudget != nil {
+		client.dict = newDictBudget(*config.DictBudget)
+	}
+
+	// Build OpenTelemetry instruments if metrics are enabled
+	if config.MetricsEnabled || config.MeterProvider != nil {
+		mp := config.MeterProvider
+		if mp == nil {
+			mp = otel.GetMeterProvider()
+		}
+		metrics, err := observability.NewMetricsWithProvider(mp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics: %w", err)
+		}
+		client.metrics = metrics
 	}
 
 	config.Logger.Info("Evertec API client initialized",
@@ -77,5 +116,10 @@ func (c *Client) Config() Config {
 // Close closes the HTTP client's idle connections
 func (c *Client) Close() {
 	c.http.CloseIdleConnections()
+	c.tenantsMu.Lock()
+	for _, tc := range c.tenants {
+		tc.http.CloseIdleConnections()
+	}
+	c.tenantsMu.Unlock()
 	c.config.Logger.Info("Evertec API client closed")
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"crypto/tls"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -51,6 +52,14 @@ func TestNew(t *testing.T) {
 			opts:      nil,
 			wantErr:   true,
 		},
+		{
+			name:      "custom transport without TLS config",
+			baseURL:   "https://api.example.com",
+			apiKey:    "test-key",
+			tlsConfig: nil,
+			opts:      []Option{WithTransport(http.DefaultTransport)},
+			wantErr:   false,
+		},
 		{
 			name:      "with custom timeout",
 			baseURL:   "https://api.example.com",
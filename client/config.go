@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"go.opentelemetry.io/otel/metric"
@@ -45,6 +46,10 @@ type Config struct {
 	// TLSConfig is the TLS configuration for mTLS authentication
 	TLSConfig *tls.Config
 
+	// Transport replaces the default mTLS transport, e.g. with a cassette.Recorder in tests.
+	// When set, TLSConfig is optional and ignored.
+	Transport http.RoundTripper
+
 	// ServerName overrides the TLS ServerName for SNI (useful for IP-based endpoints)
 	ServerName string
 
@@ -57,7 +62,7 @@ type Config struct {
 	// TracerProvider is the OpenTelemetry tracer provider for distributed tracing
 	TracerProvider trace.TracerProvider
 
-	// MeterProvider is the OpenTelemetry meter provider for metrics
+	// MeterProvider is the OpenTelemetry meter provider for metrics (setting it enables metrics)
 	MeterProvider metric.MeterProvider
 
 	// TracingEnabled enables OpenTelemetry tracing (uses default provider if TracerProvider is nil)
@@ -68,19 +73,43 @@ type Config struct {
 
 	// AutoIdempotency enables automatic UUID-v4 idempotency key generation for mutating requests
 	AutoIdempotency bool
+
+	// RetryPolicy controls retries of failed requests (defaults to DefaultRetryPolicy())
+	RetryPolicy *RetryPolicy
+
+	// CircuitBreaker enables per endpoint group circuit breakers (disabled when nil)
+	CircuitBreaker *CircuitBreakerPolicy
+
+	// RateLimit enables client-side rate limits per endpoint group (disabled when nil)
+	RateLimit *RateLimitPolicy
+
+	// DictBudget enables the client-side DICT anti-scan budget of GetPixKeyInfo (disabled when nil)
+	DictBudget *DictBudgetPolicy
+
+	// CredentialsResolver selects the API key, certificate and base URL per call
+	// (the ones passed to New are used when nil)
+	CredentialsResolver CredentialsResolver
+
+	// SkipValidation disables client-side validation of request bodies implementing types.Validator
+	SkipValidation bool
 }
 
 // validate checks if the configuration is valid
 func (c *Config) validate() error {
-	if c.BaseURL == "" {
+	// A credentials resolver may provide the base URL and API key per call
+	if c.BaseURL == "" && c.CredentialsResolver == nil {
 		return fmt.Errorf("base URL is required")
 	}
 
-	if c.APIKey == "" {
+	if c.APIKey == "" && c.CredentialsResolver == nil {
 		return fmt.Errorf("API key is required")
 	}
 
 	if c.TLSConfig == nil {
+		// A custom transport owns its TLS setup
+		if c.Transport != nil {
+			return nil
+		}
 		return fmt.Errorf("TLS config is required for mTLS authentication")
 	}
 
@@ -116,4 +145,9 @@ func (c *Config) applyDefaults() {
 	if c.Hooks == nil {
 		c.Hooks = []Hook{}
 	}
+
+	if c.RetryPolicy == nil {
+		policy := DefaultRetryPolicy()
+		c.RetryPolicy = &policy
+	}
 }
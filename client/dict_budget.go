@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// DictBucket is a DICT anti-scan token bucket. It holds up to Capacity tokens and gains
+// Refill tokens at the end of every RefillInterval. A zero Capacity disables the bucket.
+type DictBucket struct {
+	Capacity       int
+	Refill         int
+	RefillInterval time.Duration
+}
+
+// DictBudgetPolicy models the anti-scan limits BACEN's DICT applies to PIX key lookups,
+// so GetPixKeyInfo fails fast with ErrDictScanLimit instead of getting the end user
+// blocked. Start from DefaultDictBudgetPolicy and adjust fields as needed.
+//
+// Every lookup takes a token from the payer's bucket and from the Participant bucket. A
+// lookup of a key that does not exist costs NotFoundCost tokens in total. A successful
+// DoPixPayment to a key looked up less than RefundWindow earlier gives its token back.
+// Buckets are kept apart for each tenant set with WithTenant.
+type DictBudgetPolicy struct {
+	// Person is the bucket of each natural person (CPF), and of payers without a known document
+	Person DictBucket
+
+	// Company is the bucket of each legal entity (CNPJ)
+	Company DictBucket
+
+	// Participant is a bucket shared by all lookups of a tenant, or of the client when no
+	// tenant is set (disabled by default)
+	Participant DictBucket
+
+	// NotFoundCost is the number of tokens taken by a lookup of a key that does not exist
+	NotFoundCost int
+
+	// RefundWindow is how long after a lookup a payment to the key refunds it
+	RefundWindow time.Duration
+}
+
+// DefaultDictBudgetPolicy returns the DICT limits for end users: 100 tokens refilled at
+// 2 per minute for persons, 1000 tokens refilled at 20 per minute for companies, and
+// 3 tokens per lookup of a key that does not exist.
+func DefaultDictBudgetPolicy() DictBudgetPolicy {
+	return DictBudgetPolicy{
+		Person:       DictBucket{Capacity: 100, Refill: 2, RefillInterval: time.Minute},
+		Company:      DictBucket{Capacity: 1000, Refill: 20, RefillInterval: time.Minute},
+		NotFoundCost: 3,
+		RefundWindow: 10 * time.Minute,
+	}
+}
+
+// DictScanLimitError is returned by GetPixKeyInfo, without calling the API, when a
+// lookup would exceed the DICT anti-scan limits
+type DictScanLimitError struct {
+	// Payer identifies the exhausted bucket: a masked document, an account or "participant"
+	Payer string
+	// RetryAfter is the time until the bucket gains a token
+	RetryAfter time.Duration
+}
+
+func (e *DictScanLimitError) Error() string {
+	return fmt.Sprintf("DICT scan limit reached for %s (retry in %s)", e.Payer, e.RetryAfter.Round(time.Second))
+}
+
+func (e *DictScanLimitError) Unwrap() error {
+	return ErrDictScanLimit
+}
+
+// dictPayerKey is the context key of the payer document set by WithDictPayer
+type dictPayerKey struct{}
+
+// WithDictPayer marks PIX key lookups and payments made with ctx as done on behalf of
+// the end user with the given CPF or CNPJ. The DICT budget is then tracked per document,
+// with the Company bucket for CNPJs; otherwise it is tracked per account as a person.
+func WithDictPayer(ctx context.Context, document types.Document) context.Context {
+	return context.WithValue(ctx, dictPayerKey{}, document)
+}
+
+// SeedDictBudget adjusts the DICT budget enabled by WithDictBudget to the issuer's PIX
+// scan configuration: ScanFrequency (in minutes) becomes the refill interval of the
+// person and company buckets. The budget stays on whatever the configuration's Enabled
+// flag, as the DICT limits apply regardless of the issuer's own scan settings.
+func (c *Client) SeedDictBudget(ctx context.Context) error {
+	if c.dict == nil {
+		return errors.New("DICT budget not enabled; use WithDictBudget")
+	}
+	cfg, err := c.GetPixScanConfiguration(ctx)
+	if err != nil {
+		return err
+	}
+	c.dict.seed(cfg)
+	return nil
+}
+
+// dictBudget tracks the DICT tokens of each payer
+type dictBudget struct {
+	now func() time.Time
+
+	mu           sync.Mutex
+	policy       DictBudgetPolicy
+	participants map[string]*dictTokens
+	payers       map[string]*dictPayer
+	nextSweep    int
+}
+
+// dictPayer is the bucket of one payer, the participant bucket of its tenant and its
+// lookups that a payment can refund
+type dictPayer struct {
+	bucket      DictBucket
+	tokens      dictTokens
+	participant *dictTokens
+	lookups     map[string]time.Time
+}
+
+// dictLookup is a lookup whose tokens were taken, to be settled once it completes
+type dictLookup struct {
+	payer string
+	key   string
+}
+
+// newDictBudget returns a budget for the policy, with full buckets
+func newDictBudget(policy DictBudgetPolicy) *dictBudget {
+	return &dictBudget{
+		now:          time.Now,
+		policy:       policy,
+		participants: make(map[string]*dictTokens),
+		payers:       make(map[string]*dictPayer),
+		nextSweep:    1024,
+	}
+}
+
+// payerOf returns the budget key, display name and bucket of the payer in ctx. Keys are
+// prefixed with the tenant, as account IDs of different issuers may collide.
+func (d *dictBudget) payerOf(ctx context.Context, accountID int64) (string, string, DictBucket) {
+	tenant, _ := TenantFromContext(ctx)
+	if document, ok := ctx.Value(dictPayerKey{}).(types.Document); ok && document.Valid() {
+		bucket := d.policy.Person
+		if document.Kind() == types.DocumentTypeCNPJ {
+			bucket = d.policy.Company
+		}
+		return tenant + "|doc:" + document.Canonical(), document.Masked(), bucket
+	}
+	id := strconv.FormatInt(accountID, 10)
+	return tenant + "|account:" + id, "account " + id, d.policy.Person
+}
+
+// take takes a token for a lookup of key, or fails if the payer or participant has none
+func (d *dictBudget) take(ctx context.Context, accountID int64, key string) (*dictLookup, error) {
+	id, name, bucket := d.payerOf(ctx, accountID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	tenant, _ := TenantFromContext(ctx)
+	p := d.payer(tenant, id, bucket, now)
+	if bucket.Capacity > 0 && p.tokens.tokens < 1 {
+		return nil, &DictScanLimitError{Payer: name, RetryAfter: p.tokens.retryAfter(bucket, now)}
+	}
+	if participant := d.policy.Participant; participant.Capacity > 0 {
+		p.participant.refill(participant, now)
+		if p.participant.tokens < 1 {
+			return nil, &DictScanLimitError{Payer: "participant", RetryAfter: p.participant.retryAfter(participant, now)}
+		}
+		p.participant.tokens--
+	}
+	if bucket.Capacity > 0 {
+		p.tokens.tokens--
+	}
+	return &dictLookup{payer: id, key: normalizeDictKey(key)}, nil
+}
+
+// settle accounts for the outcome of a lookup: keys that do not exist cost extra tokens,
+// and lookups that never got an answer from the API are given back
+func (d *dictBudget) settle(lookup *dictLookup, err error) {
+	if lookup == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, ok := d.payers[lookup.payer]
+	if !ok {
+		return
+	}
+	_, answered := ErrorMetaOf(err)
+	switch {
+	case err == nil:
+		p.lookups[lookup.key] = d.now()
+	case errors.Is(err, ErrNotFound):
+		extra := max(d.policy.NotFoundCost-1, 0)
+		p.tokens.tokens = max(p.tokens.tokens-extra, 0)
+		p.participant.tokens = max(p.participant.tokens-extra, 0)
+	case !answered:
+		d.give(p)
+	}
+}
+
+// refund gives back the token of a lookup of the payment's key, once per lookup
+func (d *dictBudget) refund(ctx context.Context, req *types.PixPaymentRequest) {
+	if req == nil || req.RecipientAddressingKey == nil {
+		return
+	}
+	id, _, _ := d.payerOf(ctx, req.AccountID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p, ok := d.payers[id]
+	if !ok {
+		return
+	}
+	key := normalizeDictKey(*req.RecipientAddressingKey)
+	at, ok := p.lookups[key]
+	if !ok {
+		return
+	}
+	delete(p.lookups, key)
+	if d.now().Sub(at) <= d.policy.RefundWindow {
+		d.give(p)
+	}
+}
+
+// normalizeDictKey returns the canonical form of a PIX key, so a payment refunds the lookup
+// of the same key written another way (e.g. a formatted CPF, or a phone without +55)
+func normalizeDictKey(key string) string {
+	// Ambiguous keys still return their most likely form
+	if parsed, _ := types.ParsePixKey(key); parsed.Value != "" {
+		return parsed.Value
+	}
+	return key
+}
+
+// seed applies the refill interval of the issuer's PIX scan configuration
+func (d *dictBudget) seed(cfg *types.PixScanConfigurationResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if cfg.ScanFrequency != nil && *cfg.ScanFrequency > 0 {
+		interval := time.Duration(*cfg.ScanFrequency) * time.Minute
+		d.policy.Person.RefillInterval = interval
+		d.policy.Company.RefillInterval = interval
+		for _, p := range d.payers {
+			p.bucket.RefillInterval = interval
+		}
+	}
+}
+
+// give returns a token to a payer and the participant; d.mu must be held
+func (d *dictBudget) give(p *dictPayer) {
+	p.tokens.tokens = min(p.tokens.tokens+1, p.bucket.Capacity)
+	p.participant.tokens = min(p.participant.tokens+1, d.policy.Participant.Capacity)
+}
+
+// payer returns the refilled state of a tenant's payer, creating it with a full bucket;
+// d.mu must be held
+func (d *dictBudget) payer(tenant, id string, bucket DictBucket, now time.Time) *dictPayer {
+	p, ok := d.payers[id]
+	if !ok {
+		if len(d.payers) >= d.nextSweep {
+			d.sweep(now)
+		}
+		participant, ok := d.participants[tenant]
+		if !ok {
+			participant = &dictTokens{tokens: d.policy.Participant.Capacity, last: now}
+			d.participants[tenant] = participant
+		}
+		p = &dictPayer{
+			bucket:      bucket,
+			tokens:      dictTokens{tokens: bucket.Capacity, last: now},
+			participant: participant,
+			lookups:     make(map[string]time.Time),
+		}
+		d.payers[id] = p
+		return p
+	}
+	p.tokens.refill(p.bucket, now)
+	return p
+}
+
+// sweep forgets payers whose bucket is full again and who have no refundable lookups,
+// as they are the same as new ones; d.mu must be held
+func (d *dictBudget) sweep(now time.Time) {
+	for id, p := range d.payers {
+		for key, at := range p.lookups {
+			if now.Sub(at) > d.policy.RefundWindow {
+				delete(p.lookups, key)
+			}
+		}
+		p.tokens.refill(p.bucket, now)
+		if p.tokens.tokens >= p.bucket.Capacity && len(p.lookups) == 0 {
+			delete(d.payers, id)
+		}
+	}
+	d.nextSweep = max(2*len(d.payers), 1024)
+}
+
+// dictTokens is the token count of a bucket, refilled in whole intervals since last
+type dictTokens struct {
+	tokens int
+	last   time.Time
+}
+
+// refill adds the tokens of the intervals elapsed since the last refill
+func (t *dictTokens) refill(bucket DictBucket, now time.Time) {
+	if bucket.Refill <= 0 || bucket.RefillInterval <= 0 {
+		return
+	}
+	if t.tokens >= bucket.Capacity {
+		t.last = now
+		return
+	}
+	if n := int(now.Sub(t.last) / bucket.RefillInterval); n > 0 {
+		t.tokens = min(t.tokens+n*bucket.Refill, bucket.Capacity)
+		t.last = t.last.Add(time.Duration(n) * bucket.RefillInterval)
+	}
+}
+
+// retryAfter returns the time until the next refill
+func (t *dictTokens) retryAfter(bucket DictBucket, now time.Time) time.Duration {
+	if bucket.Refill <= 0 || bucket.RefillInterval <= 0 {
+		return 0
+	}
+	return max(t.last.Add(bucket.RefillInterval).Sub(now), 0)
+}
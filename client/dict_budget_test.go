@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// newDictTestServer answers PIX key lookups (404 for keys starting with "missing"),
+// payments and the scan configuration
+func newDictTestServer(t *testing.T, lookups *atomic.Int32, scanConfig string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pix/keys/"):
+			lookups.Add(1)
+			if strings.Contains(r.URL.Path, "/missing") {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"key not found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/backoffice/pix/scan/configuration":
+			_, _ = w.Write([]byte(scanConfig))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newDictTestPayment(key string) *types.PixPaymentRequest {
+	return &types.PixPaymentRequest{
+		AccountID:                1,
+		RecipientInstitutionCode: "001",
+		RecipientBranchCode:      "0001",
+		RecipientAccountNumber:   "123456",
+		RecipientAccountType:     types.PixAccountTypeCACC,
+		RecipientCpfCnpj:         "52998224725",
+		RecipientName:            "John Doe",
+		OperationAmount:          types.MustParseMoney("10.00"),
+		RecipientAddressingKey:   &key,
+	}
+}
+
+func TestDictBudget(t *testing.T) {
+	var lookups atomic.Int32
+	server := newDictTestServer(t, &lookups, `{}`)
+
+	policy := DefaultDictBudgetPolicy()
+	policy.Person = DictBucket{Capacity: 4, Refill: 1, RefillInterval: time.Minute}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithDictBudget(policy))
+	now := time.Now()
+	c.dict.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := c.GetPixKeyInfo(ctx, 1, "a@email.com"); err != nil {
+		t.Fatalf("lookup = %v", err)
+	}
+	// A key that does not exist costs 3 tokens, leaving none
+	if _, err := c.GetPixKeyInfo(ctx, 1, "missing@email.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("lookup = %v; want ErrNotFound", err)
+	}
+	_, err := c.GetPixKeyInfo(ctx, 1, "b@email.com")
+	var limitErr *DictScanLimitError
+	if !errors.As(err, &limitErr) || limitErr.Payer != "account 1" || limitErr.RetryAfter != time.Minute {
+		t.Fatalf("lookup = %v; want DictScanLimitError for account 1", err)
+	}
+	if !errors.Is(err, ErrDictScanLimit) {
+		t.Errorf("errors.Is(%v, ErrDictScanLimit) = false", err)
+	}
+	if lookups.Load() != 2 {
+		t.Errorf("server got %d lookups; want 2", lookups.Load())
+	}
+
+	// Other accounts have their own bucket
+	if _, err := c.GetPixKeyInfo(ctx, 2, "b@email.com"); err != nil {
+		t.Errorf("lookup for account 2 = %v", err)
+	}
+
+	// Tokens come back in whole intervals, and a payment to a looked up key refunds it
+	now = now.Add(time.Minute)
+	if _, err := c.GetPixKeyInfo(ctx, 1, "c@email.com"); err != nil {
+		t.Fatalf("lookup after refill = %v", err)
+	}
+	if _, err := c.DoPixPayment(ctx, newDictTestPayment("c@email.com")); err != nil {
+		t.Fatalf("payment = %v", err)
+	}
+	if _, err := c.GetPixKeyInfo(ctx, 1, "d@email.com"); err != nil {
+		t.Errorf("lookup after refund = %v", err)
+	}
+	if _, err := c.DoPixPayment(ctx, newDictTestPayment("c@email.com")); err != nil {
+		t.Fatalf("payment = %v", err)
+	}
+	if _, err := c.GetPixKeyInfo(ctx, 1, "e@email.com"); !errors.Is(err, ErrDictScanLimit) {
+		t.Errorf("lookup = %v; want ErrDictScanLimit as a lookup is refunded once", err)
+	}
+}
+
+func TestDictBudgetRefundNormalizesKey(t *testing.T) {
+	var lookups atomic.Int32
+	server := newDictTestServer(t, &lookups, `{}`)
+
+	tests := []struct{ lookup, payment string }{
+		{"529.982.247-25", "52998224725"},
+		{"A@Email.com", "a@email.com"},
+		{"+55 (11) 98765-4321", "+5511987654321"},
+	}
+	for _, tt := range tests {
+		policy := DefaultDictBudgetPolicy()
+		policy.Person = DictBucket{Capacity: 1}
+		c := newRetryTestClient(t, server, NoRetryPolicy(), WithDictBudget(policy))
+		ctx := context.Background()
+
+		if _, err := c.GetPixKeyInfo(ctx, 1, tt.lookup); err != nil {
+			t.Fatalf("lookup of %s = %v", tt.lookup, err)
+		}
+		if _, err := c.DoPixPayment(ctx, newDictTestPayment(tt.payment)); err != nil {
+			t.Fatalf("payment to %s = %v", tt.payment, err)
+		}
+		if _, err := c.GetPixKeyInfo(ctx, 1, tt.lookup); err != nil {
+			t.Errorf("lookup of %s after a payment to %s = %v; want the token refunded", tt.lookup, tt.payment, err)
+		}
+	}
+}
+
+func TestDictBudgetPayer(t *testing.T) {
+	var lookups atomic.Int32
+	server := newDictTestServer(t, &lookups, `{}`)
+
+	policy := DefaultDictBudgetPolicy()
+	policy.Person = DictBucket{Capacity: 1}
+	policy.Company = DictBucket{Capacity: 2}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithDictBudget(policy))
+
+	person := WithDictPayer(context.Background(), "529.982.247-25")
+	if _, err := c.GetPixKeyInfo(person, 1, "a@email.com"); err != nil {
+		t.Fatalf("lookup = %v", err)
+	}
+	// The budget follows the document across accounts
+	_, err := c.GetPixKeyInfo(person, 2, "b@email.com")
+	var limitErr *DictScanLimitError
+	if !errors.As(err, &limitErr) || limitErr.Payer != "***.982.247-**" {
+		t.Errorf("lookup = %v; want DictScanLimitError for the masked CPF", err)
+	}
+
+	company := WithDictPayer(context.Background(), "11222333000181")
+	for range 2 {
+		if _, err := c.GetPixKeyInfo(company, 1, "a@email.com"); err != nil {
+			t.Fatalf("company lookup = %v", err)
+		}
+	}
+	if _, err := c.GetPixKeyInfo(company, 1, "a@email.com"); !errors.Is(err, ErrDictScanLimit) {
+		t.Errorf("company lookup = %v; want ErrDictScanLimit", err)
+	}
+}
+
+func TestDictBudgetParticipant(t *testing.T) {
+	var lookups atomic.Int32
+	server := newDictTestServer(t, &lookups, `{}`)
+
+	policy := DefaultDictBudgetPolicy()
+	policy.Participant = DictBucket{Capacity: 2, Refill: 1, RefillInterval: time.Second}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithDictBudget(policy))
+
+	for account := range int64(2) {
+		if _, err := c.GetPixKeyInfo(context.Background(), account, "a@email.com"); err != nil {
+			t.Fatalf("lookup = %v", err)
+		}
+	}
+	_, err := c.GetPixKeyInfo(context.Background(), 3, "a@email.com")
+	var limitErr *DictScanLimitError
+	if !errors.As(err, &limitErr) || limitErr.Payer != "participant" {
+		t.Errorf("lookup = %v; want DictScanLimitError for the participant", err)
+	}
+}
+
+func TestDictBudgetUnansweredLookup(t *testing.T) {
+	d := newDictBudget(DictBudgetPolicy{Person: DictBucket{Capacity: 1}})
+	lookup, err := d.take(context.Background(), 1, "a@email.com")
+	if err != nil {
+		t.Fatalf("take = %v", err)
+	}
+	// The API was never reached, so the token is given back
+	d.settle(lookup, context.DeadlineExceeded)
+	if _, err := d.take(context.Background(), 1, "a@email.com"); err != nil {
+		t.Errorf("take after unanswered lookup = %v", err)
+	}
+}
+
+func TestSeedDictBudget(t *testing.T) {
+	var lookups atomic.Int32
+	server := newDictTestServer(t, &lookups, `{"enabled":true,"scanFrequency":5}`)
+
+	policy := DefaultDictBudgetPolicy()
+	policy.Person = DictBucket{Capacity: 1, Refill: 1, RefillInterval: time.Minute}
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithDictBudget(policy))
+	if err := c.SeedDictBudget(context.Background()); err != nil {
+		t.Fatalf("SeedDictBudget = %v", err)
+	}
+	if _, err := c.GetPixKeyInfo(context.Background(), 1, "a@email.com"); err != nil {
+		t.Fatalf("lookup = %v", err)
+	}
+	_, err := c.GetPixKeyInfo(context.Background(), 1, "a@email.com")
+	var limitErr *DictScanLimitError
+	if !errors.As(err, &limitErr) || limitErr.RetryAfter <= 4*time.Minute {
+		t.Errorf("lookup = %v; want a retry after about 5m", err)
+	}
+
+	// A disabled scan configuration does not turn the budget off
+	disabled := newRetryTestClient(t, newDictTestServer(t, &lookups, `{"enabled":false,"scanFrequency":5}`), NoRetryPolicy(), WithDictBudget(policy))
+	if err := disabled.SeedDictBudget(context.Background()); err != nil {
+		t.Fatalf("SeedDictBudget = %v", err)
+	}
+	if _, err := disabled.GetPixKeyInfo(context.Background(), 1, "a@email.com"); err != nil {
+		t.Fatalf("lookup = %v", err)
+	}
+	if _, err := disabled.GetPixKeyInfo(context.Background(), 1, "a@email.com"); !errors.Is(err, ErrDictScanLimit) {
+		t.Errorf("lookup with a disabled scan configuration = %v; want ErrDictScanLimit", err)
+	}
+
+	plain := newRetryTestClient(t, server, NoRetryPolicy())
+	if err := plain.SeedDictBudget(context.Background()); err == nil {
+		t.Error("SeedDictBudget without WithDictBudget = nil; want an error")
+	}
+}
@@ -1,10 +1,15 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
 )
 
 // Sentinel errors for error type checking with errors.Is()
@@ -23,8 +28,108 @@ var (
 	ErrPreconditionFailed = errors.New("precondition failed")
 	ErrThirdParty        = errors.New("third party error")
 	ErrPanic             = errors.New("panic recovered")
+	ErrCircuitOpen       = errors.New("circuit open")
+	ErrDictScanLimit     = errors.New("dict scan limit reached")
 )
 
+// MaxErrorBodySize is the largest response body kept in ErrorMeta.Body
+const MaxErrorBodySize = 8 << 10
+
+// requestIDHeaders are the response headers checked, in order, for a request identifier
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "X-Amzn-Requestid"}
+
+// ErrorMeta describes the request and response behind an API error. It is embedded in
+// every typed API error, so its fields can be read directly (apiErr.RequestID), and is
+// worth including in support tickets to Evertec. Errors built outside the client, or
+// ValidationErrors raised before sending, leave it empty.
+type ErrorMeta struct {
+	// Method is the HTTP method of the request
+	Method string
+	// Route is the templated request path, e.g. "/accounts/{id}/balance"
+	Route string
+	// RequestID is the request or correlation identifier returned by the API, if any
+	RequestID string
+	// RetryAfter is the wait requested by a Retry-After header, or 0
+	RetryAfter time.Duration
+	// IdempotencyKey is the idempotency key sent with the request, if any
+	IdempotencyKey string
+	// Attempt is the 1-based attempt that failed
+	Attempt int
+	// Header holds the response headers
+	Header http.Header
+	// Body is the raw response body, cut at MaxErrorBodySize bytes
+	Body []byte
+}
+
+// errorMeta returns the metadata embedded in a typed error
+func (m *ErrorMeta) errorMeta() *ErrorMeta {
+	return m
+}
+
+// ErrorMetaOf returns the metadata of the typed API error in err's chain
+func ErrorMetaOf(err error) (*ErrorMeta, bool) {
+	var withMeta interface{ errorMeta() *ErrorMeta }
+	if !errors.As(err, &withMeta) {
+		return nil, false
+	}
+	return withMeta.errorMeta(), true
+}
+
+// newErrorMeta collects the metadata of a failed attempt
+func newErrorMeta(info *RequestInfo, req *http.Request, resp *http.Response, body []byte) ErrorMeta {
+	meta := ErrorMeta{
+		Method:         info.Method,
+		Route:          info.Route,
+		IdempotencyKey: req.Header.Get(IdempotencyKeyHeader),
+		Attempt:        info.Attempt,
+		Header:         resp.Header,
+		Body:           body[:min(len(body), MaxErrorBodySize)],
+	}
+	for _, name := range requestIDHeaders {
+		if id := resp.Header.Get(name); id != "" {
+			meta.RequestID = id
+			break
+		}
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header, time.Now()); ok {
+		meta.RetryAfter = retryAfter
+	}
+	return meta
+}
+
+// setErrorMeta stores meta in the typed API error in err's chain, if any
+func setErrorMeta(err error, meta ErrorMeta) {
+	if m, ok := ErrorMetaOf(err); ok {
+		*m = meta
+	}
+}
+
+// RetryableError is implemented by every typed API error so that retry logic and callers
+// classify failures uniformly. Retryable reports whether the same request may succeed if
+// sent again: 429 and 5xx responses and business codes classified as retryable (see
+// BusinessCode). Temporary reports the same, following the net.Error convention.
+type RetryableError interface {
+	error
+	Retryable() bool
+	Temporary() bool
+}
+
+// IsRetryable reports whether err holds a typed API error that may succeed if retried.
+// Transport errors, which carry no response, are not typed and report false.
+func IsRetryable(err error) bool {
+	var re RetryableError
+	return errors.As(err, &re) && re.Retryable()
+}
+
+// isRetryable classifies an API error from its status code and business code
+func isRetryable(statusCode int, code string) bool {
+	if isRetryableStatus(statusCode) {
+		return true
+	}
+	sentinel, _, ok := LookupBusinessCode(code)
+	return ok && sentinel.Retryable
+}
+
 // APIError represents an error response from the Evertec API
 type APIError struct {
 	StatusCode int    `json:"statusCode"`
@@ -32,6 +137,8 @@ type APIError struct {
 	Message    string `json:"message"`
 	Details    any    `json:"details,omitempty"`
 	Err        error  `json:"-"` // Wrapped error for error chaining
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *APIError) Error() string {
@@ -45,11 +152,32 @@ func (e *APIError) Unwrap() error {
 	return ErrAPI
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *APIError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *APIError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *APIError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *APIError) errorCode() string {
+	return e.Code
+}
+
 // ValidationError represents a 400 Bad Request response with field-level validation errors
 type ValidationError struct {
 	StatusCode int                `json:"statusCode"`
 	Errors     []ValidationDetail `json:"errors"`
 	Err        error              `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 type ValidationDetail struct {
@@ -72,12 +200,38 @@ func (e *ValidationError) Unwrap() error {
 	return ErrValidation
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *ValidationError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *ValidationError) Temporary() bool {
+	return e.Retryable()
+}
+
+// newRequestValidationError converts the result of a request's Validate method into a ValidationError.
+// StatusCode is 0 because the request was rejected before being sent.
+func newRequestValidationError(err error) *ValidationError {
+	var fieldErrs types.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return &ValidationError{Errors: []ValidationDetail{{Code: types.ErrInvalidField, Message: err.Error()}}}
+	}
+	details := make([]ValidationDetail, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		details[i] = ValidationDetail(fe)
+	}
+	return &ValidationError{Errors: details}
+}
+
 // BusinessRuleError represents a 409 Conflict response
 type BusinessRuleError struct {
 	StatusCode int    `json:"statusCode"`
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *BusinessRuleError) Error() string {
@@ -91,11 +245,32 @@ func (e *BusinessRuleError) Unwrap() error {
 	return ErrBusinessRule
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *BusinessRuleError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *BusinessRuleError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *BusinessRuleError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *BusinessRuleError) errorCode() string {
+	return e.Code
+}
+
 // ExceptionError represents a 500 Internal Server Error response
 type ExceptionError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *ExceptionError) Error() string {
@@ -109,11 +284,23 @@ func (e *ExceptionError) Unwrap() error {
 	return ErrException
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *ExceptionError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *ExceptionError) Temporary() bool {
+	return e.Retryable()
+}
+
 // IntegrationError represents a 503 Service Unavailable response
 type IntegrationError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *IntegrationError) Error() string {
@@ -127,11 +314,23 @@ func (e *IntegrationError) Unwrap() error {
 	return ErrIntegration
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *IntegrationError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *IntegrationError) Temporary() bool {
+	return e.Retryable()
+}
+
 // UnauthorizedError represents a 401 Unauthorized response
 type UnauthorizedError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *UnauthorizedError) Error() string {
@@ -145,11 +344,23 @@ func (e *UnauthorizedError) Unwrap() error {
 	return ErrUnauthorized
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *UnauthorizedError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *UnauthorizedError) Temporary() bool {
+	return e.Retryable()
+}
+
 // ForbiddenError represents a 403 Forbidden response
 type ForbiddenError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *ForbiddenError) Error() string {
@@ -163,12 +374,24 @@ func (e *ForbiddenError) Unwrap() error {
 	return ErrForbidden
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *ForbiddenError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *ForbiddenError) Temporary() bool {
+	return e.Retryable()
+}
+
 // NotFoundError represents a 404 Not Found response
 type NotFoundError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Resource   string `json:"resource,omitempty"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *NotFoundError) Error() string {
@@ -185,12 +408,24 @@ func (e *NotFoundError) Unwrap() error {
 	return ErrNotFound
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *NotFoundError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *NotFoundError) Temporary() bool {
+	return e.Retryable()
+}
+
 // UnprocessableEntityError represents a 422 Unprocessable Entity response
 type UnprocessableEntityError struct {
 	StatusCode int    `json:"statusCode"`
 	Code       string `json:"code,omitempty"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *UnprocessableEntityError) Error() string {
@@ -207,6 +442,25 @@ func (e *UnprocessableEntityError) Unwrap() error {
 	return ErrUnprocessable
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *UnprocessableEntityError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *UnprocessableEntityError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *UnprocessableEntityError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *UnprocessableEntityError) errorCode() string {
+	return e.Code
+}
+
 // InsufficientFundsError represents a 402 Payment Required response
 type InsufficientFundsError struct {
 	StatusCode int    `json:"statusCode"`
@@ -215,6 +469,8 @@ type InsufficientFundsError struct {
 	Required   int64  `json:"required,omitempty"`
 	Available  int64  `json:"available,omitempty"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *InsufficientFundsError) Error() string {
@@ -231,11 +487,32 @@ func (e *InsufficientFundsError) Unwrap() error {
 	return ErrInsufficientFunds
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *InsufficientFundsError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *InsufficientFundsError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *InsufficientFundsError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *InsufficientFundsError) errorCode() string {
+	return e.Code
+}
+
 // MethodNotAllowedError represents a 405 Method Not Allowed response
 type MethodNotAllowedError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *MethodNotAllowedError) Error() string {
@@ -249,12 +526,24 @@ func (e *MethodNotAllowedError) Unwrap() error {
 	return ErrMethodNotAllowed
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *MethodNotAllowedError) Retryable() bool {
+	return isRetryable(e.StatusCode, "")
+}
+
+// Temporary reports the same as Retryable
+func (e *MethodNotAllowedError) Temporary() bool {
+	return e.Retryable()
+}
+
 // PreconditionFailedError represents a 412 Precondition Failed response
 type PreconditionFailedError struct {
 	StatusCode int    `json:"statusCode"`
 	Code       string `json:"code,omitempty"`
 	Message    string `json:"message"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *PreconditionFailedError) Error() string {
@@ -271,6 +560,25 @@ func (e *PreconditionFailedError) Unwrap() error {
 	return ErrPreconditionFailed
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *PreconditionFailedError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *PreconditionFailedError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *PreconditionFailedError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *PreconditionFailedError) errorCode() string {
+	return e.Code
+}
+
 // ThirdPartyError represents a 424 Failed Dependency response (third-party service failure)
 type ThirdPartyError struct {
 	StatusCode int    `json:"statusCode"`
@@ -278,6 +586,8 @@ type ThirdPartyError struct {
 	Message    string `json:"message"`
 	Service    string `json:"service,omitempty"`
 	Err        error  `json:"-"`
+
+	ErrorMeta `json:"-"`
 }
 
 func (e *ThirdPartyError) Error() string {
@@ -294,6 +604,25 @@ func (e *ThirdPartyError) Unwrap() error {
 	return ErrThirdParty
 }
 
+// Retryable reports whether the request may succeed if sent again
+func (e *ThirdPartyError) Retryable() bool {
+	return isRetryable(e.StatusCode, e.Code)
+}
+
+// Temporary reports the same as Retryable
+func (e *ThirdPartyError) Temporary() bool {
+	return e.Retryable()
+}
+
+// Is matches the business code sentinel of the error code, e.g. ErrDailyLimitExceeded
+func (e *ThirdPartyError) Is(target error) bool {
+	return isBusinessCode(e.Code, target)
+}
+
+func (e *ThirdPartyError) errorCode() string {
+	return e.Code
+}
+
 // PanicError represents a recovered panic during HTTP operations
 type PanicError struct {
 	Message string
@@ -512,3 +841,53 @@ func parseErrorResponse(resp *http.Response, body []byte) error {
 		Message:    string(body),
 	}
 }
+
+// errorClass returns a low-cardinality label for an error, used in metrics and logs
+func errorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ErrDictScanLimit):
+		return "dict_scan_limit"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, ErrBusinessRule):
+		return "business_rule"
+	case errors.Is(err, ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrMethodNotAllowed):
+		return "method_not_allowed"
+	case errors.Is(err, ErrPreconditionFailed):
+		return "precondition_failed"
+	case errors.Is(err, ErrUnprocessable):
+		return "unprocessable"
+	case errors.Is(err, ErrThirdParty):
+		return "third_party"
+	case errors.Is(err, ErrException):
+		return "server"
+	case errors.Is(err, ErrIntegration):
+		return "integration"
+	case errors.Is(err, ErrPanic):
+		return "panic"
+	case errors.Is(err, ErrAPI):
+		return "api"
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "transport"
+	}
+	return "unknown"
+}
@@ -2,10 +2,15 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 )
 
 func TestParseErrorResponse(t *testing.T) {
@@ -457,3 +462,102 @@ func TestPanicErrorFields(t *testing.T) {
 		t.Error("PanicError should match ErrPanic with errors.Is()")
 	}
 }
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"validation", &ValidationError{StatusCode: 400}, "validation"},
+		{"business rule", &BusinessRuleError{StatusCode: 409}, "business_rule"},
+		{"not found", &NotFoundError{StatusCode: 404}, "not_found"},
+		{"integration", &IntegrationError{StatusCode: 503}, "integration"},
+		{"wrapped deadline", fmt.Errorf("request failed: %w", context.DeadlineExceeded), "timeout"},
+		{"transport", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "https://x", Err: errors.New("refused")}), "transport"},
+		{"unknown", errors.New("boom"), "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorClass(tt.err); got != tt.want {
+				t.Errorf("errorClass() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorMeta(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-Id", "corr-123")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"LIMIT_EXCEEDED","message":"daily limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, NoRetryPolicy())
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	err := c.post(ctx, "/accounts/12345/transfer", map[string]int{"transferAmount": 1}, nil)
+
+	var bizErr *BusinessRuleError
+	if !errors.As(err, &bizErr) {
+		t.Fatalf("post = %v; want BusinessRuleError", err)
+	}
+	if bizErr.Method != http.MethodPost || bizErr.Route != "/accounts/{id}/transfer" || bizErr.RequestID != "corr-123" ||
+		bizErr.RetryAfter != 7*time.Second || bizErr.IdempotencyKey != "key-1" || bizErr.Attempt != 1 {
+		t.Errorf("meta = %+v", bizErr.ErrorMeta)
+	}
+	if string(bizErr.Body) != `{"code":"LIMIT_EXCEEDED","message":"daily limit exceeded"}` || bizErr.Header.Get("Retry-After") != "7" {
+		t.Errorf("body = %s, header = %v", bizErr.Body, bizErr.Header)
+	}
+
+	meta, ok := ErrorMetaOf(fmt.Errorf("wrapped: %w", err))
+	if !ok || meta.RequestID != "corr-123" {
+		t.Errorf("ErrorMetaOf = %+v, %v", meta, ok)
+	}
+	if _, ok := ErrorMetaOf(errors.New("plain")); ok {
+		t.Error("ErrorMetaOf(plain error) = ok")
+	}
+}
+
+func TestErrorMetaBodyIsCapped(t *testing.T) {
+	body := bytes.Repeat([]byte("x"), MaxErrorBodySize+100)
+	meta := newErrorMeta(&RequestInfo{Method: http.MethodGet, Attempt: 2},
+		&http.Request{Header: http.Header{}}, &http.Response{Header: http.Header{}}, body)
+	if len(meta.Body) != MaxErrorBodySize || meta.Attempt != 2 {
+		t.Errorf("len(Body) = %d, Attempt = %d", len(meta.Body), meta.Attempt)
+	}
+}
+
+func TestRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"exception", &ExceptionError{StatusCode: 500}, true},
+		{"integration", &IntegrationError{StatusCode: 503}, true},
+		{"rate limited", &APIError{StatusCode: 429}, true},
+		{"bad gateway", &APIError{StatusCode: 502}, true},
+		{"not found", &NotFoundError{StatusCode: 404}, false},
+		{"validation", &ValidationError{StatusCode: 400}, false},
+		{"business rule", &BusinessRuleError{StatusCode: 409, Code: "LIMIT_EXCEEDED"}, false},
+		{"retryable business code", &ThirdPartyError{StatusCode: 424, Code: "AB03"}, true},
+		{"wrapped", fmt.Errorf("op: %w", &ExceptionError{StatusCode: 500}), true},
+		{"transport", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v; want %v", tt.err, got, tt.want)
+			}
+			var re RetryableError
+			if errors.As(tt.err, &re) && re.Temporary() != re.Retryable() {
+				t.Errorf("Temporary() != Retryable() for %v", tt.err)
+			}
+		})
+	}
+}
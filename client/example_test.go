@@ -60,15 +60,17 @@ func ExampleNewWithCertFiles() {
 // LoggingHook is a custom hook for logging HTTP requests/responses
 type LoggingHook struct{}
 
-func (h *LoggingHook) BeforeRequest(ctx context.Context, method, path string, body any) {
-	log.Printf("→ %s %s", method, path)
+func (h *LoggingHook) BeforeRequest(ctx context.Context, req *client.RequestInfo) context.Context {
+	log.Printf("→ %s %s (attempt %d)", req.Method, req.Path, req.Attempt)
+	return ctx
 }
 
-func (h *LoggingHook) AfterResponse(ctx context.Context, method, path string, statusCode int, duration time.Duration, err error) {
-	if err != nil {
-		log.Printf("← %s %s [%d] %v (error: %v)", method, path, statusCode, duration, err)
+func (h *LoggingHook) AfterResponse(ctx context.Context, resp *client.ResponseInfo) {
+	req := resp.Request
+	if resp.Err != nil {
+		log.Printf("← %s %s [%d] %v (error: %v)", req.Method, req.Path, resp.StatusCode, resp.Duration, resp.Err)
 	} else {
-		log.Printf("← %s %s [%d] %v", method, path, statusCode, duration)
+		log.Printf("← %s %s [%d] %v", req.Method, req.Path, resp.StatusCode, resp.Duration)
 	}
 }
 
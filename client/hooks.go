@@ -2,23 +2,44 @@ package client
 
 import (
 	"context"
-	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
 )
 
 // Hook provides observability into HTTP client operations.
 // Implement this interface to add custom logging, metrics, tracing, etc.
-type Hook interface {
-	// BeforeRequest is called before making an HTTP request
-	BeforeRequest(ctx context.Context, method, path string, body any)
+// The observability package's LoggerHook, MetricsHook and IdempotencyHook
+// implement it and can be passed to WithHooks directly.
+type Hook = observability.Hook
 
-	// AfterResponse is called after receiving an HTTP response
-	AfterResponse(ctx context.Context, method, path string, statusCode int, duration time.Duration, err error)
-}
+// RequestInfo describes a single HTTP attempt passed to Hook.BeforeRequest
+type RequestInfo = observability.RequestInfo
+
+// ResponseInfo describes the outcome of an HTTP attempt passed to Hook.AfterResponse
+type ResponseInfo = observability.ResponseInfo
 
 // NoOpHook is a hook that does nothing - useful for testing
 type NoOpHook struct{}
 
-func (h *NoOpHook) BeforeRequest(ctx context.Context, method, path string, body any) {}
+func (h *NoOpHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
+	return ctx
+}
+
+func (h *NoOpHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {}
+
+// runBeforeHooks calls BeforeRequest on each hook in order, threading the derived context
+func runBeforeHooks(ctx context.Context, hooks []Hook, req *RequestInfo) context.Context {
+	for _, hook := range hooks {
+		if next := hook.BeforeRequest(ctx, req); next != nil {
+			ctx = next
+		}
+	}
+	return ctx
+}
 
-func (h *NoOpHook) AfterResponse(ctx context.Context, method, path string, statusCode int, duration time.Duration, err error) {
+// notifyHooks calls AfterResponse on each hook in reverse order
+func notifyHooks(ctx context.Context, hooks []Hook, resp *ResponseInfo) {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].AfterResponse(ctx, resp)
+	}
 }
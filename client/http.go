@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -20,34 +21,48 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-// idempotencyKeyCtxKey is the context key for idempotency key
-type idempotencyKeyCtxKey struct{}
-
 // IdempotencyKeyHeader is the header name for idempotency key (required for PIX operations)
 const IdempotencyKeyHeader = "idempotencyKey"
 
 // WithIdempotencyKey adds an idempotency key to the context.
 // Use this for PIX operations to prevent duplicate transactions.
+// The key is shared with observability.WithIdempotencyKey, so keys set by
+// observability.IdempotencyHook are sent as well.
 func WithIdempotencyKey(ctx context.Context, key string) context.Context {
-	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+	return observability.WithIdempotencyKey(ctx, key)
 }
 
 // getIdempotencyKey extracts the idempotency key from context, if present
 func getIdempotencyKey(ctx context.Context) (string, bool) {
-	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	key, ok := observability.GetIdempotencyKey(ctx)
 	return key, ok && key != ""
 }
 
-// do performs an HTTP request with light retries on idempotent methods (GET/PUT)
+// do performs an HTTP request, retrying failed attempts according to the configured RetryPolicy
 // Includes panic recovery to prevent crashes from unexpected runtime errors
 func (c *Client) do(ctx context.Context, method, path string, body, response any) (err error) {
+	route := routeTemplate(path)
+	// CPFs and CNPJs in the path are masked in logs and spans
+	logPath := types.RedactDocuments(path)
+
+	// Registered before panic recovery so recovered panics are counted as errors
+	if c.metrics != nil {
+		c.metrics.IncrementActiveRequests(ctx)
+		defer func() {
+			if err != nil {
+				c.metrics.RecordError(ctx, method, route, errorClass(err))
+			}
+			c.metrics.DecrementActiveRequests(ctx)
+		}()
+	}
+
 	// Panic recovery middleware
 	defer func() {
 		if r := recover(); r != nil {
 			stack := string(debug.Stack())
-			c.config.Logger.Error("panic recovered in HTTP request",
+			c.logger(ctx).Error("panic recovered in HTTP request",
 				"method", method,
-				"path", path,
+				"path", logPath,
 				"panic", r,
 				"stack", stack,
 			)
@@ -58,21 +73,30 @@ func (c *Client) do(ctx context.Context, method, path string, body, response any
 		}
 	}()
 
-	url := joinURL(c.config.BaseURL, path)
+	// Select the API key, base URL and connection pool of the call's tenant
+	creds, err := c.credentials(ctx)
+	if err != nil {
+		return err
+	}
+	url := joinURL(creds.baseURL, path)
 
 	// Start OpenTelemetry span if tracing is enabled
 	var span trace.Span
 	if c.config.TracingEnabled {
 		tracer := c.getTracer()
-		ctx, span = tracer.Start(ctx, method+" "+path,
+		ctx, span = tracer.Start(ctx, method+" "+logPath,
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(
 				attribute.String("http.method", method),
-				attribute.String("http.url", url),
+				attribute.String("http.url", joinURL(creds.baseURL, logPath)),
+				attribute.String("http.route", route),
 				attribute.String("rpc.system", "http"),
 				attribute.String("rpc.service", "evertec-conta-pagamento"),
 			),
 		)
+		if creds.tenant != "" {
+			span.SetAttributes(attribute.String(observability.TenantAttribute, creds.tenant))
+		}
 		defer span.End()
 	}
 
@@ -89,12 +113,22 @@ func (c *Client) do(ctx context.Context, method, path string, body, response any
 		}
 	}
 
+	// Validate the request body before it reaches the network
+	if v, ok := body.(types.Validator); ok && !c.config.SkipValidation {
+		if err := v.Validate(); err != nil {
+			if span != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "request validation failed")
+			}
+			return newRequestValidationError(err)
+		}
+	}
+
 	// Serialize body if provided
 	var bodyBytes []byte
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
-			notifyHooks(ctx, c.config.Hooks, method, path, 0, 0, err)
 			if span != nil {
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "failed to marshal request body")
@@ -107,22 +141,67 @@ func (c *Client) do(ctx context.Context, method, path string, body, response any
 		}
 	}
 
-	isIdempotent := method == http.MethodGet || method == http.MethodPut
-	maxAttempts := 1
-	if isIdempotent {
-		maxAttempts = 3
+	policy := c.config.RetryPolicy
+
+	group := endpointGroup(route)
+	var breaker *circuitBreaker
+	if c.breakers != nil {
+		breaker = c.breakers.get(creds.tenant, group)
 	}
 
+	// An attempt let through by the breaker may hold a half-open probe until done is
+	// called; attempts aborted before that (request errors, panics) must release it
+	var generation uint64
+	allowed := false
+	defer func() {
+		if allowed {
+			breaker.release(generation)
+		}
+	}()
+
 	var lastErr error
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		var bodyReader io.Reader
-		if len(bodyBytes) > 0 {
-			bodyReader = bytes.NewReader(bodyBytes)
+	var queued time.Duration
+	for attempt := 1; ; attempt++ {
+		// Wait for the client-side rate limits of the endpoint group
+		if c.limiter != nil {
+			wait, err := c.limiter.wait(ctx, creds.tenant, group)
+			queued += wait
+			if c.metrics != nil {
+				c.metrics.RecordRateLimitWait(ctx, method, route, string(group), wait)
+			}
+			if span != nil {
+				span.SetAttributes(attribute.Int64("http.rate_limit_wait_ms", queued.Milliseconds()))
+			}
+			if err != nil {
+				if span != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "rate limit wait aborted")
+				}
+				if lastErr != nil {
+					return errors.Join(lastErr, err)
+				}
+				return err
+			}
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		// Fail fast while the endpoint group's circuit is open
+		if breaker != nil {
+			var err error
+			if generation, err = breaker.allow(ctx); err != nil {
+				if span != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "circuit open")
+				}
+				if lastErr != nil {
+					return errors.Join(lastErr, err)
+				}
+				return err
+			}
+			allowed = true
+		}
+
+		req, err := c.newRequest(ctx, method, url, creds.apiKey, bodyBytes)
 		if err != nil {
-			notifyHooks(ctx, c.config.Hooks, method, path, 0, 0, err)
 			if span != nil {
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "failed to create request")
@@ -130,117 +209,206 @@ func (c *Client) do(ctx context.Context, method, path string, body, response any
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
-		if bodyReader != nil {
-			req.Header.Set("Content-Type", "application/json")
+		info := &RequestInfo{
+			Method:  method,
+			Path:    path,
+			Route:   route,
+			Body:    bodyBytes,
+			Header:  req.Header,
+			Attempt: attempt,
+			Tenant:  creds.tenant,
 		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("User-Agent", c.config.UserAgent)
-		req.Header.Set(APIKeyHeader, c.config.APIKey)
-
-		// Add idempotency key header if present in context
-		if idempotencyKey, ok := getIdempotencyKey(ctx); ok {
-			req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		attemptCtx, result := c.send(ctx, creds.http, req, info)
+		if breaker != nil {
+			allowed = false
+			breaker.done(ctx, generation, result)
 		}
-
-		// Inject trace context into headers for distributed tracing
-		if c.config.TracingEnabled {
-			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
-		}
-
-		startTime := time.Now()
-		for _, hook := range c.config.Hooks {
-			hook.BeforeRequest(ctx, method, path, body)
+		if c.limiter != nil && result.StatusCode == http.StatusTooManyRequests {
+			if retryAfter, ok := parseRetryAfter(result.Header, time.Now()); ok {
+				c.limiter.throttle(creds.tenant, group, retryAfter)
+			}
 		}
 
-		resp, err := c.http.Do(req)
-		duration := time.Since(startTime)
-
-		statusCode := 0
-		if resp != nil {
-			statusCode = resp.StatusCode
+		// Keep an idempotency key introduced by a hook so retries reuse it
+		_, hasKey := getIdempotencyKey(ctx)
+		if !hasKey {
+			if key, ok := getIdempotencyKey(attemptCtx); ok {
+				ctx = WithIdempotencyKey(ctx, key)
+				hasKey = true
+			}
 		}
-		notifyHooks(ctx, c.config.Hooks, method, path, statusCode, duration, err)
 
-		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
-			if span != nil {
-				span.RecordError(err)
+		if span != nil {
+			span.SetAttributes(attribute.Int("http.retry_count", attempt-1))
+			if result.StatusCode != 0 {
 				span.SetAttributes(
-					attribute.Int("http.retry_count", attempt-1),
+					attribute.Int("http.status_code", result.StatusCode),
+					attribute.Int64("http.response_time_ms", result.Duration.Milliseconds()),
+					attribute.Int("http.response_content_length", len(result.Body)),
 				)
 			}
-			if isIdempotent && attempt < maxAttempts {
-				time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
-				continue
-			}
-			if span != nil {
-				span.SetStatus(codes.Error, "request failed")
-			}
-			return lastErr
 		}
 
-		respBody, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			if span != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "failed to read response body")
-			}
-			return fmt.Errorf("failed to read response body: %w", err)
-		}
-
-		if span != nil {
-			span.SetAttributes(
-				attribute.Int("http.status_code", resp.StatusCode),
-				attribute.Int64("http.response_time_ms", duration.Milliseconds()),
-				attribute.Int("http.response_content_length", len(respBody)),
+		if result.StatusCode != 0 {
+			c.logger(ctx).Debug("HTTP response",
+				"method", method,
+				"path", logPath,
+				"status", result.StatusCode,
+				"duration", result.Duration,
+				"attempt", attempt,
 			)
 		}
 
-		c.config.Logger.Debug("HTTP response",
-			"method", method,
-			"path", path,
-			"status", resp.StatusCode,
-			"duration", duration,
-		)
-
-		if resp.StatusCode >= 400 {
-			parseErr := parseErrorResponse(resp, respBody)
-			if isIdempotent && attempt < maxAttempts && isRetryableStatus(resp.StatusCode) {
-				lastErr = parseErr
-				time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
-				continue
+		if result.Err == nil {
+			if response != nil && len(result.Body) > 0 {
+				if err := json.Unmarshal(result.Body, response); err != nil {
+					if span != nil {
+						span.RecordError(err)
+						span.SetStatus(codes.Error, "failed to unmarshal response")
+					}
+					return fmt.Errorf("failed to unmarshal response: %w", err)
+				}
 			}
 			if span != nil {
-				span.RecordError(parseErr)
-				span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", resp.StatusCode))
+				span.SetStatus(codes.Ok, "")
 			}
-			return parseErr
+			return nil
 		}
 
-		if response != nil && len(respBody) > 0 {
-			if err := json.Unmarshal(respBody, response); err != nil {
-				if span != nil {
-					span.RecordError(err)
-					span.SetStatus(codes.Error, "failed to unmarshal response")
+		if span != nil {
+			span.RecordError(result.Err)
+		}
+
+		// A body read failure on a successful response is not retried
+		retryable := (result.StatusCode == 0 || result.StatusCode >= 400) && policy.shouldRetry(result)
+		if retryable && attempt < policy.maxAttemptsFor(method, hasKey) {
+			if delay, ok := policy.delay(attempt, result); ok {
+				c.reportRetry(ctx, span, info, result, delay)
+				if err := sleepContext(ctx, delay); err != nil {
+					if span != nil {
+						span.SetStatus(codes.Error, "retry aborted")
+					}
+					return errors.Join(result.Err, err)
 				}
-				return fmt.Errorf("failed to unmarshal response: %w", err)
+				lastErr = result.Err
+				continue
 			}
 		}
 
 		if span != nil {
-			span.SetStatus(codes.Ok, "")
+			switch {
+			case result.StatusCode >= 400:
+				span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", result.StatusCode))
+			case result.StatusCode == 0:
+				span.SetStatus(codes.Error, "request failed")
+			default:
+				span.SetStatus(codes.Error, "failed to read response body")
+			}
 		}
-		return nil
+		return result.Err
 	}
+}
 
-	if lastErr != nil {
-		if span != nil {
-			span.SetStatus(codes.Error, "max retries exceeded")
+// reportRetry logs, counts and records on the span a retry about to happen
+func (c *Client) reportRetry(ctx context.Context, span trace.Span, info *RequestInfo, result *ResponseInfo, delay time.Duration) {
+	next := info.Attempt + 1
+	c.logger(ctx).Warn("Retrying request",
+		"method", info.Method,
+		"path", types.RedactDocuments(info.Path),
+		"attempt", next,
+		"status", result.StatusCode,
+		"delay", delay,
+		"error", result.Err,
+	)
+	c.recordRetry(ctx, info.Method, info.Route, next)
+	if span != nil {
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("http.retry_attempt", next),
+			attribute.Int("http.status_code", result.StatusCode),
+			attribute.Int64("http.retry_delay_ms", delay.Milliseconds()),
+			attribute.String("error.type", result.ErrorType),
+		))
+	}
+}
+
+// newRequest builds an HTTP request with the standard SDK headers
+func (c *Client) newRequest(ctx context.Context, method, url, apiKey string, body []byte) (*http.Request, error) {
+	var bodyReader io.Reader
+	if len(body) > 0 {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set(APIKeyHeader, apiKey)
+	return req, nil
+}
+
+// send performs a single HTTP attempt wrapped by hooks and attempt metrics.
+// It returns the context derived by the hooks and the attempt outcome, whose Err
+// holds the transport error, body read error or typed API error.
+func (c *Client) send(ctx context.Context, httpClient *http.Client, req *http.Request, info *RequestInfo) (context.Context, *ResponseInfo) {
+	attemptCtx := runBeforeHooks(ctx, c.config.Hooks, info)
+
+	// Add idempotency key header if present in context
+	if idempotencyKey, ok := getIdempotencyKey(attemptCtx); ok {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+
+	// Inject trace context into headers for distributed tracing
+	if c.config.TracingEnabled {
+		otel.GetTextMapPropagator().Inject(attemptCtx, propagation.HeaderCarrier(req.Header))
+	}
+
+	result := &ResponseInfo{Request: info}
+	startTime := time.Now()
+	resp, err := httpClient.Do(req.WithContext(attemptCtx))
+	if err != nil {
+		result.Err = fmt.Errorf("request failed: %w", err)
+	} else {
+		result.StatusCode = resp.StatusCode
+		result.Header = resp.Header
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		result.Body = respBody
+		switch {
+		case readErr != nil:
+			result.Err = fmt.Errorf("failed to read response body: %w", readErr)
+		case resp.StatusCode >= 400:
+			result.Err = parseErrorResponse(resp, respBody)
+			setErrorMeta(result.Err, newErrorMeta(info, req, resp, respBody))
 		}
-		return lastErr
 	}
-	return errors.New("request failed without response")
+	result.Duration = time.Since(startTime)
+	result.ErrorType = errorClass(result.Err)
+
+	c.recordAttempt(attemptCtx, info.Method, info.Route, result.StatusCode, result.Duration, len(info.Body), len(result.Body))
+	notifyHooks(attemptCtx, c.config.Hooks, result)
+	return attemptCtx, result
+}
+
+// recordAttempt records request metrics for a single HTTP attempt, if metrics are enabled
+func (c *Client) recordAttempt(ctx context.Context, method, route string, statusCode int, duration time.Duration, reqSize, respSize int) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordRequest(ctx, method, route, statusCode, duration, int64(reqSize), int64(respSize))
+}
+
+// recordRetry records a retry metric, if metrics are enabled
+func (c *Client) recordRetry(ctx context.Context, method, route string, attempt int) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordRetry(ctx, method, route, attempt)
 }
 
 // getTracer returns the appropriate tracer based on config
@@ -272,12 +440,6 @@ func joinURL(base, path string) string {
 	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
 }
 
-func notifyHooks(ctx context.Context, hooks []Hook, method, path string, status int, duration time.Duration, err error) {
-	for _, hook := range hooks {
-		hook.AfterResponse(ctx, method, path, status, duration, err)
-	}
-}
-
 // get performs a GET request
 func (c *Client) get(ctx context.Context, path string, response any) error {
 	return c.do(ctx, http.MethodGet, path, nil, response)
@@ -7,11 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 // TestJoinURL tests the URL joining logic
@@ -319,21 +325,22 @@ type mockAfterResponse struct {
 	err        error
 }
 
-func (h *mockHook) BeforeRequest(ctx context.Context, method, path string, body any) {
+func (h *mockHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
 	h.beforeRequestCalls = append(h.beforeRequestCalls, mockBeforeRequest{
-		method: method,
-		path:   path,
-		body:   body,
+		method: req.Method,
+		path:   req.Path,
+		body:   req.Body,
 	})
+	return ctx
 }
 
-func (h *mockHook) AfterResponse(ctx context.Context, method, path string, statusCode int, duration time.Duration, err error) {
+func (h *mockHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
 	h.afterResponseCalls = append(h.afterResponseCalls, mockAfterResponse{
-		method:     method,
-		path:       path,
-		statusCode: statusCode,
-		duration:   duration,
-		err:        err,
+		method:     resp.Request.Method,
+		path:       resp.Request.Path,
+		statusCode: resp.StatusCode,
+		duration:   resp.Duration,
+		err:        resp.Err,
 	})
 }
 
@@ -394,7 +401,12 @@ func TestNotifyHooks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			notifyHooks(ctx, tt.hooks, tt.method, tt.path, tt.status, tt.duration, tt.err)
+			notifyHooks(ctx, tt.hooks, &ResponseInfo{
+				Request:    &RequestInfo{Method: tt.method, Path: tt.path},
+				StatusCode: tt.status,
+				Duration:   tt.duration,
+				Err:        tt.err,
+			})
 
 			if tt.expectCall {
 				for _, hook := range tt.hooks {
@@ -1217,9 +1229,261 @@ func TestPanicRecovery(t *testing.T) {
 // panicOnBeforeRequestHook is a test hook that panics on BeforeRequest
 type panicOnBeforeRequestHook struct{}
 
-func (h *panicOnBeforeRequestHook) BeforeRequest(ctx context.Context, method, path string, body any) {
+func (h *panicOnBeforeRequestHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
 	panic("intentional panic for testing")
 }
 
-func (h *panicOnBeforeRequestHook) AfterResponse(ctx context.Context, method, path string, statusCode int, duration time.Duration, err error) {
+func (h *panicOnBeforeRequestHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
+}
+
+// TestRouteTemplate tests reduction of request paths to route templates
+func TestRouteTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/accounts", "/accounts"},
+		{"/accounts/12345/balance", "/accounts/{id}/balance"},
+		{"/accounts/7", "/accounts/{id}"},
+		{"/accounts/12345/statement?first=0&max=50", "/accounts/{id}/statement"},
+		{"/accounts/12345678901/corporate", "/accounts/{id}/corporate"},
+		{"/pix/keys/12345/user@email.com", "/pix/keys/{id}/{id}"},
+		{"/pix/refunds/3f2b1c4e-8a9d-4e2f-b1c3-1234567890ab/cancel", "/pix/refunds/{id}/cancel"},
+		{"/pix/transactions/payment/E12345678202401011200abcdefghijk", "/pix/transactions/payment/{id}"},
+		{"/bankslip/v2", "/bankslip/v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := routeTemplate(tt.path); got != tt.expected {
+				t.Errorf("routeTemplate(%q) = %q; want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMetricsRecording tests that the client emits OpenTelemetry metrics per templated route
+func TestMetricsRecording(t *testing.T) {
+	attempts := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"account not found"}`))
+	}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client, err := New(
+		server.URL,
+		"test-api-key",
+		newTestTLSConfig(server),
+		WithMeterProvider(mp),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.get(ctx, "/accounts/12345", nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			data, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range data.DataPoints {
+				if route, ok := dp.Attributes.Value("http.route"); ok && route.AsString() != "/accounts/{id}" {
+					t.Errorf("%s: http.route = %q; want %q", m.Name, route.AsString(), "/accounts/{id}")
+				}
+				if class, ok := dp.Attributes.Value("error.type"); ok && class.AsString() != "not_found" {
+					t.Errorf("error.type = %q; want %q", class.AsString(), "not_found")
+				}
+				sums[m.Name] += dp.Value
+			}
+		}
+	}
+
+	expected := map[string]int64{
+		"evertec.sdk.requests.total":  2,
+		"evertec.sdk.retries.total":   1,
+		"evertec.sdk.errors.total":    1,
+		"evertec.sdk.requests.active": 0,
+	}
+	for name, want := range expected {
+		if got := sums[name]; got != want {
+			t.Errorf("%s = %d; want %d", name, got, want)
+		}
+	}
+}
+
+// TestObservabilityHooksIntegration tests that observability hooks plug into WithHooks
+func TestObservabilityHooksIntegration(t *testing.T) {
+	var keys []string
+	attempts := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":"ok"}`))
+	}))
+	defer server.Close()
+
+	var logs strings.Builder
+	logger := observability.NewLogger(observability.WithHandler(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	metrics, err := observability.NewMetricsWithProvider(sdkmetric.NewMeterProvider())
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+	idempotencyHook := observability.NewIdempotencyHook(observability.NewIdempotencyProvider())
+
+	client, err := New(
+		server.URL,
+		"test-api-key",
+		newTestTLSConfig(server),
+		WithHooks(
+			observability.NewLoggerHook(logger),
+			observability.NewMetricsHook(metrics),
+			idempotencyHook,
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.put(context.Background(), "/accounts/12345", map[string]string{"a": "b"}, nil); err != nil {
+		t.Fatalf("PUT request failed: %v", err)
+	}
+
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the hook's idempotency key to be sent and reused on retry, got %q and %q", keys[0], keys[1])
+	}
+	if keys[0] != idempotencyHook.GetKey() {
+		t.Errorf("sent key %q does not match hook key %q", keys[0], idempotencyHook.GetKey())
+	}
+	if !strings.Contains(logs.String(), "SDK response") {
+		t.Error("expected LoggerHook to log the response")
+	}
+}
+
+// TestHookContextPropagation tests that the context derived in BeforeRequest reaches AfterResponse
+func TestHookContextPropagation(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":"ok"}`))
+	}))
+	defer server.Close()
+
+	hook := &contextHook{}
+	client, err := New(server.URL, "test-api-key", newTestTLSConfig(server), WithHooks(hook))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("GET request failed: %v", err)
+	}
+
+	if hook.seen != "attempt-1" {
+		t.Errorf("AfterResponse context value = %q; want %q", hook.seen, "attempt-1")
+	}
+	if hook.requestID != "req-1" {
+		t.Errorf("AfterResponse header X-Request-Id = %q; want %q", hook.requestID, "req-1")
+	}
+	if hook.body != `{"result":"ok"}` {
+		t.Errorf("AfterResponse body = %q", hook.body)
+	}
+}
+
+type contextHookKey struct{}
+
+// contextHook derives a context in BeforeRequest and reads it back in AfterResponse
+type contextHook struct {
+	seen      string
+	requestID string
+	body      string
+}
+
+func (h *contextHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
+	return context.WithValue(ctx, contextHookKey{}, fmt.Sprintf("attempt-%d", req.Attempt))
+}
+
+func (h *contextHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
+	h.seen, _ = ctx.Value(contextHookKey{}).(string)
+	h.requestID = resp.Header.Get("X-Request-Id")
+	h.body = string(resp.Body)
+}
+
+// TestRequestValidation tests that invalid request bodies are rejected before being sent
+func TestRequestValidation(t *testing.T) {
+	var calls int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	req := &types.PixPaymentRequest{AccountID: 1, RecipientAccountType: "XXXX", OperationAmount: -1}
+
+	client, err := New(server.URL, "test-api-key", newTestTLSConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.DoPixPayment(context.Background(), req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if validationErr.StatusCode != 0 {
+		t.Errorf("StatusCode = %d; want 0 for client-side validation", validationErr.StatusCode)
+	}
+	if len(validationErr.Errors) < 2 {
+		t.Errorf("expected all field errors to be reported, got %v", validationErr.Errors)
+	}
+	if calls != 0 {
+		t.Errorf("server received %d requests; want 0", calls)
+	}
+
+	unchecked, err := New(server.URL, "test-api-key", newTestTLSConfig(server), WithoutValidation())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer unchecked.Close()
+
+	if _, err := unchecked.DoPixPayment(context.Background(), req); err != nil {
+		t.Fatalf("expected request to be sent with validation disabled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("server received %d requests; want 1", calls)
+	}
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"crypto/tls"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"go.opentelemetry.io/otel/metric"
@@ -61,6 +62,25 @@ func WithTLSConfig(tlsConfig *tls.Config) Option {
 	}
 }
 
+// WithTransport sets the HTTP transport used for requests, replacing the default mTLS
+// transport. Use it to record or replay traffic with a cassette.Recorder; the TLS
+// config passed to New may then be nil.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = transport
+	}
+}
+
+// WithCredentialsResolver selects the API key, mTLS certificate and base URL of each call,
+// typically from the tenant set with WithTenant, so one client can serve several issuers.
+// Each tenant certificate gets its own connection pool. The API key and base URL passed
+// to New may then be empty.
+func WithCredentialsResolver(resolver CredentialsResolver) Option {
+	return func(c *Config) {
+		c.CredentialsResolver = resolver
+	}
+}
+
 // WithUserAgent sets a custom User-Agent header
 func WithUserAgent(userAgent string) Option {
 	return func(c *Config) {
@@ -118,3 +138,52 @@ func WithAutoIdempotency() Option {
 		c.AutoIdempotency = true
 	}
 }
+
+// WithRetryPolicy sets the retry policy for failed requests.
+// Start from DefaultRetryPolicy() and adjust the fields you need.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Config) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// WithCircuitBreaker enables circuit breakers per endpoint group (PIX, DICT lookups,
+// transfers, statements, cards, bills, backoffice and other). While a group's circuit
+// is open its requests fail fast with ErrCircuitOpen instead of waiting on a degraded
+// API. Zero fields of the policy take the values of DefaultCircuitBreakerPolicy.
+func WithCircuitBreaker(policy CircuitBreakerPolicy) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = &policy
+	}
+}
+
+// WithRateLimit enables client-side token bucket rate limits per endpoint group and
+// overall. Requests wait for a token before being sent; a wait that would outlast the
+// context deadline fails immediately with an error wrapping context.DeadlineExceeded.
+func WithRateLimit(policy RateLimitPolicy) Option {
+	return func(c *Config) {
+		c.RateLimit = &policy
+	}
+}
+
+// WithDictBudget tracks the DICT anti-scan tokens of each end user, so GetPixKeyInfo
+// fails fast with ErrDictScanLimit instead of a lookup that would get the user blocked.
+// Use WithDictPayer to track users by document and SeedDictBudget to apply the issuer's
+// PIX scan configuration.
+func WithDictBudget(policy DictBudgetPolicy) Option {
+	return func(c *Config) {
+		c.DictBudget = &policy
+	}
+}
+
+// WithoutRetries disables request retries
+func WithoutRetries() Option {
+	return WithRetryPolicy(NoRetryPolicy())
+}
+
+// WithoutValidation disables client-side request validation, sending request bodies as-is
+func WithoutValidation() Option {
+	return func(c *Config) {
+		c.SkipValidation = true
+	}
+}
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// DefaultPageSize is the page size used by iterators when neither the params nor WithPageSize set one
+const DefaultPageSize = 50
+
+// PageOption configures a paginated iterator
+type PageOption func(*pageConfig)
+
+// pageConfig holds iterator settings
+type pageConfig struct {
+	pageSize int
+}
+
+// WithPageSize sets the number of items requested per page
+func WithPageSize(size int) PageOption {
+	return func(c *pageConfig) {
+		c.pageSize = size
+	}
+}
+
+// newPageConfig applies options, falling back to the page size from the request params
+func newPageConfig(paramsSize *int, opts []PageOption) pageConfig {
+	cfg := pageConfig{pageSize: DefaultPageSize}
+	if paramsSize != nil && *paramsSize > 0 {
+		cfg.pageSize = *paramsSize
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pageSize <= 0 {
+		cfg.pageSize = DefaultPageSize
+	}
+	return cfg
+}
+
+// pageFetcher fetches the next page, reporting whether more pages may follow
+type pageFetcher[T any] func() (items []T, more bool, err error)
+
+// paginate turns a page fetcher factory into an iterator. The factory is called
+// on every range so that each iteration starts from the first page.
+func paginate[T any](newFetcher func() pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		fetch := newFetcher()
+		for {
+			items, more, err := fetch()
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !more || len(items) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// hasMoreOffset reports whether an offset-paginated endpoint has more items.
+// Pagination stops on a short page or once the reported total has been reached.
+func hasMoreOffset(received, pageSize, offset int, total *int) bool {
+	if received < pageSize {
+		return false
+	}
+	return total == nil || offset < *total
+}
+
+// AccountsIter iterates over all accounts matching params, fetching pages with first/max offsets.
+// Iteration starts at params.First (if set) and stops at the first error, which is yielded.
+func (c *Client) AccountsIter(ctx context.Context, params *types.ListAccountsParams, opts ...PageOption) iter.Seq2[types.AccountDataResponse, error] {
+	var base types.ListAccountsParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Max, opts)
+
+	return paginate(func() pageFetcher[types.AccountDataResponse] {
+		p := base
+		offset := 0
+		if base.First != nil {
+			offset = *base.First
+		}
+		return func() ([]types.AccountDataResponse, bool, error) {
+			first, size := offset, cfg.pageSize
+			p.First, p.Max = &first, &size
+			resp, err := c.ListAccounts(ctx, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			offset += len(resp.Accounts)
+			return resp.Accounts, hasMoreOffset(len(resp.Accounts), size, offset, resp.Total), nil
+		}
+	})
+}
+
+// StatementEntries iterates over all statement entries of an account, fetching pages with first/max offsets.
+// Iteration starts at params.First (if set) and stops at the first error, which is yielded.
+func (c *Client) StatementEntries(ctx context.Context, accountID int64, params *types.StatementParams, opts ...PageOption) iter.Seq2[types.StatementEntry, error] {
+	var base types.StatementParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Max, opts)
+
+	return paginate(func() pageFetcher[types.StatementEntry] {
+		p := base
+		offset := 0
+		if base.First != nil {
+			offset = *base.First
+		}
+		return func() ([]types.StatementEntry, bool, error) {
+			first, size := offset, cfg.pageSize
+			p.First, p.Max = &first, &size
+			resp, err := c.GetAccountStatement(ctx, accountID, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			offset += len(resp.Entries)
+			var total *int
+			if resp.Total > 0 {
+				t := int(resp.Total)
+				total = &t
+			}
+			return resp.Entries, hasMoreOffset(len(resp.Entries), size, offset, total), nil
+		}
+	})
+}
+
+// InfractionReportsIter iterates over all infraction reports matching params.
+// The MED API pages by modification time, so each page is requested with ModifiedAfter set
+// to the last update date of the previous page; reports already returned are skipped.
+func (c *Client) InfractionReportsIter(ctx context.Context, params *types.ListInfractionReportsParams, opts ...PageOption) iter.Seq2[types.InfractionReportResponse, error] {
+	var base types.ListInfractionReportsParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Limit, opts)
+
+	return paginate(func() pageFetcher[types.InfractionReportResponse] {
+		p := base
+		cursor := newModifiedCursor(base.ModifiedAfter)
+		return func() ([]types.InfractionReportResponse, bool, error) {
+			size := cfg.pageSize
+			p.Limit, p.ModifiedAfter = &size, cursor.after
+			resp, err := c.ListInfractionReports(ctx, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			items := make([]types.InfractionReportResponse, 0, len(resp.InfractionReports))
+			for _, report := range resp.InfractionReports {
+				if cursor.advance(report.InfractionReportID, report.LastModifiedDateTime) {
+					items = append(items, report)
+				}
+			}
+			if err := cursor.check(resp.HasMoreElements, len(items)); err != nil {
+				return nil, false, err
+			}
+			return items, resp.HasMoreElements, nil
+		}
+	})
+}
+
+// RefundSolicitationsIter iterates over all refund solicitations matching params.
+// Pages are requested with ModifiedAfter set to the last modification of the previous page;
+// refunds already returned are skipped.
+func (c *Client) RefundSolicitationsIter(ctx context.Context, params *types.ListRefundsParams, opts ...PageOption) iter.Seq2[types.RefundResponse, error] {
+	var base types.ListRefundsParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Limit, opts)
+
+	return paginate(func() pageFetcher[types.RefundResponse] {
+		p := base
+		cursor := newModifiedCursor(base.ModifiedAfter)
+		return func() ([]types.RefundResponse, bool, error) {
+			size := cfg.pageSize
+			p.Limit, p.ModifiedAfter = &size, cursor.after
+			resp, err := c.ListRefundSolicitations(ctx, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			items := make([]types.RefundResponse, 0, len(resp.Refunds))
+			for _, refund := range resp.Refunds {
+				if cursor.advance(refund.RefundID, refund.LastModifiedDateTime) {
+					items = append(items, refund)
+				}
+			}
+			if err := cursor.check(resp.HasMoreElements, len(items)); err != nil {
+				return nil, false, err
+			}
+			return items, resp.HasMoreElements, nil
+		}
+	})
+}
+
+// AutomaticPixIter iterates over all automatic PIX recurrences of an account, fetching pages with page/size.
+func (c *Client) AutomaticPixIter(ctx context.Context, accountID int64, params *types.ListAutomaticPixParams, opts ...PageOption) iter.Seq2[types.AutomaticPixResponse, error] {
+	var base types.ListAutomaticPixParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Size, opts)
+
+	return paginate(func() pageFetcher[types.AutomaticPixResponse] {
+		p := base
+		page := 0
+		if base.Page != nil {
+			page = *base.Page
+		}
+		return func() ([]types.AutomaticPixResponse, bool, error) {
+			current, size := page, cfg.pageSize
+			p.Page, p.Size = &current, &size
+			resp, err := c.ListAutomaticPixByAccount(ctx, accountID, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			page++
+			return resp.Recurrences, resp.HasNext, nil
+		}
+	})
+}
+
+// AutomaticPixChargesIter iterates over all automatic PIX charges of an account, fetching pages with page/size.
+func (c *Client) AutomaticPixChargesIter(ctx context.Context, accountID int64, params *types.ListAutomaticPixParams, opts ...PageOption) iter.Seq2[types.AutomaticPixPaymentScheduleDTO, error] {
+	var base types.ListAutomaticPixParams
+	if params != nil {
+		base = *params
+	}
+	cfg := newPageConfig(base.Size, opts)
+
+	return paginate(func() pageFetcher[types.AutomaticPixPaymentScheduleDTO] {
+		p := base
+		page := 0
+		if base.Page != nil {
+			page = *base.Page
+		}
+		return func() ([]types.AutomaticPixPaymentScheduleDTO, bool, error) {
+			current, size := page, cfg.pageSize
+			p.Page, p.Size = &current, &size
+			resp, err := c.ListAutomaticPixCharges(ctx, accountID, &p)
+			if err != nil {
+				return nil, false, err
+			}
+			page++
+			return resp.Recurrences, resp.HasNext, nil
+		}
+	})
+}
+
+// modifiedCursor tracks a modification-time cursor and the IDs already returned at that time
+type modifiedCursor struct {
+	after *string
+	seen  map[string]struct{}
+}
+
+func newModifiedCursor(after *string) *modifiedCursor {
+	return &modifiedCursor{after: after, seen: make(map[string]struct{})}
+}
+
+// advance records an item and reports whether it has not been returned before
+func (m *modifiedCursor) advance(id, modified string) bool {
+	if _, ok := m.seen[id]; ok {
+		return false
+	}
+	if m.after == nil || modified != *m.after {
+		m.after = &modified
+		m.seen = make(map[string]struct{})
+	}
+	m.seen[id] = struct{}{}
+	return true
+}
+
+// check fails when the server reports more elements but the cursor can no longer advance
+func (m *modifiedCursor) check(more bool, fresh int) error {
+	if more && fresh == 0 {
+		after := ""
+		if m.after != nil {
+			after = *m.after
+		}
+		return fmt.Errorf("pagination cursor did not advance past %q", after)
+	}
+	return nil
+}
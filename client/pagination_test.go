@@ -0,0 +1,247 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// newPaginationTestClient creates a client against server without retries
+func newPaginationTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	client, err := New(server.URL, "test-api-key", newTestTLSConfig(server), WithTimeout(5*time.Second), WithoutRetries())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// statementServer serves total statement entries using first/max offsets
+func statementServer(t *testing.T, total int, reportTotal bool, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("max"))
+
+		resp := types.StatementResponse{}
+		if reportTotal {
+			resp.Total = int32(total) //nolint:gosec // test data
+		}
+		for i := first; i < min(first+size, total); i++ {
+			resp.Entries = append(resp.Entries, types.StatementEntry{TransactionID: strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestStatementEntriesIter(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		reportTotal  bool
+		pageSize     int
+		wantRequests int32
+	}{
+		{"stops on short page", 7, false, 3, 3},
+		{"exact multiple needs empty page", 6, false, 3, 3},
+		{"stops on total", 6, true, 3, 2},
+		{"single page", 2, true, 50, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := statementServer(t, tt.total, tt.reportTotal, &requests)
+			defer server.Close()
+			client := newPaginationTestClient(t, server)
+
+			var got []string
+			for entry, err := range client.StatementEntries(context.Background(), 1, nil, WithPageSize(tt.pageSize)) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, entry.TransactionID)
+			}
+
+			if len(got) != tt.total {
+				t.Fatalf("got %d entries; want %d", len(got), tt.total)
+			}
+			for i, id := range got {
+				if id != strconv.Itoa(i) {
+					t.Errorf("entry %d = %s; want %d", i, id, i)
+				}
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("made %d requests; want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestStatementEntriesIterBreakAndRestart(t *testing.T) {
+	var requests atomic.Int32
+	server := statementServer(t, 10, true, &requests)
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	seq := client.StatementEntries(context.Background(), 1, &types.StatementParams{Max: intPtrInt(2)})
+	for range 2 {
+		count := 0
+		for entry, err := range seq {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.TransactionID != strconv.Itoa(count) {
+				t.Fatalf("entry %d = %s; want iteration to restart from the first page", count, entry.TransactionID)
+			}
+			count++
+			if count == 3 {
+				break
+			}
+		}
+	}
+	if requests.Load() != 4 {
+		t.Errorf("made %d requests; want 4 (two pages per iteration)", requests.Load())
+	}
+}
+
+func TestAccountsIterPropagatesError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("first") != "0" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"id":1},{"id":2}]}`))
+	}))
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	var count int
+	var iterErr error
+	for _, err := range client.AccountsIter(context.Background(), nil, WithPageSize(2)) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("got %d accounts before the error; want 2", count)
+	}
+	if !errors.Is(iterErr, ErrException) {
+		t.Errorf("expected server error, got %v", iterErr)
+	}
+}
+
+func TestRefundSolicitationsIterCursor(t *testing.T) {
+	// Refunds sorted by modification time; r2 and r3 share the timestamp used as the second cursor
+	refunds := []types.RefundResponse{
+		{RefundID: "r1", LastModifiedDateTime: "2025-01-01T00:00:01Z"},
+		{RefundID: "r2", LastModifiedDateTime: "2025-01-01T00:00:02Z"},
+		{RefundID: "r3", LastModifiedDateTime: "2025-01-01T00:00:02Z"},
+		{RefundID: "r4", LastModifiedDateTime: "2025-01-01T00:00:03Z"},
+	}
+	var cursors []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("modifiedAfter")
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		cursors = append(cursors, after)
+
+		// Inclusive filter, as the API may return items modified exactly at the cursor
+		var page []types.RefundResponse
+		for _, refund := range refunds {
+			if refund.LastModifiedDateTime >= after {
+				page = append(page, refund)
+			}
+		}
+		resp := types.ListRefundsResponse{Refunds: page}
+		if len(page) > limit {
+			resp.Refunds, resp.HasMoreElements = page[:limit], true
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	var got []string
+	for refund, err := range client.RefundSolicitationsIter(context.Background(), nil, WithPageSize(3)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, refund.RefundID)
+	}
+
+	if fmt.Sprint(got) != "[r1 r2 r3 r4]" {
+		t.Errorf("got refunds %v; want [r1 r2 r3 r4]", got)
+	}
+	if len(cursors) < 2 || cursors[0] != "" || cursors[1] != "2025-01-01T00:00:02Z" {
+		t.Errorf("unexpected cursors %q", cursors)
+	}
+}
+
+func TestInfractionReportsIterStalledCursor(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"infractionReport":[{"infractionReportId":"a","lastUpdateDate":"t"}],"hasMoreElements":true}`))
+	}))
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	var ids []string
+	var iterErr error
+	for report, err := range client.InfractionReportsIter(context.Background(), nil, WithPageSize(1)) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		ids = append(ids, report.InfractionReportID)
+	}
+	if len(ids) != 1 {
+		t.Errorf("got reports %v; want a single report", ids)
+	}
+	if iterErr == nil {
+		t.Error("expected an error when the cursor does not advance")
+	}
+}
+
+func TestAutomaticPixIterPages(t *testing.T) {
+	var pages []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page")+"/"+r.URL.Query().Get("size"))
+		resp := types.AutomaticPixListResponse{
+			Recurrences: []types.AutomaticPixResponse{{RecurrenceID: fmt.Sprintf("rec-%d", page)}},
+			Page:        page,
+			HasNext:     page < 2,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	var got []string
+	for rec, err := range client.AutomaticPixIter(context.Background(), 1, nil, WithPageSize(1)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, rec.RecurrenceID)
+	}
+
+	if fmt.Sprint(got) != "[rec-0 rec-1 rec-2]" {
+		t.Errorf("got recurrences %v; want [rec-0 rec-1 rec-2]", got)
+	}
+	if fmt.Sprint(pages) != "[0/1 1/1 2/1]" {
+		t.Errorf("requested pages %v; want [0/1 1/1 2/1]", pages)
+	}
+}
@@ -16,13 +16,13 @@ func TestCreatePixClaim(t *testing.T) {
 	accountID := int64(12345)
 	reqData := &types.CreatePixClaimRequest{
 		KeyType:   types.PixKeyTypeCPF,
-		KeyValue:  "12345678901",
+		KeyValue:  "52998224725",
 		ClaimType: "PORTABILITY",
 	}
 	respData := &types.PixClaimResponse{
 		ClaimID:   "claim123",
 		KeyType:   types.PixKeyTypeCPF,
-		KeyValue:  "12345678901",
+		KeyValue:  "52998224725",
 		ClaimType: "PORTABILITY",
 		Status:    "PENDING",
 	}
@@ -205,7 +205,7 @@ func TestGetRequestedClaims(t *testing.T) {
 			{
 				ClaimID:   "claim123",
 				KeyType:   types.PixKeyTypeCPF,
-				KeyValue:  "12345678901",
+				KeyValue:  "52998224725",
 				ClaimType: "PORTABILITY",
 				Status:    "PENDING",
 			},
@@ -304,7 +304,7 @@ func TestGetPixLimit(t *testing.T) {
 // TestUpdatePixLimit tests updating PIX limits
 func TestUpdatePixLimit(t *testing.T) {
 	accountID := int64(12345)
-	dailyLimit := int64(200000)
+	dailyLimit := types.MoneyCents(200000)
 	reqData := &types.PixLimitRequest{
 		DailyLimit: &dailyLimit,
 	}
@@ -620,7 +620,7 @@ func TestListPixClaims(t *testing.T) {
 			{
 				ClaimID:   "claim123",
 				KeyType:   types.PixKeyTypeCPF,
-				KeyValue:  "12345678901",
+				KeyValue:  "52998224725",
 				ClaimType: "PORTABILITY",
 				Status:    "PENDING",
 			},
@@ -661,13 +661,13 @@ func TestListPixClaims(t *testing.T) {
 func TestCreateClaimFromKey(t *testing.T) {
 	reqData := &types.CreateClaimFromKeyRequest{
 		KeyType:   types.PixKeyTypeCPF,
-		KeyValue:  "12345678901",
+		KeyValue:  "52998224725",
 		ClaimType: "OWNERSHIP",
 	}
 	respData := &types.PixClaimResponse{
 		ClaimID:   "claim456",
 		KeyType:   types.PixKeyTypeCPF,
-		KeyValue:  "12345678901",
+		KeyValue:  "52998224725",
 		ClaimType: "OWNERSHIP",
 		Status:    "PENDING",
 	}
@@ -869,7 +869,7 @@ func TestGetRaiseLimitRequestDetail(t *testing.T) {
 // TestReceivePixCallback tests receiving PIX callback
 func TestReceivePixCallback(t *testing.T) {
 	reqData := &types.PixCallbackRequest{
-		EndToEndID:      "E123456789202401010000000001",
+		EndToEndID:      "E12345678202401011200abcdef12345",
 		Amount:          10000,
 		TransactionDate: "2024-01-01T12:00:00Z",
 	}
@@ -9,12 +9,16 @@ import (
 
 // PIX Transaction Operations - Aligned with OpenAPI Specification
 
-// DoPixPayment executes a PIX payment transaction (POST /pix/transactions/payment)
+// DoPixPayment executes a PIX payment transaction (POST /pix/transactions/payment).
+// With WithDictBudget, a payment to a key looked up with GetPixKeyInfo refunds the lookup.
 func (c *Client) DoPixPayment(ctx context.Context, req *types.PixPaymentRequest) (*types.PixPaymentResponse, error) {
 	var response types.PixPaymentResponse
 	if err := c.post(ctx, "/pix/transactions/payment", req, &response); err != nil {
 		return nil, err
 	}
+	if c.dict != nil {
+		c.dict.refund(ctx, req)
+	}
 	return &response, nil
 }
 
@@ -83,11 +87,26 @@ func (c *Client) ListPSPs(ctx context.Context) (*types.PspListResponse, error) {
 	return &response, nil
 }
 
-// GetPixKeyInfo retrieves information about a PIX key (GET /pix/keys/{accountId}/{key})
+// GetPixKeyInfo retrieves information about a PIX key (GET /pix/keys/{accountId}/{key}).
+// With WithDictBudget, it returns a DictScanLimitError without calling the API when the
+// lookup would exceed the DICT anti-scan limits.
 func (c *Client) GetPixKeyInfo(ctx context.Context, accountID int64, key string) (*types.SearchKeyResponse, error) {
+	var lookup *dictLookup
+	if c.dict != nil {
+		var err error
+		if lookup, err = c.dict.take(ctx, accountID, key); err != nil {
+			c.logger(ctx).Warn("DICT lookup refused by the scan budget", "error", err)
+			return nil, err
+		}
+	}
+
 	path := fmt.Sprintf("/pix/keys/%d/%s", accountID, key)
 	var response types.SearchKeyResponse
-	if err := c.get(ctx, path, &response); err != nil {
+	err := c.get(ctx, path, &response)
+	if c.dict != nil {
+		c.dict.settle(lookup, err)
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &response, nil
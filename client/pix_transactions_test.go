@@ -19,9 +19,9 @@ func TestDoPixPayment(t *testing.T) {
 		RecipientBranchCode:      "0001",
 		RecipientAccountNumber:   "123456",
 		RecipientAccountType:     types.PixAccountTypeCACC,
-		RecipientCpfCnpj:         "12345678901",
+		RecipientCpfCnpj:         "52998224725",
 		RecipientName:            "John Doe",
-		OperationAmount:          100.50,
+		OperationAmount:          types.MustParseMoney("100.50"),
 	}
 	respData := &types.PixPaymentResponse{
 		Message:            "Payment successful",
@@ -45,7 +45,7 @@ func TestDoPixPayment(t *testing.T) {
 			t.Errorf("request AccountID = %d; want %d", body.AccountID, reqData.AccountID)
 		}
 		if body.OperationAmount != reqData.OperationAmount {
-			t.Errorf("request OperationAmount = %f; want %f", body.OperationAmount, reqData.OperationAmount)
+			t.Errorf("request OperationAmount = %s; want %s", body.OperationAmount, reqData.OperationAmount)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -81,7 +81,7 @@ func TestDoPixChargeback(t *testing.T) {
 	reqData := &types.PixChargebackRequest{
 		AccountID:     12345,
 		IDTransaction: 9876,
-		Amount:        100.50,
+		Amount:        types.MustParseMoney("100.50"),
 		ReasonCode:    "MD06",
 	}
 	respData := &types.PixChargebackResponse{
@@ -186,7 +186,7 @@ func TestCreatePrecautionaryBlock(t *testing.T) {
 		IDAccount:     12345,
 		IDTransaction: 9876,
 		Message:       "Block created",
-		Value:         100.50,
+		Value:         types.MustParseMoney("100.50"),
 	}
 
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -234,7 +234,7 @@ func TestCreatePrecautionaryBlock(t *testing.T) {
 func TestUpdatePrecautionaryBlock(t *testing.T) {
 	reqData := &types.PixUpdatePrecautionaryBlockRequest{
 		IDOnlineTransactionLog: 111,
-		PixPrecautionaryEnum:   "UNBLOCK",
+		PixPrecautionaryEnum:   types.PrecautionaryBlockReleased,
 	}
 	respData := &types.PixUpdatePrecautionaryBlockResponse{
 		Message: "Block updated",
@@ -285,9 +285,9 @@ func TestGetPixTransactionLimit(t *testing.T) {
 		Message: "Limits retrieved",
 		PixLimitInternal: &types.PixTransactionLimit{
 			TotalAvailableLimit:                   100000,
-			TotalAvailableLimitFormatted:          1000.00,
+			TotalAvailableLimitFormatted:          1000 * types.Real,
 			LimitPerAvailableTransaction:          10000,
-			LimitPerAvailableTransactionFormatted: 100.00,
+			LimitPerAvailableTransactionFormatted: 100 * types.Real,
 		},
 	}
 
@@ -425,7 +425,7 @@ func TestListPSPs(t *testing.T) {
 // TestGetPixKeyInfo tests retrieving PIX key information
 func TestGetPixKeyInfo(t *testing.T) {
 	accountID := int64(12345)
-	key := "12345678901"
+	key := "52998224725"
 	respData := &types.SearchKeyResponse{
 		Success:           true,
 		ResultDescription: "Key found",
@@ -440,7 +440,7 @@ func TestGetPixKeyInfo(t *testing.T) {
 		if r.Method != http.MethodGet {
 			t.Errorf("expected GET request, got %s", r.Method)
 		}
-		expectedPath := "/pix/keys/12345/12345678901"
+		expectedPath := "/pix/keys/12345/52998224725"
 		if r.URL.Path != expectedPath {
 			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
 		}
@@ -80,8 +80,8 @@ func (c *Client) GetProposalTypeStatus(ctx context.Context) ([]types.ProposalTyp
 }
 
 // GetLastProposal retrieves the last proposal for a document
-func (c *Client) GetLastProposal(ctx context.Context, document string) (*types.ProposalDetailResponse, error) {
-	path := fmt.Sprintf("/proposal/last/%s", document)
+func (c *Client) GetLastProposal(ctx context.Context, document types.Document) (*types.ProposalDetailResponse, error) {
+	path := fmt.Sprintf("/proposal/last/%s", document.Canonical())
 	var response types.ProposalDetailResponse
 	if err := c.get(ctx, path, &response); err != nil {
 		return nil, err
@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitRecovery is the default time without 429 responses after which a
+// throttled rate limit doubles back towards its configured rate
+const DefaultRateLimitRecovery = 30 * time.Second
+
+// minRateFraction is the lowest fraction of its configured rate a limit is throttled to
+const minRateFraction = 1.0 / 16
+
+// RateLimit is a token bucket allowing Rate requests per second with bursts of up to
+// Burst requests. A zero Rate disables the limit.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second
+	Rate float64
+
+	// Burst is the number of requests that may be sent at once (defaults to 1)
+	Burst int
+}
+
+// RateLimitPolicy configures client-side rate limits. A request takes a token from the
+// Global bucket and from the bucket of its endpoint group, waiting until both have one.
+// Each tenant set with WithTenant has its own buckets.
+//
+// When the API answers 429 with a Retry-After header, the bucket of the request's group
+// (or the Global bucket if the group has none) sends nothing until Retry-After has
+// passed and halves its rate. The rate doubles back after each Recovery period without
+// another 429, up to the configured rate.
+type RateLimitPolicy struct {
+	// Global limits all requests of a tenant, or of the client when no tenant is set
+	Global RateLimit
+
+	// Groups limits the requests of each endpoint group, e.g. EndpointGroupDICT
+	Groups map[EndpointGroup]RateLimit
+
+	// Recovery is how long a throttled rate stays halved (defaults to DefaultRateLimitRecovery)
+	Recovery time.Duration
+}
+
+// rateLimiter holds the token buckets of a RateLimitPolicy for each tenant
+type rateLimiter struct {
+	policy RateLimitPolicy
+	now    func() time.Time
+
+	mu      sync.Mutex
+	tenants map[string]*rateBuckets
+}
+
+// rateBuckets are the token buckets of one tenant ("" for calls without one)
+type rateBuckets struct {
+	global *tokenBucket
+	groups map[EndpointGroup]*tokenBucket
+}
+
+// newRateLimiter returns a limiter for the policy
+func newRateLimiter(policy RateLimitPolicy) *rateLimiter {
+	if policy.Recovery <= 0 {
+		policy.Recovery = DefaultRateLimitRecovery
+	}
+	return &rateLimiter{
+		policy:  policy,
+		now:     time.Now,
+		tenants: make(map[string]*rateBuckets),
+	}
+}
+
+// buckets returns the buckets of a tenant, creating full ones and skipping disabled limits
+func (l *rateLimiter) buckets(tenant string) *rateBuckets {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rb, ok := l.tenants[tenant]
+	if !ok {
+		rb = &rateBuckets{
+			global: newTokenBucket(l.policy.Global, l.policy.Recovery),
+			groups: make(map[EndpointGroup]*tokenBucket, len(l.policy.Groups)),
+		}
+		for group, limit := range l.policy.Groups {
+			if b := newTokenBucket(limit, l.policy.Recovery); b != nil {
+				rb.groups[group] = b
+			}
+		}
+		l.tenants[tenant] = rb
+	}
+	return rb
+}
+
+// wait blocks until a request of the tenant's group may be sent and returns the time spent
+// queued. It fails without waiting when the context deadline would pass first.
+func (l *rateLimiter) wait(ctx context.Context, tenant string, group EndpointGroup) (time.Duration, error) {
+	rb := l.buckets(tenant)
+	buckets := make([]*tokenBucket, 0, 2)
+	if rb.global != nil {
+		buckets = append(buckets, rb.global)
+	}
+	if b := rb.groups[group]; b != nil {
+		buckets = append(buckets, b)
+	}
+
+	now := l.now()
+	var delay time.Duration
+	for _, b := range buckets {
+		delay = max(delay, b.reserve(now))
+	}
+	if delay == 0 {
+		return 0, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		for _, b := range buckets {
+			b.cancel()
+		}
+		return 0, fmt.Errorf("rate limit wait of %s for %s endpoints exceeds the context deadline: %w",
+			delay.Round(time.Millisecond), group, context.DeadlineExceeded)
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		for _, b := range buckets {
+			b.cancel()
+		}
+		return l.now().Sub(now), err
+	}
+	return delay, nil
+}
+
+// throttle pauses and slows down the bucket of a tenant's group after a 429 response
+func (l *rateLimiter) throttle(tenant string, group EndpointGroup, retryAfter time.Duration) {
+	rb := l.buckets(tenant)
+	b := rb.groups[group]
+	if b == nil {
+		b = rb.global
+	}
+	if b != nil {
+		b.throttle(l.now(), retryAfter)
+	}
+}
+
+// tokenBucket is a token bucket where tokens may be reserved ahead of time. A negative
+// token count is the number of callers already waiting.
+type tokenBucket struct {
+	limit    RateLimit
+	recovery time.Duration
+
+	mu          sync.Mutex
+	rate        float64
+	tokens      float64
+	last        time.Time // tokens accrue from last on; it is in the future while paused
+	throttledAt time.Time
+}
+
+// newTokenBucket returns a full bucket for the limit, or nil if the limit is disabled
+func newTokenBucket(limit RateLimit, recovery time.Duration) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{
+		limit:    limit,
+		recovery: recovery,
+		rate:     limit.Rate,
+		tokens:   float64(limit.Burst),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	if b.rate < b.limit.Rate && now.Sub(b.throttledAt) >= b.recovery {
+		b.rate = min(b.rate*2, b.limit.Rate)
+		b.throttledAt = now
+	}
+
+	b.tokens--
+	at := b.last
+	if b.tokens < 0 {
+		at = at.Add(time.Duration(-b.tokens / b.rate * float64(time.Second)))
+	}
+	return max(at.Sub(now), 0)
+}
+
+// cancel gives back a reserved token that was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+1, float64(b.limit.Burst))
+}
+
+// throttle halves the rate and stops tokens from accruing until retryAfter has passed
+func (b *tokenBucket) throttle(now time.Time, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.rate = max(b.rate/2, b.limit.Rate*minRateFraction)
+	b.throttledAt = now
+	// Saved-up bursts are dropped so only one request goes out as soon as the pause ends
+	b.tokens = min(b.tokens, 1)
+	if until := now.Add(retryAfter); until.After(b.last) {
+		b.last = until
+	}
+}
+
+// advance adds the tokens accrued since the last update; b.mu must be held
+func (b *tokenBucket) advance(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+		return
+	}
+	if now.After(b.last) {
+		b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, float64(b.limit.Burst))
+		b.last = now
+	}
+}
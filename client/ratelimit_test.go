@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(RateLimit{Rate: 10, Burst: 2}, time.Minute)
+	now := time.Now()
+
+	// The burst is available at once, then tokens come every 100ms
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := b.reserve(now); got != want {
+			t.Errorf("reserve %d = %s; want %s", i, got, want)
+		}
+	}
+	b.cancel()
+	if got := b.reserve(now); got != 200*time.Millisecond {
+		t.Errorf("reserve after cancel = %s; want 200ms", got)
+	}
+
+	// A 429 pauses the bucket for Retry-After and halves its rate
+	now = now.Add(time.Second)
+	b.throttle(now, 2*time.Second)
+	if got := b.reserve(now); got != 2*time.Second {
+		t.Errorf("reserve while paused = %s; want 2s", got)
+	}
+	if got := b.reserve(now); got != 2*time.Second+200*time.Millisecond {
+		t.Errorf("reserve at half rate = %s; want 2.2s", got)
+	}
+
+	// The rate doubles back once the recovery period passes without another 429
+	now = now.Add(time.Minute)
+	b.reserve(now)
+	b.reserve(now)
+	if got := b.reserve(now); got != 100*time.Millisecond {
+		t.Errorf("reserve after recovery = %s; want 100ms", got)
+	}
+	if b.rate != 10 {
+		t.Errorf("rate = %v; want 10", b.rate)
+	}
+}
+
+func TestTokenBucketDisabled(t *testing.T) {
+	if b := newTokenBucket(RateLimit{}, time.Minute); b != nil {
+		t.Error("newTokenBucket with zero rate = bucket; want nil")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithRateLimit(RateLimitPolicy{
+		Groups: map[EndpointGroup]RateLimit{EndpointGroupDICT: {Rate: 20}},
+	}))
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		if err := c.get(ctx, "/pix/keys/1/user@email.com", nil); err != nil {
+			t.Fatalf("get = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 DICT lookups took %s; want at least 100ms at 20/s", elapsed)
+	}
+
+	// Other groups are not limited
+	start = time.Now()
+	for range 5 {
+		if err := c.get(ctx, "/accounts/1", nil); err != nil {
+			t.Fatalf("get = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("unlimited requests took %s", elapsed)
+	}
+}
+
+func TestRateLimitRespectsDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithRateLimit(RateLimitPolicy{
+		Global: RateLimit{Rate: 0.1},
+	}))
+	if err := c.get(context.Background(), "/accounts/1", nil); err != nil {
+		t.Fatalf("get = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	err := c.get(ctx, "/accounts/1", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("get = %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("get failed after %s; want it to fail without waiting", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server got %d calls; want 1", calls.Load())
+	}
+}
+
+func TestRateLimitAdaptsToRetryAfter(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithRateLimit(RateLimitPolicy{
+		Global: RateLimit{Rate: 100, Burst: 10},
+		Groups: map[EndpointGroup]RateLimit{EndpointGroupPix: {Rate: 10, Burst: 5}},
+	}))
+	if err := c.get(context.Background(), "/pix/psps", nil); !errors.Is(err, ErrAPI) {
+		t.Fatalf("get = %v; want ErrAPI", err)
+	}
+
+	buckets := c.limiter.buckets("")
+	pix := buckets.groups[EndpointGroupPix]
+	if pix.rate != 5 {
+		t.Errorf("pix rate = %v; want 5", pix.rate)
+	}
+	if wait := pix.reserve(time.Now()); wait < 2*time.Second {
+		t.Errorf("pix wait = %s; want about 3s", wait)
+	}
+	if buckets.global.rate != 100 {
+		t.Errorf("global rate = %v; want 100", buckets.global.rate)
+	}
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of attempts (including the first) for retryable requests
+	DefaultMaxAttempts = 3
+
+	// DefaultRetryBaseDelay is the default delay before the first retry
+	DefaultRetryBaseDelay = 100 * time.Millisecond
+
+	// DefaultRetryMaxDelay is the default upper bound for the backoff delay
+	DefaultRetryMaxDelay = 2 * time.Second
+
+	// DefaultRetryJitter is the default fraction of each delay that is randomized
+	DefaultRetryJitter = 0.2
+
+	// DefaultMaxRetryAfter is the default longest Retry-After the client is willing to wait
+	DefaultMaxRetryAfter = 30 * time.Second
+)
+
+// RetryPolicy configures how failed requests are retried.
+// Start from DefaultRetryPolicy and adjust fields as needed.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one (1 disables retries).
+	// It applies to idempotent methods (GET, PUT) and to mutating methods carrying an
+	// idempotency key when RetryWithIdempotencyKey is set.
+	MaxAttempts int
+
+	// MethodMaxAttempts overrides MaxAttempts per HTTP method (e.g. {"DELETE": 2}).
+	// Overrides apply regardless of idempotency, so use them with care for POST.
+	MethodMaxAttempts map[string]int
+
+	// BaseDelay is the delay before the first retry; it doubles on each further retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the exponential backoff delay; without it the delay keeps doubling
+	// up to the largest time.Duration
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0 to 1) of each delay that is randomized to spread retries
+	Jitter float64
+
+	// RetryableStatuses lists the HTTP status codes that are retried.
+	// When nil, errors are retried if their Retryable method reports true: 429, 500,
+	// 502, 503 and 504 responses and retryable business codes.
+	RetryableStatuses []int
+
+	// RetryIf, if set, replaces the default classification (transport errors and
+	// RetryableStatuses) and decides from the typed error whether to retry, e.g.
+	//
+	//	func(err error) bool { return errors.Is(err, client.ErrIntegration) }
+	RetryIf func(err error) bool
+
+	// RetryWithIdempotencyKey enables retries of POST, PATCH and DELETE requests
+	// that carry an idempotency key (see WithIdempotencyKey and WithAutoIdempotency)
+	RetryWithIdempotencyKey bool
+
+	// MaxRetryAfter is the longest Retry-After delay honored on 429/503 responses.
+	// Responses asking for a longer wait are returned to the caller instead of retried.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   DefaultMaxAttempts,
+		BaseDelay:     DefaultRetryBaseDelay,
+		MaxDelay:      DefaultRetryMaxDelay,
+		Jitter:        DefaultRetryJitter,
+		MaxRetryAfter: DefaultMaxRetryAfter,
+	}
+}
+
+// NoRetryPolicy returns a retry policy that performs a single attempt
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// maxAttemptsFor returns the number of attempts allowed for a request
+func (p *RetryPolicy) maxAttemptsFor(method string, hasIdempotencyKey bool) int {
+	attempts := 1
+	if n, ok := p.MethodMaxAttempts[method]; ok {
+		attempts = n
+	} else if method == http.MethodGet || method == http.MethodPut ||
+		(hasIdempotencyKey && p.RetryWithIdempotencyKey) {
+		attempts = p.MaxAttempts
+	}
+	return max(attempts, 1)
+}
+
+// shouldRetry reports whether a failed attempt is eligible for a retry
+func (p *RetryPolicy) shouldRetry(resp *ResponseInfo) bool {
+	if errors.Is(resp.Err, context.Canceled) || errors.Is(resp.Err, context.DeadlineExceeded) {
+		return false
+	}
+	if p.RetryIf != nil {
+		return p.RetryIf(resp.Err)
+	}
+	if resp.StatusCode == 0 {
+		return true
+	}
+	if p.RetryableStatuses == nil {
+		return IsRetryable(resp.Err)
+	}
+	return slices.Contains(p.RetryableStatuses, resp.StatusCode)
+}
+
+// delay returns how long to wait before the retry following the given attempt.
+// The second result is false when the server asked for a longer wait than MaxRetryAfter.
+func (p *RetryPolicy) delay(attempt int, resp *ResponseInfo) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if retryAfter, ok := parseRetryAfter(resp.Header, time.Now()); ok {
+			if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+	}
+
+	// Without MaxDelay, doubling stops before it would overflow
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay) && d <= math.MaxInt64/2; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 && d > 0 {
+		//nolint:gosec // Jitter does not need a cryptographic source
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d, true
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
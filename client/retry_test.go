@@ -0,0 +1,265 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient creates a client against server with the given retry policy
+func newRetryTestClient(t *testing.T, server *httptest.Server, policy RetryPolicy, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithTimeout(5 * time.Second), WithRetryPolicy(policy)}, opts...)
+	client, err := New(server.URL, "test-api-key", newTestTLSConfig(server), opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestRetryPolicyMaxAttemptsFor(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.MethodMaxAttempts = map[string]int{http.MethodDelete: 2}
+
+	tests := []struct {
+		name   string
+		method string
+		hasKey bool
+		retry  bool
+		want   int
+	}{
+		{"GET uses max attempts", http.MethodGet, false, false, 3},
+		{"PUT uses max attempts", http.MethodPut, false, false, 3},
+		{"POST without key", http.MethodPost, false, true, 1},
+		{"POST with key, retries disabled", http.MethodPost, true, false, 1},
+		{"POST with key, retries enabled", http.MethodPost, true, true, 3},
+		{"DELETE override", http.MethodDelete, false, false, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			p.RetryWithIdempotencyKey = tt.retry
+			if got := p.maxAttemptsFor(tt.method, tt.hasKey); got != tt.want {
+				t.Errorf("maxAttemptsFor(%s, %v) = %d; want %d", tt.method, tt.hasKey, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 350 * time.Millisecond}
+	resp := &ResponseInfo{StatusCode: http.StatusBadGateway}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 350 * time.Millisecond, 350 * time.Millisecond}
+	for i, want := range expected {
+		got, ok := policy.delay(i+1, resp)
+		if !ok || got != want {
+			t.Errorf("delay(attempt %d) = %v, %v; want %v, true", i+1, got, ok, want)
+		}
+	}
+
+	// Without MaxDelay the delay stays positive for any attempt count
+	unbounded := RetryPolicy{BaseDelay: 100 * time.Millisecond}
+	for _, attempt := range []int{40, 64, 1000} {
+		if got, _ := unbounded.delay(attempt, resp); got <= 0 {
+			t.Errorf("delay(attempt %d) without MaxDelay = %v; want positive", attempt, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 50; i++ {
+		got, _ := policy.delay(1, resp)
+		if got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("jittered delay %v out of range [50ms, 100ms]", got)
+		}
+	}
+}
+
+func TestRetryPolicyDelayRetryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.MaxRetryAfter = 10 * time.Second
+
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	got, ok := policy.delay(1, &ResponseInfo{StatusCode: http.StatusTooManyRequests, Header: header})
+	if !ok || got != 3*time.Second {
+		t.Errorf("delay with Retry-After = %v, %v; want 3s, true", got, ok)
+	}
+
+	header.Set("Retry-After", "60")
+	if _, ok := policy.delay(1, &ResponseInfo{StatusCode: http.StatusServiceUnavailable, Header: header}); ok {
+		t.Error("expected Retry-After above MaxRetryAfter to stop retries")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"negative", "-1", 0, false},
+		{"http date", now.Add(2 * time.Second).Format(http.TimeFormat), 2 * time.Second, true},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			got, ok := parseRetryAfter(header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfterHeader(t *testing.T) {
+	var attempts atomic.Int32
+	var first time.Time
+	var gap time.Duration
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"slow down"}`))
+			return
+		}
+		gap = time.Since(first)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server, DefaultRetryPolicy())
+	if err := client.get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("GET request should succeed after retry: %v", err)
+	}
+	if gap < 900*time.Millisecond {
+		t.Errorf("retry happened after %v; want at least 1s per Retry-After", gap)
+	}
+}
+
+func TestRetrySleepHonorsContext(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 5 * time.Second
+	policy.MaxDelay = 5 * time.Second
+	client := newRetryTestClient(t, server, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.get(ctx, "/test", nil)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("retry sleep ignored context cancellation, took %v", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !errors.Is(err, ErrIntegration) {
+		t.Errorf("expected last attempt error to be preserved, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPOSTWithIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.RetryWithIdempotencyKey = true
+	client := newRetryTestClient(t, server, policy, WithAutoIdempotency())
+
+	if err := client.post(context.Background(), "/pix", map[string]int{"amount": 1}, nil); err != nil {
+		t.Fatalf("POST request should succeed after retry: %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected 2 attempts sharing one idempotency key, got %q", keys)
+	}
+}
+
+func TestRetryIfPredicate(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"boom"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.RetryIf = func(err error) bool {
+		var integrationErr *IntegrationError
+		return errors.As(err, &integrationErr)
+	}
+	client := newRetryTestClient(t, server, policy)
+
+	if err := client.get(context.Background(), "/test", nil); !errors.Is(err, ErrException) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt since RetryIf rejects ExceptionError, got %d", attempts.Load())
+	}
+}
+
+func TestWithoutRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, "test-api-key", newTestTLSConfig(server), WithoutRetries())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.get(context.Background(), "/test", nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
@@ -0,0 +1,12 @@
+package client
+
+import (
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/internal/route"
+)
+
+// routeTemplate reduces a concrete request path to a low-cardinality route template
+// suitable for metric and span labels, e.g. "/accounts/123/balance?x=1" becomes
+// "/accounts/{id}/balance". The same templates are used by cassette matching.
+func routeTemplate(path string) string {
+	return route.Template(path)
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+)
+
+// CredentialsResolver returns the credentials of a call, typically looked up from the
+// tenant set with WithTenant. Empty values fall back to the API key, base URL and TLS
+// certificate the client was created with.
+//
+// Each tenant certificate gets its own connection pool, reused while the certificate
+// content is unchanged. Up to maxTenantClients pools are kept; beyond that the least
+// recently used one is closed.
+type CredentialsResolver func(ctx context.Context) (apiKey string, cert *tls.Certificate, baseURL string, err error)
+
+// WithTenant sets the tenant (issuer or program) a call is made for. The tenant is passed
+// to the CredentialsResolver and labels hooks, logs, spans and metrics.
+// The key is shared with observability.WithTenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return observability.WithTenant(ctx, tenant)
+}
+
+// TenantFromContext returns the tenant set with WithTenant, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	return observability.GetTenant(ctx)
+}
+
+// credentials are the resolved settings of a call
+type credentials struct {
+	tenant  string
+	apiKey  string
+	baseURL string
+	http    *http.Client
+}
+
+// maxTenantClients is the number of tenant connection pools kept by a client
+const maxTenantClients = 256
+
+// tenantClient is the HTTP client, with its own connection pool, of a tenant certificate
+type tenantClient struct {
+	fingerprint [sha256.Size]byte
+	http        *http.Client
+	lastUsed    uint64
+}
+
+// credentials resolves the credentials of a call, falling back to the client configuration
+func (c *Client) credentials(ctx context.Context) (*credentials, error) {
+	tenant, _ := TenantFromContext(ctx)
+	creds := &credentials{
+		tenant:  tenant,
+		apiKey:  c.config.APIKey,
+		baseURL: c.config.BaseURL,
+		http:    c.http,
+	}
+	if c.config.CredentialsResolver == nil {
+		return creds, nil
+	}
+
+	apiKey, cert, baseURL, err := c.config.CredentialsResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials for tenant %q: %w", tenant, err)
+	}
+	if apiKey != "" {
+		creds.apiKey = apiKey
+	}
+	if baseURL != "" {
+		creds.baseURL = baseURL
+	}
+	if creds.apiKey == "" || creds.baseURL == "" {
+		return nil, fmt.Errorf("no API key or base URL for tenant %q", tenant)
+	}
+	if cert != nil {
+		creds.http = c.tenantHTTPClient(tenant, cert)
+	}
+	return creds, nil
+}
+
+// tenantHTTPClient returns the HTTP client of a tenant, creating one when the tenant is
+// new or the content of its certificate changed. A custom transport is shared by all tenants.
+func (c *Client) tenantHTTPClient(tenant string, cert *tls.Certificate) *http.Client {
+	if c.config.Transport != nil {
+		return c.http
+	}
+	fingerprint := certFingerprint(cert)
+
+	c.tenantsMu.Lock()
+	defer c.tenantsMu.Unlock()
+	c.tenantsClock++
+	if tc, ok := c.tenants[tenant]; ok {
+		if tc.fingerprint == fingerprint {
+			tc.lastUsed = c.tenantsClock
+			return tc.http
+		}
+		tc.http.CloseIdleConnections()
+		delete(c.tenants, tenant)
+	}
+	if len(c.tenants) >= maxTenantClients {
+		c.evictTenantClient()
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.config.TLSConfig != nil {
+		tlsConfig = c.config.TLSConfig.Clone()
+		tlsConfig.GetClientCertificate = nil
+	}
+	tlsConfig.Certificates = []tls.Certificate{*cert}
+
+	httpClient := &http.Client{
+		Timeout:   c.config.Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	c.tenants[tenant] = &tenantClient{fingerprint: fingerprint, http: httpClient, lastUsed: c.tenantsClock}
+	return httpClient
+}
+
+// evictTenantClient closes the least recently used tenant pool; c.tenantsMu must be held
+func (c *Client) evictTenantClient() {
+	var oldest *tenantClient
+	var oldestTenant string
+	for tenant, tc := range c.tenants {
+		if oldest == nil || tc.lastUsed < oldest.lastUsed {
+			oldest, oldestTenant = tc, tenant
+		}
+	}
+	if oldest != nil {
+		oldest.http.CloseIdleConnections()
+		delete(c.tenants, oldestTenant)
+	}
+}
+
+// certFingerprint returns the SHA-256 of the leaf certificate, so resolvers that parse
+// the certificate on every call still reuse the tenant's pool
+func certFingerprint(cert *tls.Certificate) [sha256.Size]byte {
+	if len(cert.Certificate) == 0 {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(cert.Certificate[0])
+}
+
+// logger returns the client logger, labeled with the tenant of ctx if any
+func (c *Client) logger(ctx context.Context) *slog.Logger {
+	if tenant, ok := TenantFromContext(ctx); ok {
+		return c.config.Logger.With("tenant", tenant)
+	}
+	return c.config.Logger
+}
@@ -0,0 +1,300 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// tenantRecorder is a hook that records the tenant of each attempt
+type tenantRecorder struct {
+	NoOpHook
+	mu      sync.Mutex
+	tenants []string
+}
+
+func (h *tenantRecorder) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tenants = append(h.tenants, req.Tenant)
+	return ctx
+}
+
+// newTenantServer returns a server that answers with the API key it received
+func newTenantServer(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"server":%q,"apiKey":%q}`, name, r.Header.Get(APIKeyHeader))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestCertificate returns a self-signed client certificate
+func newTestCertificate(t *testing.T) *tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tenant"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestCredentialsResolver(t *testing.T) {
+	serverA := newTenantServer(t, "a")
+	serverB := newTenantServer(t, "b")
+	certA := serverA.TLS.Certificates[0]
+	certB := serverB.TLS.Certificates[0]
+	tenants := map[string]struct {
+		apiKey  string
+		cert    *tls.Certificate
+		baseURL string
+	}{
+		"issuerA": {"key-a", &certA, serverA.URL},
+		"issuerB": {"key-b", &certB, serverB.URL},
+	}
+	resolver := func(ctx context.Context) (string, *tls.Certificate, string, error) {
+		tenant, _ := TenantFromContext(ctx)
+		creds, ok := tenants[tenant]
+		if !ok {
+			return "", nil, "", fmt.Errorf("unknown tenant %q", tenant)
+		}
+		return creds.apiKey, creds.cert, creds.baseURL, nil
+	}
+
+	var logs bytes.Buffer
+	reader := sdkmetric.NewManualReader()
+	hook := &tenantRecorder{}
+	c, err := New("", "", newTestTLSConfig(serverA),
+		WithCredentialsResolver(resolver),
+		WithHooks(hook),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("New = %v", err)
+	}
+	defer c.Close()
+
+	var response struct{ Server, APIKey string }
+	for _, tenant := range []string{"issuerA", "issuerB", "issuerA"} {
+		if err := c.get(WithTenant(context.Background(), tenant), "/accounts/1", &response); err != nil {
+			t.Fatalf("get for %s = %v", tenant, err)
+		}
+		wantServer := strings.ToLower(strings.TrimPrefix(tenant, "issuer"))
+		if response.Server != wantServer || response.APIKey != tenants[tenant].apiKey {
+			t.Errorf("%s reached server %s with key %s", tenant, response.Server, response.APIKey)
+		}
+	}
+
+	// Each tenant keeps its own connection pool
+	if len(c.tenants) != 2 || c.tenants["issuerA"].http == c.tenants["issuerB"].http {
+		t.Errorf("tenant clients = %v; want one per tenant", c.tenants)
+	}
+	// A resolver returning a new copy of the same certificate keeps the pool
+	poolA := c.tenants["issuerA"].http
+	reparsed := certA
+	tenants["issuerA"] = struct {
+		apiKey  string
+		cert    *tls.Certificate
+		baseURL string
+	}{"key-a", &reparsed, serverA.URL}
+	if err := c.get(WithTenant(context.Background(), "issuerA"), "/accounts/1", nil); err != nil {
+		t.Fatalf("get with reparsed certificate = %v", err)
+	}
+	if c.tenants["issuerA"].http != poolA {
+		t.Error("reparsed certificate created a new connection pool")
+	}
+
+	tenants["issuerA"] = struct {
+		apiKey  string
+		cert    *tls.Certificate
+		baseURL string
+	}{"key-a", newTestCertificate(t), serverA.URL}
+	if err := c.get(WithTenant(context.Background(), "issuerA"), "/accounts/1", nil); err != nil {
+		t.Fatalf("get after rotation = %v", err)
+	}
+	if c.tenants["issuerA"].http == poolA {
+		t.Error("rotated certificate reused the old connection pool")
+	}
+
+	err = c.get(WithTenant(context.Background(), "issuerC"), "/accounts/1", nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown tenant") {
+		t.Errorf("get for unknown tenant = %v; want the resolver error", err)
+	}
+
+	hook.mu.Lock()
+	if want := []string{"issuerA", "issuerB", "issuerA", "issuerA", "issuerA"}; fmt.Sprint(hook.tenants) != fmt.Sprint(want) {
+		t.Errorf("hook tenants = %v; want %v", hook.tenants, want)
+	}
+	hook.mu.Unlock()
+
+	if !strings.Contains(logs.String(), "tenant=issuerB") {
+		t.Errorf("logs = %q; want the tenant label", logs.String())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	requests := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			data, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || m.Name != "evertec.sdk.requests.total" {
+				continue
+			}
+			for _, dp := range data.DataPoints {
+				tenant, _ := dp.Attributes.Value("evertec.tenant")
+				requests[tenant.AsString()] += dp.Value
+			}
+		}
+	}
+	if requests["issuerA"] != 4 || requests["issuerB"] != 1 {
+		t.Errorf("requests per tenant = %v; want issuerA 4, issuerB 1", requests)
+	}
+}
+
+func TestTenantClientEviction(t *testing.T) {
+	server := newTenantServer(t, "default")
+	c := newRetryTestClient(t, server, NoRetryPolicy())
+	cert := server.TLS.Certificates[0]
+
+	first := c.tenantHTTPClient("tenant-0", &cert)
+	second := c.tenantHTTPClient("tenant-1", &cert)
+	for i := 2; i < maxTenantClients; i++ {
+		c.tenantHTTPClient(fmt.Sprintf("tenant-%d", i), &cert)
+	}
+	// Using tenant-0 again makes tenant-1 the least recently used pool
+	if c.tenantHTTPClient("tenant-0", &cert) != first {
+		t.Fatal("tenant-0 got a new pool")
+	}
+	c.tenantHTTPClient("tenant-new", &cert)
+
+	if len(c.tenants) != maxTenantClients {
+		t.Errorf("tenant pools = %d; want %d", len(c.tenants), maxTenantClients)
+	}
+	if _, ok := c.tenants["tenant-1"]; ok {
+		t.Error("least recently used pool tenant-1 was kept")
+	}
+	if c.tenantHTTPClient("tenant-1", &cert) == second {
+		t.Error("evicted tenant-1 reused its closed pool")
+	}
+}
+
+func TestCredentialsResolverFallback(t *testing.T) {
+	server := newTenantServer(t, "default")
+	c := newRetryTestClient(t, server, NoRetryPolicy(), WithCredentialsResolver(
+		func(ctx context.Context) (string, *tls.Certificate, string, error) {
+			return "", nil, "", nil
+		},
+	))
+
+	var response struct{ APIKey string }
+	if err := c.get(context.Background(), "/accounts/1", &response); err != nil {
+		t.Fatalf("get = %v", err)
+	}
+	if response.APIKey != "test-api-key" {
+		t.Errorf("API key = %q; want the one passed to New", response.APIKey)
+	}
+	if len(c.tenants) != 0 {
+		t.Errorf("tenant clients = %v; want the default pool", c.tenants)
+	}
+
+	_, err := New("", "", newTestTLSConfig(server), WithCredentialsResolver(
+		func(ctx context.Context) (string, *tls.Certificate, string, error) {
+			return "", nil, "", errors.New("unused")
+		},
+	))
+	if err != nil {
+		t.Errorf("New without base URL and API key = %v; want them left to the resolver", err)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	// The API fails every call made with issuerA's key
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(APIKeyHeader) == "key-issuerA" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	breakerPolicy := DefaultCircuitBreakerPolicy()
+	breakerPolicy.MinRequests = 2
+	dictPolicy := DefaultDictBudgetPolicy()
+	dictPolicy.Person = DictBucket{Capacity: 1}
+	c := newRetryTestClient(t, server, NoRetryPolicy(),
+		WithCircuitBreaker(breakerPolicy),
+		WithRateLimit(RateLimitPolicy{Global: RateLimit{Rate: 1000, Burst: 10}}),
+		WithDictBudget(dictPolicy),
+		WithCredentialsResolver(func(ctx context.Context) (string, *tls.Certificate, string, error) {
+			tenant, _ := TenantFromContext(ctx)
+			return "key-" + tenant, nil, "", nil
+		}),
+	)
+	issuerA := WithTenant(context.Background(), "issuerA")
+	issuerB := WithTenant(context.Background(), "issuerB")
+
+	// The same account ID of two issuers has two DICT budgets
+	if _, err := c.GetPixKeyInfo(issuerA, 1, "a@email.com"); !errors.Is(err, ErrAPI) {
+		t.Fatalf("issuerA lookup = %v; want ErrAPI", err)
+	}
+	if _, err := c.GetPixKeyInfo(issuerB, 1, "a@email.com"); err != nil {
+		t.Errorf("issuerB lookup = %v; want its own DICT budget", err)
+	}
+	if _, err := c.GetPixKeyInfo(issuerA, 1, "a@email.com"); !errors.Is(err, ErrDictScanLimit) {
+		t.Errorf("issuerA lookup = %v; want ErrDictScanLimit", err)
+	}
+
+	// issuerA's failures open only its own circuit
+	for range 2 {
+		_ = c.get(issuerA, "/pix/psps", nil)
+	}
+	if got := c.TenantCircuitState("issuerA", EndpointGroupPix); got != CircuitOpen {
+		t.Errorf("issuerA PIX circuit = %s; want open", got)
+	}
+	if err := c.get(issuerB, "/pix/psps", nil); err != nil {
+		t.Errorf("issuerB get = %v; want its own circuit", err)
+	}
+	if got := c.CircuitState(EndpointGroupPix); got != CircuitClosed {
+		t.Errorf("PIX circuit without tenant = %s; want closed", got)
+	}
+
+	// A 429 for issuerA does not slow down issuerB
+	c.limiter.throttle("issuerA", EndpointGroupPix, time.Minute)
+	if wait, err := c.limiter.wait(issuerB, "issuerB", EndpointGroupPix); err != nil || wait != 0 {
+		t.Errorf("issuerB rate limit wait = %s, %v; want none", wait, err)
+	}
+}
@@ -1,6 +1,10 @@
 package client
 
-import "time"
+import (
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
 
 // Test helper functions for pointer types
 
@@ -8,7 +12,11 @@ func strPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int64) *int64 {
+func centsPtr(c types.MoneyCents) *types.MoneyCents {
+	return &c
+}
+
+func intPtrInt(i int) *int {
 	return &i
 }
 
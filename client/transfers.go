@@ -108,8 +108,8 @@ func (c *Client) CancelInternalTransfer(ctx context.Context, accountID int64, re
 }
 
 // TransferByID performs a transfer using document ID
-func (c *Client) TransferByID(ctx context.Context, document string, req *types.TransferByIDRequest) (*types.InternalTransferResponse, error) {
-	path := fmt.Sprintf("/accounts/%s/transfer/idid", document)
+func (c *Client) TransferByID(ctx context.Context, document types.Document, req *types.TransferByIDRequest) (*types.InternalTransferResponse, error) {
+	path := fmt.Sprintf("/accounts/%s/transfer/idid", document.Canonical())
 	var response types.InternalTransferResponse
 	if err := c.post(ctx, path, req, &response); err != nil {
 		return nil, err
@@ -15,7 +15,7 @@ import (
 func TestInternalTransferMethod(t *testing.T) {
 	accountID := int64(12345)
 	recipientID := int64(67890)
-	amount := int64(50000) // R$ 500.00
+	amount := types.MoneyCents(50000) // R$ 500.00
 	description := "Payment for services"
 
 	expectedReq := &types.InternalTransferRequest{
@@ -83,7 +83,7 @@ func TestInternalTransferMethod(t *testing.T) {
 func TestInternalTransferArrangement(t *testing.T) {
 	accountID := int64(12345)
 	recipientID := int64(67890)
-	amount := int64(30000)
+	amount := types.MoneyCents(30000)
 
 	expectedReq := &types.InternalTransferRequest{
 		RecipientAccountID: recipientID,
@@ -136,7 +136,7 @@ func TestBankTransferMethod(t *testing.T) {
 	expectedReq := &types.BankTransferRequest{
 		Recipient: types.BankTransferRecipient{
 			Name:        "John Doe",
-			Document:    "12345678900",
+			Document:    "52998224725",
 			BankCode:    "001",
 			Branch:      "1234",
 			Account:     "56789",
@@ -298,6 +298,7 @@ func TestBatchInternalTransfer(t *testing.T) {
 	processingCode := "BATCH123456"
 
 	expectedReq := &types.BatchTransferRequest{
+		ExpiresIn: "2025-12-31",
 		Transfers: []types.InternalTransferRequest{
 			{
 				RecipientAccountID: 11111,
@@ -567,9 +568,9 @@ func TestCancelInternalTransfer(t *testing.T) {
 
 // TestTransferByID tests the TransferByID method
 func TestTransferByID(t *testing.T) {
-	document := "12345678900"
+	document := types.Document("12345678900")
 	targetAccountID := int64(67890)
-	amount := int64(25000)
+	amount := types.MoneyCents(25000)
 	merchantName := "Test Merchant"
 
 	expectedReq := &types.TransferByIDRequest{
@@ -693,7 +694,7 @@ func TestBankTransferWithScheduling(t *testing.T) {
 	expectedReq := &types.BankTransferRequest{
 		Recipient: types.BankTransferRecipient{
 			Name:        "Scheduled Recipient",
-			Document:    "11122233344",
+			Document:    "11222333000181",
 			BankCode:    "237",
 			Branch:      "0001",
 			Account:     "12345",
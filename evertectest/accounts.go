@@ -0,0 +1,228 @@
+package evertectest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// currencyBRL is the ISO 4217 numeric code of the Brazilian real, used in statement amounts
+const currencyBRL = 986
+
+// Account seeds an account in the fake
+type Account struct {
+	ID       int64 // Assigned by AddAccount when zero
+	Name     string
+	Email    string
+	Document types.Document // CPF accounts are PERSONAL, CNPJ accounts COMPANY
+	Balance  types.Money
+	Status   types.AccountStatus // Defaults to ACTIVE
+}
+
+// account is the state of a fake account
+type account struct {
+	data      types.AccountDataResponse
+	balance   types.Money
+	entries   []types.StatementEntry
+	keys      []types.PixKeyResponse
+	bankslips []types.BankslipItem
+	cards     []types.CardResponse
+}
+
+// AddAccount creates an account and returns its ID
+func (s *Server) AddAccount(a Account) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == 0 {
+		a.ID = s.newID()
+	}
+	if a.Status == "" {
+		a.Status = types.AccountStatusActive
+	}
+	accountType := types.AccountTypePersonal
+	if a.Document.Kind() == types.DocumentTypeCNPJ {
+		accountType = types.AccountTypeCompany
+	}
+	now := time.Now().UTC()
+	s.accounts[a.ID] = &account{
+		data: types.AccountDataResponse{
+			AccountID:    a.ID,
+			Name:         a.Name,
+			Email:        a.Email,
+			Document:     a.Document.Canonical(),
+			DocumentType: a.Document.Kind(),
+			Status:       a.Status,
+			AccountType:  accountType,
+			CreatedAt:    &now,
+		},
+		balance: a.Balance,
+	}
+	return a.ID
+}
+
+// Balance returns the current balance of an account, or zero if it does not exist
+func (s *Server) Balance(accountID int64) types.Money {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[accountID]; ok {
+		return acc.balance
+	}
+	return 0
+}
+
+// Statement returns a copy of the statement entries of an account, oldest first
+func (s *Server) Statement(accountID int64) []types.StatementEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[accountID]; ok {
+		return slices.Clone(acc.entries)
+	}
+	return nil
+}
+
+// account returns the account named by the accountID path value
+func (s *Server) account(req *request) (*account, error) {
+	id, err := pathID(req, "accountID")
+	if err != nil {
+		return nil, err
+	}
+	acc, ok := s.accounts[id]
+	if !ok {
+		return nil, notFound("account")
+	}
+	return acc, nil
+}
+
+// post records a movement on the account statement and updates its balance.
+// Debits use a negative amount.
+func (acc *account) post(id int64, description string, amount types.Money) {
+	direction := "C"
+	if amount.IsNegative() {
+		direction = "D"
+	}
+	acc.balance = acc.balance.Add(amount)
+	acc.entries = append(acc.entries, types.StatementEntry{
+		TransactionID:          strconv.FormatInt(id, 10),
+		TransactionDate:        time.Now().UTC(),
+		TransactionDescription: description,
+		Amount:                 &types.AmountDTO{Amount: amount.Abs().InCents(), CurrencyCode: currencyBRL},
+		DebitOrCredit:          direction,
+	})
+}
+
+// debit checks the balance and posts a debit
+func (acc *account) debit(id int64, description string, amount types.Money) error {
+	if acc.balance.Cmp(amount) < 0 {
+		return &statusError{status: http.StatusPaymentRequired, body: insufficientFundsBody{
+			Code:      "INSUFFICIENT_FUNDS",
+			Message:   fmt.Sprintf("insufficient funds: balance %s, required %s", acc.balance, amount),
+			Required:  amount.Cents(),
+			Available: acc.balance.Cents(),
+		}}
+	}
+	acc.post(id, description, amount.Neg())
+	return nil
+}
+
+// insufficientFundsBody is the JSON body of 402 responses
+type insufficientFundsBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Required  int64  `json:"required"`
+	Available int64  `json:"available"`
+}
+
+func (s *Server) getAccount(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	data := acc.data
+	balance := acc.balance.InCents()
+	data.Balance = &balance
+	return data, nil
+}
+
+func (s *Server) getBalance(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	return types.BalanceResponse{
+		Message:   "success",
+		IDAccount: acc.data.AccountID,
+		Balance:   acc.balance.InCents(),
+		DateTime:  time.Now().UTC(),
+	}, nil
+}
+
+func (s *Server) getStatement(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	return types.StatementResponse{
+		Message:   "success",
+		Total:     int32(len(acc.entries)), //nolint:gosec // Test statements are small
+		Count:     int32(len(acc.entries)), //nolint:gosec // Test statements are small
+		AccountID: acc.data.AccountID,
+		Entries:   slices.Clone(acc.entries),
+	}, nil
+}
+
+func (s *Server) getStatementEntry(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range acc.entries {
+		if e.TransactionID == req.PathValue("transactionID") {
+			return e, nil
+		}
+	}
+	return nil, notFound("transaction")
+}
+
+func (s *Server) internalTransfer(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.InternalTransferRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	recipient, ok := s.accounts[body.RecipientAccountID]
+	if !ok {
+		return nil, notFound("recipient account")
+	}
+
+	id := s.newID()
+	amount := body.TransferAmount.Money()
+	if err := acc.debit(id, "Transferência para "+recipient.data.Name, amount); err != nil {
+		return nil, err
+	}
+	recipient.post(id, "Transferência de "+acc.data.Name, amount)
+
+	now := time.Now().UTC()
+	code := authenticationCode(id)
+	return types.InternalTransferResponse{
+		Message:            "success",
+		TransactionID:      id,
+		DateTimeTransfer:   &now,
+		Amount:             body.TransferAmount,
+		RecipientAccountID: &recipient.data.AccountID,
+		RecipientName:      &recipient.data.Name,
+		AuthenticationCode: &code,
+	}, nil
+}
+
+// authenticationCode returns a deterministic authentication code for a transaction
+func authenticationCode(id int64) string {
+	return fmt.Sprintf("AUTH%012d", id)
+}
@@ -0,0 +1,100 @@
+package evertectest
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/boleto"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// bankCode is the bank code in the barcodes of bankslips issued by the fake
+const bankCode = "999"
+
+// PayBankslip marks a pending bankslip as paid and credits its amount to the account,
+// as when the payer settles it at another bank
+func (s *Server) PayBankslip(accountID, bankslipID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, ok := s.accounts[accountID]
+	if !ok {
+		return fmt.Errorf("evertectest: account %d not found", accountID)
+	}
+	i := slices.IndexFunc(acc.bankslips, func(b types.BankslipItem) bool { return b.ID == bankslipID })
+	if i < 0 {
+		return fmt.Errorf("evertectest: bankslip %d not found", bankslipID)
+	}
+	slip := &acc.bankslips[i]
+	if slip.Status != types.BankSlipStatusPending {
+		return fmt.Errorf("evertectest: bankslip %d is %s", bankslipID, slip.Status)
+	}
+	slip.Status = types.BankSlipStatusPaid
+	acc.post(s.newID(), "Pagamento de boleto", slip.Amount.Money())
+	return nil
+}
+
+func (s *Server) listBankslips(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	return types.BankslipsResponse{Bankslips: slices.Clone(acc.bankslips)}, nil
+}
+
+func (s *Server) createBankslip(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.CreateBankslipRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	due, err := time.Parse(time.DateOnly, body.DueDate)
+	if err != nil {
+		return nil, badRequest("dueDate must be a date in YYYY-MM-DD format")
+	}
+
+	id := s.newID()
+	slip, err := boleto.Parse(bankslipBarcode(due, body.Amount, id))
+	if err != nil {
+		return nil, err
+	}
+	acc.bankslips = append(acc.bankslips, types.BankslipItem{
+		ID:            id,
+		DigitableLine: slip.DigitableLine,
+		Barcode:       slip.Barcode,
+		Amount:        body.Amount,
+		DueDate:       body.DueDate,
+		Status:        types.BankSlipStatusPending,
+	})
+	return types.CreateBankslipResponse{
+		IDBankslip:    id,
+		DigitableLine: slip.DigitableLine,
+		DueDate:       body.DueDate,
+		Barcode:       &slip.Barcode,
+	}, nil
+}
+
+// factorBase is the date of due factor 1000 in the factor cycle started in February 2025
+var factorBase = time.Date(2025, time.February, 22, 0, 0, 0, 0, time.UTC)
+
+// bankslipBarcode builds a valid bank slip barcode whose free field holds the bankslip ID
+func bankslipBarcode(due time.Time, amount types.MoneyCents, id int64) string {
+	days := int(due.Sub(factorBase).Hours() / 24)
+	factor := 1000 + (days%9000+9000)%9000
+	digits := fmt.Sprintf("%s9%04d%010d%025d", bankCode, factor, amount, id)
+
+	// General check digit: module 11 with weights 2 to 9 from the right; 0, 10 and 11 become 1
+	sum := 0
+	for i := range len(digits) {
+		sum += int(digits[len(digits)-1-i]-'0') * (2 + i%8)
+	}
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 1
+	}
+	return fmt.Sprintf("%s%d%s", digits[:4], dv, digits[4:])
+}
@@ -0,0 +1,125 @@
+package evertectest
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// Card status codes used by the fake
+const (
+	cardStatusActive  int32 = 1
+	cardStatusBlocked int32 = 2
+)
+
+// card returns the card named by the cardID path value
+func (s *Server) card(req *request) (*types.CardResponse, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	id, err := pathID(req, "cardID")
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(acc.cards, func(c types.CardResponse) bool { return c.ID == id })
+	if i < 0 {
+		return nil, notFound("card")
+	}
+	return &acc.cards[i], nil
+}
+
+func (s *Server) listCards(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	return types.AccountCardsResponse{
+		Message:   "success",
+		Cards:     slices.Clone(acc.cards),
+		AccountID: acc.data.AccountID,
+	}, nil
+}
+
+func (s *Server) getCard(req *request) (any, error) {
+	card, err := s.card(req)
+	if err != nil {
+		return nil, err
+	}
+	return *card, nil
+}
+
+func (s *Server) createCard(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.CreateCardRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	id := s.newID()
+	lastFour := fmt.Sprintf("%04d", id%10000)
+	expiration := time.Now().UTC().AddDate(5, 0, 0)
+	acc.cards = append(acc.cards, types.CardResponse{
+		ID:             id,
+		CardType:       "PHYSICAL",
+		FunctionType:   "DEBIT",
+		Modality:       "PREPAID",
+		ExpirationDate: expiration,
+		Status:         cardStatusActive,
+		PrintedName:    &acc.data.Name,
+		LastFour:       &lastFour,
+		Tag:            body.Tag,
+		AccountID:      acc.data.AccountID,
+	})
+	setCardStatus(&acc.cards[len(acc.cards)-1], cardStatusActive)
+	return types.GenericResponse{Message: "success"}, nil
+}
+
+func (s *Server) blockCard(req *request) (any, error) {
+	card, err := s.card(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.BlockCardRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	if card.Status != cardStatusActive {
+		return nil, conflict("CARD_NOT_ACTIVE", "card is not active")
+	}
+	status := body.DestinationStatus
+	if status == 0 {
+		status = cardStatusBlocked
+	}
+	setCardStatus(card, status)
+	return types.BlockCardResponse{Message: "success"}, nil
+}
+
+func (s *Server) unblockCard(req *request) (any, error) {
+	card, err := s.card(req)
+	if err != nil {
+		return nil, err
+	}
+	if card.Status == cardStatusActive {
+		return nil, conflict("CARD_ALREADY_ACTIVE", "card is already active")
+	}
+	setCardStatus(card, cardStatusActive)
+	return types.UnblockCardResponse{Message: "success"}, nil
+}
+
+// setCardStatus updates the status code, description and timestamp of a card
+func setCardStatus(card *types.CardResponse, status int32) {
+	description := string(types.CardStatusBlocked)
+	if status == cardStatusActive {
+		description = string(types.CardStatusActive)
+	}
+	now := time.Now().UTC()
+	card.Status = status
+	card.StatusDescription = &description
+	card.StatusDateTime = &now
+}
@@ -0,0 +1,111 @@
+package evertectest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AllRoutes makes InjectFault apply a fault to every route
+const AllRoutes = "*"
+
+// Fault alters the responses of a route. Build one with Latency, RateLimited,
+// IntegrationError or BusinessError, or fill the fields directly.
+type Fault struct {
+	// Latency delays the response; the request fails early if the client gives up
+	Latency time.Duration
+
+	// Status, when non-zero, is returned instead of calling the fake, with Body as JSON
+	Status int
+	Body   any
+	Header http.Header
+
+	// Count is the number of requests the fault applies to; zero means every request
+	// until ClearFaults
+	Count int
+}
+
+// Latency returns a fault that delays responses by d
+func Latency(d time.Duration) Fault {
+	return Fault{Latency: d}
+}
+
+// RateLimited returns a fault answering 429 Too Many Requests with a Retry-After header
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{
+		Status: http.StatusTooManyRequests,
+		Body:   errorBody{Message: "too many requests"},
+		Header: http.Header{"Retry-After": {strconv.Itoa(int(retryAfter.Seconds()))}},
+	}
+}
+
+// IntegrationError returns a fault answering 503 with a message, which the client
+// reports as a *client.IntegrationError
+func IntegrationError(message string) Fault {
+	return Fault{Status: http.StatusServiceUnavailable, Body: errorBody{Message: message}}
+}
+
+// BusinessError returns a fault answering 409 with a business code, which the client
+// reports as a *client.BusinessRuleError
+func BusinessError(code, message string) Fault {
+	return Fault{Status: http.StatusConflict, Body: errorBody{Code: code, Message: message}}
+}
+
+// InjectFault adds a fault to route (one of the Route constants, or AllRoutes). Faults
+// on a route apply in the order they were added; route-specific faults come before
+// AllRoutes faults.
+func (s *Server) InjectFault(route string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[route] = append(s.faults[route], &f)
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.faults)
+}
+
+// takeFault returns the next fault for route, consuming one use of it
+func (s *Server) takeFault(route string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range []string{route, AllRoutes} {
+		faults := s.faults[key]
+		if len(faults) == 0 {
+			continue
+		}
+		f := faults[0]
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults[key] = faults[1:]
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apply waits for the latency and writes the fault response, if any. It reports whether
+// the request should still be served by the fake.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return false
+		}
+	}
+	if f.Status == 0 {
+		return true
+	}
+	for name, values := range f.Header {
+		w.Header()[name] = values
+	}
+	writeJSON(w, f.Status, f.Body)
+	return false
+}
@@ -0,0 +1,190 @@
+package evertectest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/webhook"
+)
+
+// ispb is the institution code of the fake, used in EndToEnd IDs and webhook payloads
+const ispb = "00000000"
+
+// pixPayment is a PIX payment made through the fake
+type pixPayment struct {
+	transactionID int64
+	endToEnd      string
+	authCode      string
+}
+
+// findPixKey returns the account holding a PIX key
+func (s *Server) findPixKey(key string) (*account, bool) {
+	for _, acc := range s.accounts {
+		for _, k := range acc.keys {
+			if k.KeyValue == key {
+				return acc, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) createPixKey(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.CreatePixKeyRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	value := body.Key
+	if body.KeyType == types.PixKeyTypeRandom && value == "" {
+		value = randomEVP()
+	}
+	if _, exists := s.findPixKey(value); exists {
+		return nil, conflict("PIX_KEY_ALREADY_EXISTS", "PIX key is already registered")
+	}
+
+	id := s.newID()
+	now := time.Now().UTC()
+	key := types.PixKeyResponse{
+		KeyID:     &id,
+		KeyType:   body.KeyType,
+		KeyValue:  value,
+		Status:    types.PixKeyStatusActive,
+		AccountID: acc.data.AccountID,
+		CreatedAt: &now,
+	}
+	acc.keys = append(acc.keys, key)
+	return key, nil
+}
+
+func (s *Server) deletePixKey(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	var body types.DeletePixKeyRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(acc.keys, func(k types.PixKeyResponse) bool { return k.KeyValue == body.Key })
+	if i < 0 {
+		return nil, notFound("PIX key")
+	}
+	acc.keys = slices.Delete(acc.keys, i, i+1)
+	return types.GenericResponse{Message: "success"}, nil
+}
+
+func (s *Server) getPixKeys(req *request) (any, error) {
+	acc, err := s.account(req)
+	if err != nil {
+		return nil, err
+	}
+	return types.PixKeyListResponse{Keys: slices.Clone(acc.keys)}, nil
+}
+
+// pixPayment debits the payer and, when the recipient key belongs to an account of the
+// fake, credits it. A movimento_pix event is sent for each account involved.
+func (s *Server) pixPayment(req *request) (any, error) {
+	var body types.PixPaymentRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	payer, ok := s.accounts[body.AccountID]
+	if !ok {
+		return nil, notFound("account")
+	}
+
+	id := s.newID()
+	if err := payer.debit(id, "PIX enviado para "+body.RecipientName, body.OperationAmount); err != nil {
+		return nil, err
+	}
+	payment := &pixPayment{transactionID: id, endToEnd: endToEndID(id), authCode: authenticationCode(id)}
+	s.payments[payment.endToEnd] = payment
+
+	movement := webhook.PixMovementTypeManual
+	var key string
+	if body.RecipientAddressingKey != nil {
+		movement, key = webhook.PixMovementTypeKey, *body.RecipientAddressingKey
+	}
+	event := webhook.PixMovementEvent{
+		AccountID:       payer.data.AccountID,
+		AuthorizationID: id,
+		Value:           body.OperationAmount,
+		MovementType:    movement,
+		EndToEnd:        payment.endToEnd,
+		PixKey:          key,
+		Payer:           payer.bankAccount(),
+		Recipient: &webhook.BankAccount{
+			Name:        body.RecipientName,
+			Document:    body.RecipientCpfCnpj,
+			Bank:        body.RecipientInstitutionCode,
+			Branch:      body.RecipientBranchCode,
+			Account:     body.RecipientAccountNumber,
+			AccountType: string(body.RecipientAccountType),
+		},
+	}
+	req.events = append(req.events, newEvent(webhook.EventTypePixMovement, event))
+
+	if recipient, ok := s.findPixKey(key); ok && key != "" {
+		recipient.post(id, "PIX recebido de "+payer.data.Name, body.OperationAmount)
+		event.AccountID = recipient.data.AccountID
+		req.events = append(req.events, newEvent(webhook.EventTypePixMovement, event))
+	}
+
+	return types.PixPaymentResponse{
+		Message:            "success",
+		IDTransaction:      id,
+		AuthenticationCode: payment.authCode,
+	}, nil
+}
+
+func (s *Server) getPixPayment(req *request) (any, error) {
+	payment, ok := s.payments[req.PathValue("endToEnd")]
+	if !ok {
+		return nil, notFound("payment")
+	}
+	return types.GetPixInfoResponse{
+		TransactionID:      payment.transactionID,
+		AuthenticationCode: payment.authCode,
+		EndToEnd:           payment.endToEnd,
+		Status:             types.TransactionStatusApproved,
+		Success:            true,
+		ResultDescription:  "success",
+	}, nil
+}
+
+// bankAccount describes the account in webhook payloads
+func (acc *account) bankAccount() *webhook.BankAccount {
+	return &webhook.BankAccount{
+		Name:        acc.data.Name,
+		Document:    types.Document(acc.data.Document),
+		Bank:        ispb,
+		Branch:      "0001",
+		Account:     fmt.Sprint(acc.data.AccountID),
+		AccountType: string(types.PixAccountTypeTRAN),
+	}
+}
+
+// endToEndID returns a 32-character EndToEnd ID: E, the ISPB, the date and time, and a
+// sequence number
+func endToEndID(id int64) string {
+	return fmt.Sprintf("E%s%s%011d", ispb, time.Now().UTC().Format("200601021504"), id)
+}
+
+// randomEVP returns a random (version 4) UUID, the format of EVP keys
+func randomEVP() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := fmt.Sprintf("%x", b)
+	return strings.Join([]string{h[:8], h[8:12], h[12:16], h[16:20], h[20:]}, "-")
+}
@@ -0,0 +1,303 @@
+// Package evertectest provides a local fake of the Evertec Conta de Pagamento API for
+// integration tests of code built on client.Client.
+//
+// NewServer starts an httptest TLS server that requires client certificates (mTLS), as
+// the real API does, and keeps in memory the state of accounts, balances, statements,
+// internal transfers, PIX keys and payments, bankslips and cards. Responses use the
+// structs of the types package. Faults (latency, 429, 503, 409 business errors) can be
+// injected per route, and PIX payments emit webhook events to a configured URL.
+//
+//	srv := evertectest.NewServer(t, evertectest.WithWebhookURL(hookURL))
+//	id := srv.AddAccount(evertectest.Account{Name: "Maria", Balance: 100 * types.Real})
+//	c := srv.Client()
+//	balance, err := c.GetAccountBalance(ctx, id)
+package evertectest
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/client"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// DefaultAPIKey is the API key accepted by the server unless WithAPIKey is used
+const DefaultAPIKey = "evertectest-api-key"
+
+// Routes served by the fake, in http.ServeMux pattern syntax. Use them with InjectFault.
+const (
+	RouteGetAccount        = "GET /accounts/{accountID}"
+	RouteGetBalance        = "GET /accounts/{accountID}/balance"
+	RouteGetStatement      = "GET /accounts/{accountID}/statement"
+	RouteGetStatementEntry = "GET /accounts/{accountID}/statement/{transactionID}"
+	RouteInternalTransfer  = "POST /accounts/{accountID}/transfer"
+	RouteCreatePixKey      = "POST /accounts/{accountID}/createKey"
+	RouteDeletePixKey      = "POST /accounts/{accountID}/deleteKey"
+	RouteGetPixKeys        = "GET /accounts/{accountID}/getKeys"
+	RoutePixPayment        = "POST /pix/transactions/payment"
+	RouteGetPixPayment     = "GET /pix/transactions/payment/{endToEnd}"
+	RouteListBankslips     = "GET /accounts/{accountID}/bankslip"
+	RouteCreateBankslip    = "POST /accounts/{accountID}/bankslip"
+	RouteListCards         = "GET /accounts/{accountID}/cards"
+	RouteGetCard           = "GET /accounts/{accountID}/cards/{cardID}"
+	RouteCreateCard        = "POST /accounts/{accountID}/cards/new"
+	RouteBlockCard         = "PUT /accounts/{accountID}/cards/{cardID}/block"
+	RouteUnblockCard       = "PUT /accounts/{accountID}/cards/{cardID}/unblock"
+)
+
+// Server is a fake Evertec API served over mTLS. Its methods are safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, to be used as the client base URL
+	URL string
+
+	tb         testing.TB
+	srv        *httptest.Server
+	certs      *certificates
+	apiKey     string
+	webhookURL string
+	webhook    *http.Client
+
+	mu       sync.Mutex
+	nextID   int64
+	accounts map[int64]*account
+	payments map[string]*pixPayment // by EndToEnd
+	faults   map[string][]*Fault    // by route, or AllRoutes
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAPIKey sets the API key the server requires in the X-API-KEY header
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithWebhookURL sets the base URL that receives webhook events. Each event is posted
+// to the URL followed by its type, e.g. <url>/movimento_pix, matching webhook.Handler.Register.
+func WithWebhookURL(url string) Option {
+	return func(s *Server) {
+		s.webhookURL = url
+	}
+}
+
+// WithWebhookClient sets the HTTP client used to deliver webhook events, e.g. one
+// trusting the certificate of an httptest TLS server
+func WithWebhookClient(c *http.Client) Option {
+	return func(s *Server) {
+		s.webhook = c
+	}
+}
+
+// NewServer starts a fake API server and registers its shutdown with tb.Cleanup.
+// It fails the test if the server cannot start.
+func NewServer(tb testing.TB, opts ...Option) *Server {
+	tb.Helper()
+
+	s := &Server{
+		tb:       tb,
+		apiKey:   DefaultAPIKey,
+		webhook:  &http.Client{Timeout: 5 * time.Second},
+		nextID:   1000,
+		accounts: make(map[int64]*account),
+		payments: make(map[string]*pixPayment),
+		faults:   make(map[string][]*Fault),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	certs, err := newCertificates()
+	if err != nil {
+		tb.Fatalf("evertectest: %v", err)
+	}
+	s.certs = certs
+
+	mux := http.NewServeMux()
+	s.register(mux)
+	s.srv = httptest.NewUnstartedServer(mux)
+	s.srv.TLS = certs.serverTLSConfig()
+	s.srv.StartTLS()
+	s.URL = s.srv.URL
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Close shuts down the server. It is called automatically at the end of the test.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// APIKey returns the API key the server accepts
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// ClientTLSConfig returns a TLS configuration with a client certificate accepted by the
+// server and the server CA as the only trusted root
+func (s *Server) ClientTLSConfig() *tls.Config {
+	return s.certs.clientTLSConfig()
+}
+
+// Client returns a client.Client connected to the server over mTLS. Options are applied
+// after the server settings; it fails the test if the client cannot be created.
+func (s *Server) Client(opts ...client.Option) *client.Client {
+	s.tb.Helper()
+	c, err := client.New(s.URL, s.apiKey, s.ClientTLSConfig(), opts...)
+	if err != nil {
+		s.tb.Fatalf("evertectest: %v", err)
+	}
+	s.tb.Cleanup(c.Close)
+	return c
+}
+
+// register mounts every fake route on mux
+func (s *Server) register(mux *http.ServeMux) {
+	routes := map[string]handlerFunc{
+		RouteGetAccount:        s.getAccount,
+		RouteGetBalance:        s.getBalance,
+		RouteGetStatement:      s.getStatement,
+		RouteGetStatementEntry: s.getStatementEntry,
+		RouteInternalTransfer:  s.internalTransfer,
+		RouteCreatePixKey:      s.createPixKey,
+		RouteDeletePixKey:      s.deletePixKey,
+		RouteGetPixKeys:        s.getPixKeys,
+		RoutePixPayment:        s.pixPayment,
+		RouteGetPixPayment:     s.getPixPayment,
+		RouteListBankslips:     s.listBankslips,
+		RouteCreateBankslip:    s.createBankslip,
+		RouteListCards:         s.listCards,
+		RouteGetCard:           s.getCard,
+		RouteCreateCard:        s.createCard,
+		RouteBlockCard:         s.blockCard,
+		RouteUnblockCard:       s.unblockCard,
+	}
+	for route, h := range routes {
+		mux.Handle(route, s.wrap(route, h))
+	}
+}
+
+// request is an API call being served. Handlers queue webhook events on it; they are
+// delivered once the handler has released the server lock.
+type request struct {
+	*http.Request
+	events []event
+}
+
+// handlerFunc serves an API call under the server lock, returning the response body or
+// a *statusError
+type handlerFunc func(req *request) (any, error)
+
+// wrap applies authentication, injected faults and locking around a handler
+func (s *Server) wrap(route string, h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(client.APIKeyHeader) != s.apiKey {
+			writeJSON(w, http.StatusUnauthorized, errorBody{Message: "invalid API key"})
+			return
+		}
+
+		if fault := s.takeFault(route); fault != nil {
+			if !fault.apply(w, r) {
+				return
+			}
+		}
+
+		req := &request{Request: r}
+		s.mu.Lock()
+		body, err := h(req)
+		s.mu.Unlock()
+
+		// Deliver events before answering, so tests observe them once the call returns
+		for _, e := range req.events {
+			s.deliver(e)
+		}
+
+		var se *statusError
+		switch {
+		case errors.As(err, &se):
+			writeJSON(w, se.status, se.body)
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, errorBody{Message: err.Error()})
+		default:
+			writeJSON(w, http.StatusOK, body)
+		}
+	})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint:errchkjson // The client sees a truncated body if encoding fails
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// decode reads a JSON request body into v and validates it when v is a types.Validator
+func decode(req *request, v any) error {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return badRequest("invalid request body: " + err.Error())
+	}
+	if validator, ok := v.(types.Validator); ok {
+		var errs types.ValidationErrors
+		if err := validator.Validate(); errors.As(err, &errs) {
+			return &statusError{status: http.StatusBadRequest, body: errs}
+		}
+	}
+	return nil
+}
+
+// pathID parses a numeric path value
+func pathID(req *request, name string) (int64, error) {
+	id, err := strconv.ParseInt(req.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, badRequest(name + " must be numeric")
+	}
+	return id, nil
+}
+
+// newID returns a fresh identifier for transactions, keys, bankslips and cards
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// errorBody is the JSON body of API errors
+type errorBody struct {
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Resource string `json:"resource,omitempty"`
+}
+
+// statusError is an API error response returned by handlers
+type statusError struct {
+	status int
+	body   any
+}
+
+// Error implements the error interface
+func (e *statusError) Error() string {
+	return http.StatusText(e.status)
+}
+
+// badRequest returns a 400 response
+func badRequest(message string) error {
+	return &statusError{status: http.StatusBadRequest, body: errorBody{Message: message}}
+}
+
+// notFound returns a 404 response for a missing resource
+func notFound(resource string) error {
+	return &statusError{status: http.StatusNotFound, body: errorBody{Message: resource + " not found", Resource: resource}}
+}
+
+// conflict returns a 409 business rule response
+func conflict(code, message string) error {
+	return &statusError{status: http.StatusConflict, body: errorBody{Code: code, Message: message}}
+}
@@ -0,0 +1,252 @@
+package evertectest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/boleto"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/client"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/webhook"
+)
+
+func TestInternalTransfer(t *testing.T) {
+	srv := NewServer(t)
+	payer := srv.AddAccount(Account{Name: "Maria", Document: "529.982.247-25", Balance: 100 * types.Real})
+	payee := srv.AddAccount(Account{Name: "Loja", Document: "11.222.333/0001-81"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	resp, err := c.InternalTransfer(ctx, payer, &types.InternalTransferRequest{RecipientAccountID: payee, TransferAmount: 2550})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Amount != 2550 || *resp.RecipientName != "Loja" {
+		t.Errorf("transfer = %+v", resp)
+	}
+
+	balance, err := c.GetAccountBalance(ctx, payer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Balance != 7450 || srv.Balance(payee) != types.MustParseMoney("25.50") {
+		t.Errorf("balances = %s and %s", balance.Balance, srv.Balance(payee))
+	}
+
+	statement, err := c.GetAccountStatement(ctx, payee, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statement.Entries) != 1 || statement.Entries[0].DebitOrCredit != "C" || statement.Entries[0].Amount.Amount != 2550 {
+		t.Errorf("statement = %+v", statement.Entries)
+	}
+
+	account, err := c.GetAccount(ctx, payee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.AccountType != types.AccountTypeCompany || account.DocumentType != types.DocumentTypeCNPJ {
+		t.Errorf("account = %+v", account)
+	}
+
+	_, err = c.InternalTransfer(ctx, payer, &types.InternalTransferRequest{RecipientAccountID: payee, TransferAmount: 100000})
+	var fundsErr *client.InsufficientFundsError
+	if !errors.As(err, &fundsErr) || fundsErr.Available != 7450 {
+		t.Errorf("InternalTransfer = %v; want insufficient funds with 7450 available", err)
+	}
+	if _, err := c.GetAccount(ctx, 1); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetAccount = %v; want ErrNotFound", err)
+	}
+}
+
+func TestPixPaymentEmitsWebhooks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []*webhook.PixMovementEvent
+	)
+	handler := webhook.NewHandler(webhook.OnPixMovement(func(e *webhook.PixMovementEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+		return nil
+	}))
+	mux := http.NewServeMux()
+	handler.Register(mux, "/hooks")
+	hooks := httptest.NewServer(mux)
+	defer hooks.Close()
+
+	srv := NewServer(t, WithWebhookURL(hooks.URL+"/hooks"))
+	payer := srv.AddAccount(Account{Name: "Maria", Document: "52998224725", Balance: 50 * types.Real})
+	payee := srv.AddAccount(Account{Name: "Joao", Document: "11144477735"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	if _, err := c.CreatePixKey(ctx, payee, &types.CreatePixKeyRequest{KeyType: types.PixKeyTypeEmail, Key: "joao@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.CreatePixKey(ctx, payer, &types.CreatePixKeyRequest{KeyType: types.PixKeyTypeEmail, Key: "joao@example.com"})
+	var bizErr *client.BusinessRuleError
+	if !errors.As(err, &bizErr) || bizErr.Code != "PIX_KEY_ALREADY_EXISTS" {
+		t.Errorf("duplicate CreatePixKey = %v", err)
+	}
+
+	key := "joao@example.com"
+	payment, err := c.DoPixPayment(ctx, &types.PixPaymentRequest{
+		AccountID:                payer,
+		RecipientInstitutionCode: ispb,
+		RecipientBranchCode:      "0001",
+		RecipientAccountNumber:   "1",
+		RecipientAccountType:     types.PixAccountTypeTRAN,
+		RecipientCpfCnpj:         "11144477735",
+		RecipientName:            "Joao",
+		OperationAmount:          types.MustParseMoney("12.34"),
+		RecipientAddressingKey:   &key,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.Balance(payer) != types.MustParseMoney("37.66") || srv.Balance(payee) != types.MustParseMoney("12.34") {
+		t.Errorf("balances = %s and %s", srv.Balance(payer), srv.Balance(payee))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("got %d events; want 2", len(events))
+	}
+	if events[0].AccountID != payer || events[1].AccountID != payee || events[0].AuthorizationID != payment.IDTransaction ||
+		events[0].MovementType != webhook.PixMovementTypeKey || events[0].Value != types.MustParseMoney("12.34") {
+		t.Errorf("events = %+v, %+v", events[0], events[1])
+	}
+
+	info, err := c.GetPixPaymentByE2E(ctx, events[0].EndToEnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.TransactionID != payment.IDTransaction || len(info.EndToEnd) != 32 {
+		t.Errorf("payment info = %+v", info)
+	}
+}
+
+func TestBankslipsAndCards(t *testing.T) {
+	srv := NewServer(t)
+	id := srv.AddAccount(Account{Name: "Maria", Document: "52998224725"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	created, err := c.CreateBankslip(ctx, id, &types.CreateBankslipRequest{Amount: 15000, DueDate: "2026-03-10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slip, err := boleto.Parse(created.DigitableLine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	due, _ := slip.DueDate(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if slip.Amount != 150*types.Real || due.Format(time.DateOnly) != "2026-03-10" {
+		t.Errorf("bankslip = %+v, due %s", slip, due)
+	}
+	if err := srv.PayBankslip(id, created.IDBankslip); err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.ListBankslips(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Bankslips[0].Status != types.BankSlipStatusPaid || srv.Balance(id) != 150*types.Real {
+		t.Errorf("bankslips = %+v, balance %s", list.Bankslips, srv.Balance(id))
+	}
+
+	if _, err := c.CreateCard(ctx, id, &types.CreateCardRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	cards, err := c.ListCards(ctx, id, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cardID := cards.Cards[0].ID
+	if _, err := c.BlockCard(ctx, id, cardID, &types.BlockCardRequest{BlockMotivation: "lost"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BlockCard(ctx, id, cardID, &types.BlockCardRequest{BlockMotivation: "lost"}); !errors.Is(err, client.ErrBusinessRule) {
+		t.Errorf("second BlockCard = %v; want ErrBusinessRule", err)
+	}
+	if _, err := c.UnblockCard(ctx, id, cardID); err != nil {
+		t.Fatal(err)
+	}
+	card, err := c.GetCard(ctx, id, cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Status != cardStatusActive || *card.StatusDescription != string(types.CardStatusActive) {
+		t.Errorf("card = %+v", card)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	srv := NewServer(t)
+	id := srv.AddAccount(Account{Name: "Maria", Balance: 10 * types.Real})
+	ctx := context.Background()
+
+	t.Run("integration error is retried", func(t *testing.T) {
+		f := IntegrationError("upstream unavailable")
+		f.Count = 1
+		srv.InjectFault(RouteGetBalance, f)
+		if _, err := srv.Client().GetAccountBalance(ctx, id); err != nil {
+			t.Errorf("GetAccountBalance = %v; want success after retry", err)
+		}
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		defer srv.ClearFaults()
+		srv.InjectFault(AllRoutes, RateLimited(time.Second))
+		_, err := srv.Client(client.WithoutRetries()).GetAccount(ctx, id)
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+			t.Errorf("GetAccount = %v; want 429", err)
+		}
+	})
+
+	t.Run("business error", func(t *testing.T) {
+		defer srv.ClearFaults()
+		srv.InjectFault(RouteInternalTransfer, BusinessError("LIMIT_EXCEEDED", "daily limit exceeded"))
+		_, err := srv.Client().InternalTransfer(ctx, id, &types.InternalTransferRequest{RecipientAccountID: id, TransferAmount: 1})
+		var bizErr *client.BusinessRuleError
+		if !errors.As(err, &bizErr) || bizErr.Code != "LIMIT_EXCEEDED" {
+			t.Errorf("InternalTransfer = %v; want LIMIT_EXCEEDED", err)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		defer srv.ClearFaults()
+		srv.InjectFault(RouteGetAccount, Latency(time.Second))
+		_, err := srv.Client(client.WithTimeout(50*time.Millisecond), client.WithoutRetries()).GetAccount(ctx, id)
+		if err == nil {
+			t.Error("GetAccount succeeded; want timeout")
+		}
+	})
+}
+
+func TestRequiresClientCertificate(t *testing.T) {
+	srv := NewServer(t)
+	tlsConfig := srv.ClientTLSConfig()
+	tlsConfig.Certificates = nil
+
+	c, err := client.New(srv.URL, srv.APIKey(), tlsConfig, client.WithoutRetries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.GetAccount(context.Background(), 1); err == nil {
+		t.Error("request without client certificate succeeded")
+	}
+
+	if _, err := srv.Client(client.WithoutRetries()).GetAccount(context.Background(), 1); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetAccount = %v; want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,63 @@
+package evertectest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/webhook"
+)
+
+// event is a webhook event waiting to be delivered
+type event struct {
+	eventType webhook.EventType
+	payload   any
+}
+
+// newEvent returns a webhook event
+func newEvent(eventType webhook.EventType, payload any) event {
+	return event{eventType: eventType, payload: payload}
+}
+
+// SendWebhook posts an event to the webhook URL, e.g. to simulate a precautionary block.
+// It returns an error if no URL is configured or the receiver does not answer 2xx.
+func (s *Server) SendWebhook(ctx context.Context, eventType webhook.EventType, payload any) error {
+	if s.webhookURL == "" {
+		return fmt.Errorf("evertectest: no webhook URL configured")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("evertectest: marshal %s event: %w", eventType, err)
+	}
+
+	url := strings.TrimRight(s.webhookURL, "/") + "/" + string(eventType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("evertectest: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.webhook.Do(req)
+	if err != nil {
+		return fmt.Errorf("evertectest: deliver %s event: %w", eventType, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("evertectest: deliver %s event: HTTP %d", eventType, resp.StatusCode)
+	}
+	return nil
+}
+
+// deliver sends an event emitted by the fake, if a webhook URL is configured. Failures
+// are logged to the test rather than failing it, since tests may inject them on purpose.
+func (s *Server) deliver(e event) {
+	if s.webhookURL == "" {
+		return
+	}
+	if err := s.SendWebhook(context.Background(), e.eventType, e.payload); err != nil {
+		s.tb.Logf("%v", err)
+	}
+}
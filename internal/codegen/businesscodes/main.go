@@ -0,0 +1,123 @@
+// Command businesscodes generates the business error code catalog of the client
+// package from business_codes.json:
+//
+//	go run ./internal/codegen/businesscodes -in client/business_codes.json -out client/business_codes_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// catalog is the content of business_codes.json
+type catalog struct {
+	Sentinels []sentinel `json:"sentinels"`
+}
+
+// sentinel is a business error sentinel and the codes that map to it
+type sentinel struct {
+	Name      string `json:"name"`
+	PT        string `json:"pt"`
+	EN        string `json:"en"`
+	Retryable bool   `json:"retryable"`
+	Codes     []code `json:"codes"`
+}
+
+// code is a business code returned by the API
+type code struct {
+	Code   string `json:"code"`
+	Source string `json:"source"`
+}
+
+func main() {
+	in := flag.String("in", "business_codes.json", "catalog file")
+	out := flag.String("out", "business_codes_gen.go", "generated Go file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil { //nolint:gosec // generated source file
+		log.Fatal(err)
+	}
+}
+
+// sources maps the source of a code to the name used in the generated file
+var sources = map[string]string{
+	"evertec": "CodeSourceEvertec",
+	"dict":    "CodeSourceDICT",
+	"spi":     "CodeSourceSPI",
+}
+
+// generate returns the formatted Go source of the catalog
+func generate(data []byte) ([]byte, error) {
+	var c catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("decode catalog: %w", err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by internal/codegen/businesscodes from business_codes.json; DO NOT EDIT.\n\n")
+	b.WriteString("package client\n\n")
+	b.WriteString("// Business code sentinels. Each matches, with errors.Is, any typed API error whose\n")
+	b.WriteString("// code is one of the Evertec, DICT or SPI codes listed for it.\n")
+	b.WriteString("var (\n")
+	seen := make(map[string]bool)
+	for _, s := range c.Sentinels {
+		if !strings.HasPrefix(s.Name, "Err") || s.PT == "" || s.EN == "" || len(s.Codes) == 0 {
+			return nil, fmt.Errorf("sentinel %q: name must start with Err and have pt, en and codes", s.Name)
+		}
+		codes := make([]string, len(s.Codes))
+		for i, c := range s.Codes {
+			codes[i] = c.Code
+		}
+		fmt.Fprintf(&b, "\t// %s matches codes %s\n", s.Name, strings.Join(codes, ", "))
+		fmt.Fprintf(&b, "\t%s = &BusinessCode{Name: %q, PT: %q, EN: %q, Retryable: %t}\n", s.Name, snakeCase(strings.TrimPrefix(s.Name, "Err")), s.PT, s.EN, s.Retryable)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// businessCodes maps the codes returned by the API to their sentinel\n")
+	b.WriteString("var businessCodes = map[string]businessCodeEntry{\n")
+	for _, s := range c.Sentinels {
+		for _, c := range s.Codes {
+			source, ok := sources[c.Source]
+			if !ok {
+				return nil, fmt.Errorf("code %q: unknown source %q", c.Code, c.Source)
+			}
+			if seen[c.Code] {
+				return nil, fmt.Errorf("code %q: listed twice", c.Code)
+			}
+			seen[c.Code] = true
+			fmt.Fprintf(&b, "\t%q: {%s, %s},\n", c.Code, s.Name, source)
+		}
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// snakeCase converts a Go identifier such as PixKeyOwnedByOtherPSP to pix_key_owned_by_other_psp
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
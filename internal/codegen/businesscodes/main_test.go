@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when business_codes_gen.go was not regenerated after
+// editing business_codes.json (run go generate ./client)
+func TestGeneratedUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../../client/business_codes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../../client/business_codes_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("client/business_codes_gen.go is out of date; run go generate ./client")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"PixKeyOwnedByOtherPSP":  "pix_key_owned_by_other_psp",
+		"DailyLimitExceeded":     "daily_limit_exceeded",
+		"InvalidBarcode":         "invalid_barcode",
+		"SPITimeoutOnDICTLookup": "spi_timeout_on_dict_lookup",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
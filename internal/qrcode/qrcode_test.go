@@ -0,0 +1,91 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" in version 1-M, from the ISO/IEC 18004 worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("ECC = %v; want %v", got, want)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	tests := []struct {
+		version int
+		level   Level
+		want    int
+	}{
+		{1, Medium, 16},
+		{5, Quartile, 62},
+		{10, High, 122},
+		{40, Low, 2956},
+	}
+
+	for _, tt := range tests {
+		if got := numDataCodewords(tt.version, tt.level); got != tt.want {
+			t.Errorf("numDataCodewords(%d, %d) = %d; want %d", tt.version, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeFunctionPatterns(t *testing.T) {
+	for _, n := range []int{10, 150, 400} {
+		c, err := Encode(bytes.Repeat([]byte("A"), n), Medium)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Size != c.version*4+17 {
+			t.Errorf("size %d for version %d", c.Size, c.version)
+		}
+		if !c.Dark(8, c.Size-8) {
+			t.Error("dark module is light")
+		}
+		for _, corner := range [][2]int{{0, 0}, {c.Size - 7, 0}, {0, c.Size - 7}} {
+			if !c.Dark(corner[0], corner[1]) || c.Dark(corner[0]+1, corner[1]+1) || !c.Dark(corner[0]+3, corner[1]+3) {
+				t.Errorf("no finder pattern at %v", corner)
+			}
+		}
+
+		// Both copies of the format information must agree and decode to level M
+		var first, second int
+		for i := 0; i <= 5; i++ {
+			first |= boolBit(c.Dark(8, i)) << i
+		}
+		first |= boolBit(c.Dark(8, 7))<<6 | boolBit(c.Dark(8, 8))<<7 | boolBit(c.Dark(7, 8))<<8
+		for i := 9; i < 15; i++ {
+			first |= boolBit(c.Dark(14-i, 8)) << i
+		}
+		for i := range 8 {
+			second |= boolBit(c.Dark(c.Size-1-i, 8)) << i
+		}
+		for i := 8; i < 15; i++ {
+			second |= boolBit(c.Dark(8, c.Size-15+i)) << i
+		}
+		if first != second {
+			t.Errorf("format copies differ: %015b and %015b", first, second)
+		}
+		if ecl := (first ^ 0x5412) >> 13; ecl != Medium.formatBits() {
+			t.Errorf("format level bits = %d", ecl)
+		}
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(make([]byte, 2400), High); !errors.Is(err, ErrDataTooLong) {
+		t.Errorf("Encode = %v; want ErrDataTooLong", err)
+	}
+}
+
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+// Package route reduces concrete request paths to low-cardinality route templates.
+package route
+
+import (
+	"strings"
+)
+
+// Param is the placeholder used for dynamic path segments in route templates
+const Param = "{id}"
+
+// Template reduces a concrete request path to a low-cardinality route template
+// suitable for metric and span labels, e.g. "/accounts/123/balance?x=1" becomes
+// "/accounts/{id}/balance". Segments holding identifiers (numbers, documents,
+// UUIDs, EndToEnd IDs, e-mails and phone keys) are replaced by a placeholder.
+func Template(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isParam(segment) {
+			segments[i] = Param
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isParam reports whether a path segment looks like an identifier rather than a fixed route name
+func isParam(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	if strings.ContainsAny(segment, "@+") {
+		return true
+	}
+
+	digits := 0
+	for _, r := range segment {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	// Short version markers such as "v2" stay literal
+	return digits >= 3 || digits == len(segment)
+}
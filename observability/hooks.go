@@ -0,0 +1,86 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a single outgoing HTTP attempt made by the SDK
+type RequestInfo struct {
+	// Method is the HTTP method
+	Method string
+	// Path is the concrete request path, including the query string
+	Path string
+	// Route is the low-cardinality route template (e.g. "/accounts/{id}/balance")
+	Route string
+	// Body is the serialized request body, if any
+	Body []byte
+	// Header holds the outgoing request headers; hooks may add headers
+	Header http.Header
+	// Attempt is the 1-based attempt number (greater than 1 for retries)
+	Attempt int
+	// Tenant is the tenant set with WithTenant, if any
+	Tenant string
+}
+
+// ResponseInfo describes the outcome of an HTTP attempt made by the SDK
+type ResponseInfo struct {
+	// Request is the attempt this response belongs to
+	Request *RequestInfo
+	// StatusCode is the HTTP status code (0 if no response was received)
+	StatusCode int
+	// Header holds the response headers (nil if no response was received)
+	Header http.Header
+	// Body is the raw response body
+	Body []byte
+	// Duration is the time spent on the attempt
+	Duration time.Duration
+	// Err is the transport or typed API error for this attempt, if any
+	Err error
+	// ErrorType is a low-cardinality classification of Err (e.g. "not_found", "transport")
+	ErrorType string
+}
+
+// Hook provides observability into SDK HTTP operations.
+// BeforeRequest is called once per attempt and may return a derived context,
+// which is used for the attempt and passed to AfterResponse. Every BeforeRequest
+// call is paired with exactly one AfterResponse call.
+type Hook interface {
+	// BeforeRequest is called before an HTTP attempt is sent
+	BeforeRequest(ctx context.Context, req *RequestInfo) context.Context
+
+	// AfterResponse is called after an HTTP attempt completes or fails
+	AfterResponse(ctx context.Context, resp *ResponseInfo)
+}
+
+// CircuitState is the state of a client circuit breaker
+type CircuitState string
+
+// Circuit breaker states
+const (
+	// CircuitClosed lets requests through and counts failures
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects requests until the cool-down elapses
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a few probe requests through to test recovery
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitStateChange describes a transition of the circuit breaker of an endpoint group
+type CircuitStateChange struct {
+	// Group is the endpoint group of the breaker (e.g. "pix")
+	Group string
+	// Tenant is the tenant of the breaker, empty for calls made without one
+	Tenant string
+	// From is the previous state
+	From CircuitState
+	// To is the new state
+	To CircuitState
+}
+
+// CircuitHook is implemented by hooks that observe circuit breaker transitions.
+// Hooks passed to the client that also implement it are notified on every change.
+type CircuitHook interface {
+	OnCircuitStateChange(ctx context.Context, change CircuitStateChange)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"sync"
 )
 
 // IdempotencyKeyHeader is the HTTP header used for idempotency keys
@@ -106,7 +107,9 @@ func (p *IdempotencyProvider) GetOrGenerate(ctx context.Context) (context.Contex
 // IdempotencyHook implements the Hook interface for idempotency key injection
 type IdempotencyHook struct {
 	provider *IdempotencyProvider
-	key      string
+
+	mu  sync.Mutex
+	key string
 }
 
 // NewIdempotencyHook creates a new IdempotencyHook
@@ -116,23 +119,28 @@ func NewIdempotencyHook(provider *IdempotencyProvider) *IdempotencyHook {
 	}
 }
 
-// BeforeRequest injects idempotency key into context
-func (h *IdempotencyHook) BeforeRequest(ctx context.Context, method, endpoint string, body []byte) context.Context {
+// BeforeRequest injects idempotency key into context.
+// An existing key is reused, so retries of the same request keep their key.
+func (h *IdempotencyHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
 	// Only add idempotency key for mutating operations
-	if method == "POST" || method == "PUT" || method == "PATCH" || method == "DELETE" {
+	if req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH" || req.Method == "DELETE" {
 		newCtx, key, _ := h.provider.GetOrGenerate(ctx)
+		h.mu.Lock()
 		h.key = key
+		h.mu.Unlock()
 		return newCtx
 	}
 	return ctx
 }
 
 // AfterResponse does nothing for idempotency
-func (h *IdempotencyHook) AfterResponse(ctx context.Context, statusCode int, body []byte, err error) {
+func (h *IdempotencyHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
 	// No-op
 }
 
 // GetKey returns the key used in the last request
 func (h *IdempotencyHook) GetKey() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	return h.key
 }
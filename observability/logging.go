@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"os"
 	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
 )
 
 // Logger provides structured logging for SDK operations using slog
@@ -136,10 +138,7 @@ func (l *Logger) LogRateLimit(ctx context.Context, method, endpoint string, retr
 
 // LoggerHook implements the Hook interface for logging
 type LoggerHook struct {
-	logger    *Logger
-	startTime time.Time
-	method    string
-	endpoint  string
+	logger *Logger
 }
 
 // NewLoggerHook creates a new LoggerHook
@@ -149,17 +148,21 @@ func NewLoggerHook(logger *Logger) *LoggerHook {
 	}
 }
 
-// BeforeRequest logs the request
-func (h *LoggerHook) BeforeRequest(ctx context.Context, method, endpoint string, body []byte) context.Context {
-	h.startTime = time.Now()
-	h.method = method
-	h.endpoint = endpoint
-	h.logger.LogRequest(ctx, method, endpoint, body)
+// BeforeRequest logs the request, masking CPFs and CNPJs in its path
+func (h *LoggerHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
+	h.loggerFor(req).LogRequest(ctx, req.Method, types.RedactDocuments(req.Path), req.Body)
 	return ctx
 }
 
-// AfterResponse logs the response
-func (h *LoggerHook) AfterResponse(ctx context.Context, statusCode int, body []byte, err error) {
-	duration := time.Since(h.startTime)
-	h.logger.LogResponse(ctx, h.method, h.endpoint, statusCode, duration, err)
+// AfterResponse logs the response, masking CPFs and CNPJs in its path
+func (h *LoggerHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
+	h.loggerFor(resp.Request).LogResponse(ctx, resp.Request.Method, types.RedactDocuments(resp.Request.Path), resp.StatusCode, resp.Duration, resp.Err)
+}
+
+// loggerFor returns the hook logger, labeled with the tenant of the request if any
+func (h *LoggerHook) loggerFor(req *RequestInfo) *Logger {
+	if req.Tenant != "" {
+		return h.logger.With("tenant", req.Tenant)
+	}
+	return h.logger
 }
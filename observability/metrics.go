@@ -2,6 +2,7 @@ package observability
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -27,8 +28,18 @@ type Metrics struct {
 	// Error metrics
 	errorCounter metric.Int64Counter
 
+	// Retry metrics
+	retryCounter metric.Int64Counter
+
 	// Active requests gauge
 	activeRequests metric.Int64UpDownCounter
+
+	// Circuit breaker metrics
+	circuitTransitions metric.Int64Counter
+	circuitState       metric.Int64Gauge
+
+	// Rate limiter metrics
+	rateLimitWait metric.Float64Histogram
 }
 
 // NewMetrics creates a new Metrics instance with default OpenTelemetry provider
@@ -95,6 +106,16 @@ func NewMetricsWithProvider(mp metric.MeterProvider) (*Metrics, error) {
 		return nil, err
 	}
 
+	// Retry counter
+	m.retryCounter, err = m.meter.Int64Counter(
+		"evertec.sdk.retries.total",
+		metric.WithDescription("Total number of request retries performed by the SDK"),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Active requests gauge
 	m.activeRequests, err = m.meter.Int64UpDownCounter(
 		"evertec.sdk.requests.active",
@@ -105,6 +126,37 @@ func NewMetricsWithProvider(mp metric.MeterProvider) (*Metrics, error) {
 		return nil, err
 	}
 
+	// Circuit breaker transition counter
+	m.circuitTransitions, err = m.meter.Int64Counter(
+		"evertec.sdk.circuit.transitions.total",
+		metric.WithDescription("Total number of circuit breaker state changes per endpoint group"),
+		metric.WithUnit("{transition}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Circuit breaker state gauge
+	m.circuitState, err = m.meter.Int64Gauge(
+		"evertec.sdk.circuit.state",
+		metric.WithDescription("Circuit breaker state per endpoint group (0 closed, 1 half-open, 2 open)"),
+		metric.WithUnit("{state}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rate limiter queue time histogram
+	m.rateLimitWait, err = m.meter.Float64Histogram(
+		"evertec.sdk.ratelimit.wait",
+		metric.WithDescription("Time requests spent waiting for client-side rate limits in milliseconds"),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(0, 1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -115,6 +167,7 @@ func (m *Metrics) RecordRequest(ctx context.Context, method, endpoint string, st
 		attribute.String("http.route", endpoint),
 		attribute.Int("http.status_code", statusCode),
 	}
+	attrs = withTenant(ctx, attrs)
 
 	m.requestCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
 	m.requestDuration.Record(ctx, float64(duration.Milliseconds()), metric.WithAttributes(attrs...))
@@ -134,26 +187,66 @@ func (m *Metrics) RecordError(ctx context.Context, method, endpoint, errorType s
 		attribute.String("http.route", endpoint),
 		attribute.String("error.type", errorType),
 	}
+	attrs = withTenant(ctx, attrs)
 	m.errorCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
 }
 
+// RecordRetry records a retry of a request, where attempt is the attempt about to be made
+func (m *Metrics) RecordRetry(ctx context.Context, method, endpoint string, attempt int) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.String("http.route", endpoint),
+		attribute.Int("http.retry_attempt", attempt),
+	}
+	attrs = withTenant(ctx, attrs)
+	m.retryCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
 // IncrementActiveRequests increments the active requests counter
 func (m *Metrics) IncrementActiveRequests(ctx context.Context) {
-	m.activeRequests.Add(ctx, 1)
+	m.activeRequests.Add(ctx, 1, metric.WithAttributes(withTenant(ctx, nil)...))
 }
 
 // DecrementActiveRequests decrements the active requests counter
 func (m *Metrics) DecrementActiveRequests(ctx context.Context) {
-	m.activeRequests.Add(ctx, -1)
+	m.activeRequests.Add(ctx, -1, metric.WithAttributes(withTenant(ctx, nil)...))
+}
+
+// RecordCircuitStateChange records a circuit breaker transition and the new state
+func (m *Metrics) RecordCircuitStateChange(ctx context.Context, change CircuitStateChange) {
+	attrs := []attribute.KeyValue{attribute.String("endpoint.group", change.Group)}
+	if change.Tenant != "" {
+		attrs = append(attrs, attribute.String(TenantAttribute, change.Tenant))
+	}
+	m.circuitTransitions.Add(ctx, 1, metric.WithAttributes(append(attrs,
+		attribute.String("circuit.from", string(change.From)),
+		attribute.String("circuit.to", string(change.To)),
+	)...))
+
+	var state int64
+	switch change.To {
+	case CircuitHalfOpen:
+		state = 1
+	case CircuitOpen:
+		state = 2
+	}
+	m.circuitState.Record(ctx, state, metric.WithAttributes(attrs...))
+}
+
+// RecordRateLimitWait records the time a request attempt waited for client-side rate limits
+func (m *Metrics) RecordRateLimitWait(ctx context.Context, method, endpoint, group string, wait time.Duration) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.String("http.route", endpoint),
+		attribute.String("endpoint.group", group),
+	}
+	attrs = withTenant(ctx, attrs)
+	m.rateLimitWait.Record(ctx, float64(wait)/float64(time.Millisecond), metric.WithAttributes(attrs...))
 }
 
 // MetricsHook implements the Hook interface for metrics collection
 type MetricsHook struct {
-	metrics   *Metrics
-	startTime time.Time
-	method    string
-	endpoint  string
-	reqSize   int64
+	metrics *Metrics
 }
 
 // NewMetricsHook creates a new MetricsHook
@@ -163,37 +256,45 @@ func NewMetricsHook(metrics *Metrics) *MetricsHook {
 	}
 }
 
-// BeforeRequest records the start time and increments active requests
-func (h *MetricsHook) BeforeRequest(ctx context.Context, method, endpoint string, body []byte) context.Context {
-	h.startTime = time.Now()
-	h.method = method
-	h.endpoint = endpoint
-	h.reqSize = int64(len(body))
+// BeforeRequest increments active requests and records retries
+func (h *MetricsHook) BeforeRequest(ctx context.Context, req *RequestInfo) context.Context {
 	h.metrics.IncrementActiveRequests(ctx)
+	if req.Attempt > 1 {
+		h.metrics.RecordRetry(ctx, req.Method, req.Route, req.Attempt)
+	}
 	return ctx
 }
 
 // AfterResponse records request metrics and decrements active requests
-func (h *MetricsHook) AfterResponse(ctx context.Context, statusCode int, body []byte, err error) {
-	duration := time.Since(h.startTime)
-	respSize := int64(len(body))
+func (h *MetricsHook) AfterResponse(ctx context.Context, resp *ResponseInfo) {
+	req := resp.Request
+	h.metrics.RecordRequest(ctx, req.Method, req.Route, resp.StatusCode, resp.Duration, int64(len(req.Body)), int64(len(resp.Body)))
 
-	h.metrics.RecordRequest(ctx, h.method, h.endpoint, statusCode, duration, h.reqSize, respSize)
-
-	if err != nil {
-		h.metrics.RecordError(ctx, h.method, h.endpoint, errorType(err))
+	if resp.Err != nil {
+		errType := resp.ErrorType
+		if errType == "" {
+			errType = errorType(resp.Err)
+		}
+		h.metrics.RecordError(ctx, req.Method, req.Route, errType)
 	}
 
 	h.metrics.DecrementActiveRequests(ctx)
 }
 
+// OnCircuitStateChange records circuit breaker transitions
+func (h *MetricsHook) OnCircuitStateChange(ctx context.Context, change CircuitStateChange) {
+	h.metrics.RecordCircuitStateChange(ctx, change)
+}
+
 // errorType extracts the error type from an error
 func errorType(err error) string {
-	if err == nil {
+	switch {
+	case err == nil:
 		return ""
-	}
-	// Try to extract specific error types
-	switch err.(type) {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
 	default:
 		return "unknown"
 	}
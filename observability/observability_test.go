@@ -605,6 +605,66 @@ func TestRecordError(t *testing.T) {
 	}
 }
 
+func TestRecordRetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := NewMetricsWithProvider(mp)
+	if err != nil {
+		t.Fatalf("NewMetricsWithProvider() error = %v", err)
+	}
+
+	ctx := context.Background()
+	metrics.RecordRetry(ctx, "GET", "/accounts/{id}", 2)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "evertec.sdk.retries.total" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("evertec.sdk.retries.total was not recorded")
+	}
+}
+
+func TestRecordRateLimitWait(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := NewMetricsWithProvider(mp)
+	if err != nil {
+		t.Fatalf("NewMetricsWithProvider() error = %v", err)
+	}
+
+	ctx := context.Background()
+	metrics.RecordRateLimitWait(ctx, "GET", "/pix/keys/{id}/{id}", "dict", 250*time.Millisecond)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evertec.sdk.ratelimit.wait" {
+				continue
+			}
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Sum != 250 {
+				t.Errorf("evertec.sdk.ratelimit.wait = %+v; want one 250ms data point", m.Data)
+			}
+			return
+		}
+	}
+	t.Error("evertec.sdk.ratelimit.wait was not recorded")
+}
+
 func TestIncrementDecrementActiveRequests(t *testing.T) {
 	reader := sdkmetric.NewManualReader()
 	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
@@ -650,29 +710,29 @@ func TestMetricsHook(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	method := "POST"
-	endpoint := "/api/v1/transactions"
-	body := []byte(`{"amount": 100}`)
+	req := &RequestInfo{
+		Method:  "POST",
+		Path:    "/api/v1/transactions",
+		Route:   "/api/v1/transactions",
+		Body:    []byte(`{"amount": 100}`),
+		Attempt: 2,
+	}
 
 	// Test BeforeRequest
-	ctx = hook.BeforeRequest(ctx, method, endpoint, body)
+	ctx = hook.BeforeRequest(ctx, req)
 	if ctx == nil {
 		t.Error("BeforeRequest() returned nil context")
 	}
-	if hook.method != method {
-		t.Errorf("BeforeRequest() method = %v, want %v", hook.method, method)
-	}
-	if hook.endpoint != endpoint {
-		t.Errorf("BeforeRequest() endpoint = %v, want %v", hook.endpoint, endpoint)
-	}
-	if hook.reqSize != int64(len(body)) {
-		t.Errorf("BeforeRequest() reqSize = %v, want %v", hook.reqSize, len(body))
-	}
 
 	// Test AfterResponse
-	time.Sleep(10 * time.Millisecond) // Ensure some duration
-	respBody := []byte(`{"id": 123}`)
-	hook.AfterResponse(ctx, 201, respBody, nil)
+	hook.AfterResponse(ctx, &ResponseInfo{
+		Request:    req,
+		StatusCode: 404,
+		Body:       []byte(`{"message": "not found"}`),
+		Duration:   10 * time.Millisecond,
+		Err:        errors.New("not found"),
+		ErrorType:  "not_found",
+	})
 
 	// Verify metrics were recorded
 	var rm metricdata.ResourceMetrics
@@ -682,7 +742,24 @@ func TestMetricsHook(t *testing.T) {
 	}
 
 	if len(rm.ScopeMetrics) == 0 {
-		t.Error("No metrics were recorded by hook")
+		t.Fatal("No metrics were recorded by hook")
+	}
+
+	recorded := map[string]bool{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		recorded[m.Name] = true
+		if m.Name != "evertec.sdk.errors.total" {
+			continue
+		}
+		sum := m.Data.(metricdata.Sum[int64])
+		if v, _ := sum.DataPoints[0].Attributes.Value("error.type"); v.AsString() != "not_found" {
+			t.Errorf("error.type = %v, want not_found", v.AsString())
+		}
+	}
+	for _, name := range []string{"evertec.sdk.requests.total", "evertec.sdk.retries.total", "evertec.sdk.errors.total"} {
+		if !recorded[name] {
+			t.Errorf("%s was not recorded by hook", name)
+		}
 	}
 }
 
@@ -702,6 +779,11 @@ func TestErrorType(t *testing.T) {
 			err:      errors.New("test error"),
 			expected: "unknown",
 		},
+		{
+			name:     "deadline exceeded",
+			err:      context.DeadlineExceeded,
+			expected: "timeout",
+		},
 	}
 
 	for _, tt := range tests {
@@ -975,13 +1057,16 @@ func TestLoggerHook(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	method := "POST"
-	endpoint := "/api/v1/transactions"
-	reqBody := []byte(`{"amount": 100}`)
+	req := &RequestInfo{
+		Method:  "POST",
+		Path:    "/api/v1/transactions",
+		Body:    []byte(`{"amount": 100}`),
+		Attempt: 1,
+	}
 
 	// Test BeforeRequest
 	buf.Reset()
-	ctx = hook.BeforeRequest(ctx, method, endpoint, reqBody)
+	ctx = hook.BeforeRequest(ctx, req)
 	if ctx == nil {
 		t.Error("BeforeRequest() returned nil context")
 	}
@@ -991,12 +1076,24 @@ func TestLoggerHook(t *testing.T) {
 
 	// Test AfterResponse
 	buf.Reset()
-	time.Sleep(10 * time.Millisecond)
-	respBody := []byte(`{"id": 123}`)
-	hook.AfterResponse(ctx, 201, respBody, nil)
+	hook.AfterResponse(ctx, &ResponseInfo{
+		Request:    req,
+		StatusCode: 201,
+		Body:       []byte(`{"id": 123}`),
+		Duration:   10 * time.Millisecond,
+	})
 	if !strings.Contains(buf.String(), "response") {
 		t.Error("AfterResponse() did not log response")
 	}
+
+	// Requests made for a tenant are labeled with it
+	buf.Reset()
+	req.Tenant = "issuerA"
+	hook.BeforeRequest(ctx, req)
+	hook.AfterResponse(ctx, &ResponseInfo{Request: req, StatusCode: 201})
+	if got := strings.Count(buf.String(), `"tenant":"issuerA"`); got != 2 {
+		t.Errorf("logs = %q; want the tenant on both lines", buf.String())
+	}
 }
 
 // ===========================
@@ -1070,6 +1167,18 @@ func TestWithIdempotencyKey(t *testing.T) {
 	}
 }
 
+func TestWithTenant(t *testing.T) {
+	if _, ok := GetTenant(context.Background()); ok {
+		t.Error("GetTenant() found a tenant in an empty context")
+	}
+	if _, ok := GetTenant(WithTenant(context.Background(), "")); ok {
+		t.Error("GetTenant() found an empty tenant")
+	}
+	if tenant, ok := GetTenant(WithTenant(context.Background(), "issuerA")); !ok || tenant != "issuerA" {
+		t.Errorf("GetTenant() = %q, %v; want issuerA", tenant, ok)
+	}
+}
+
 func TestWithNewIdempotencyKey(t *testing.T) {
 	ctx := context.Background()
 
@@ -1251,7 +1360,7 @@ func TestIdempotencyHook(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newCtx := hook.BeforeRequest(ctx, tt.method, "/api/v1/test", nil)
+			newCtx := hook.BeforeRequest(ctx, &RequestInfo{Method: tt.method, Path: "/api/v1/test", Attempt: 1})
 			if newCtx == nil {
 				t.Fatal("BeforeRequest() returned nil context")
 			}
@@ -1277,7 +1386,7 @@ func TestIdempotencyHookAfterResponse(t *testing.T) {
 	ctx := context.Background()
 
 	// AfterResponse should be a no-op
-	hook.AfterResponse(ctx, 200, nil, nil)
+	hook.AfterResponse(ctx, &ResponseInfo{Request: &RequestInfo{Method: "POST"}, StatusCode: 200})
 	// No assertion needed, just verify it doesn't panic
 }
 
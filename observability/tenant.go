@@ -0,0 +1,32 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TenantAttribute is the attribute key carrying the tenant on spans and metrics
+const TenantAttribute = "evertec.tenant"
+
+// tenantContextKey is the context key for the tenant
+type tenantContextKey struct{}
+
+// WithTenant adds the tenant (issuer or program) a call is made for to the context
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// GetTenant extracts the tenant from the context, if present
+func GetTenant(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// withTenant appends the tenant attribute to attrs when the context has a tenant
+func withTenant(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if tenant, ok := GetTenant(ctx); ok {
+		return append(attrs, attribute.String(TenantAttribute, tenant))
+	}
+	return attrs
+}
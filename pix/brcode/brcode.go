@@ -0,0 +1,276 @@
+// Package brcode encodes and decodes PIX BR Codes (the EMV QR Code payloads behind
+// "copia e cola" strings) offline, following the BR Code manual of the Central Bank of Brazil.
+//
+// Use it to build static codes, or dynamic codes for a location URL returned by the API,
+// and to check codes pasted by users before calling ParseQRCode or DecodeQRCodeV3.
+package brcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// GUI is the globally unique identifier of the PIX arrangement in the merchant account template
+const GUI = "br.gov.bcb.pix"
+
+const (
+	// DefaultMerchantCategoryCode is used when Payload.MerchantCategoryCode is empty
+	DefaultMerchantCategoryCode = "0000"
+
+	// currencyBRL is the ISO 4217 numeric code of the Brazilian real
+	currencyBRL = "986"
+
+	// noTxID is the TxID of static codes without an identifier, and of all dynamic codes
+	noTxID = "***"
+
+	maxMerchantName = 25
+	maxMerchantCity = 15
+	maxTxID         = 25
+	maxFieldLength  = 99
+)
+
+var (
+	// ErrMalformed is returned for payloads that are not a valid EMV TLV sequence
+	ErrMalformed = errors.New("brcode: malformed payload")
+
+	// ErrInvalidCRC is returned when the CRC field does not match the payload
+	ErrInvalidCRC = errors.New("brcode: CRC mismatch")
+
+	// ErrNotPix is returned when the payload has no PIX merchant account template
+	ErrNotPix = errors.New("brcode: not a PIX BR Code")
+)
+
+// Payload is the content of a PIX BR Code. Static codes carry the receiver's Key;
+// dynamic codes carry the URL of the charge (the "location") instead.
+type Payload struct {
+	Key                  string      // Static codes: PIX key of the receiver
+	URL                  string      // Dynamic codes: charge location, with or without https://
+	AdditionalInfo       string      // Optional: free text shown to the payer (static codes only)
+	MerchantName         string      // Required: up to 25 characters
+	MerchantCity         string      // Required: up to 15 characters
+	PostalCode           string      // Optional: CEP, digits only
+	MerchantCategoryCode string      // Optional: defaults to DefaultMerchantCategoryCode
+	Amount               types.Money // Optional: zero lets the payer choose the amount
+	TxID                 string      // Optional: up to 25 alphanumeric characters (static codes only)
+	SingleUse            bool        // Optional: the code may be paid only once
+}
+
+// IsDynamic reports whether the payload points to a charge location
+func (p *Payload) IsDynamic() bool {
+	return p.URL != ""
+}
+
+// Validate checks the payload against the BR Code rules
+func (p *Payload) Validate() error {
+	var errs types.ValidationErrors
+	add := func(field, code, message string) {
+		errs = append(errs, types.ValidationError{Code: code, Field: field, Message: message})
+	}
+
+	switch {
+	case p.Key == "" && p.URL == "":
+		add("key", types.ErrRequiredField, "key or url is required")
+	case p.Key != "" && p.URL != "":
+		add("url", types.ErrInvalidField, "key and url are mutually exclusive")
+	case p.Key != "":
+		if _, err := normalizeKey(p.Key); err != nil {
+			add("key", types.ErrPixKeyInvalid, "key is not a valid PIX key")
+		}
+		if p.TxID != "" && p.TxID != noTxID && (len(p.TxID) > maxTxID || !isAlphanumeric(p.TxID)) {
+			add("txId", types.ErrInvalidField, fmt.Sprintf("txId must be at most %d alphanumeric characters", maxTxID))
+		}
+	default:
+		if p.AdditionalInfo != "" {
+			add("additionalInfo", types.ErrInvalidField, "additionalInfo is not allowed in dynamic codes")
+		}
+		if p.TxID != "" && p.TxID != noTxID {
+			add("txId", types.ErrInvalidField, "txId is not allowed in dynamic codes")
+		}
+	}
+	if len(p.merchantAccount()) > maxFieldLength {
+		add("key", types.ErrInvalidField, fmt.Sprintf("key, url and additionalInfo must fit in %d characters", maxFieldLength-len(GUI)-12))
+	}
+
+	for _, f := range []struct {
+		field, value string
+		max          int
+	}{
+		{"merchantName", p.MerchantName, maxMerchantName},
+		{"merchantCity", p.MerchantCity, maxMerchantCity},
+	} {
+		value := toASCII(f.value)
+		switch {
+		case strings.TrimSpace(value) == "":
+			add(f.field, types.ErrRequiredField, f.field+" is required")
+		case len(value) > f.max:
+			add(f.field, types.ErrInvalidField, fmt.Sprintf("%s must be at most %d characters", f.field, f.max))
+		}
+	}
+	if p.PostalCode != "" && (len(p.PostalCode) != 8 || !isDigits(p.PostalCode)) {
+		add("postalCode", types.ErrInvalidField, "postalCode must have 8 digits")
+	}
+	if p.MerchantCategoryCode != "" && (len(p.MerchantCategoryCode) != 4 || !isDigits(p.MerchantCategoryCode)) {
+		add("merchantCategoryCode", types.ErrInvalidField, "merchantCategoryCode must have 4 digits")
+	}
+	if p.Amount.IsNegative() {
+		add("amount", types.ErrInvalidField, "amount must not be negative")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Encode validates the payload and returns the BR Code string, CRC included. Accented
+// letters in the merchant name and city are replaced by their unaccented forms.
+func (p *Payload) Encode() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	appendField(&b, IDPayloadFormatIndicator, "01")
+	if p.SingleUse {
+		appendField(&b, IDPointOfInitiationMethod, "12")
+	}
+	appendField(&b, IDMerchantAccountInformation, p.merchantAccount())
+	mcc := p.MerchantCategoryCode
+	if mcc == "" {
+		mcc = DefaultMerchantCategoryCode
+	}
+	appendField(&b, IDMerchantCategoryCode, mcc)
+	appendField(&b, IDTransactionCurrency, currencyBRL)
+	if p.Amount.IsPositive() {
+		appendField(&b, IDTransactionAmount, p.Amount.Decimal())
+	}
+	appendField(&b, IDCountryCode, "BR")
+	appendField(&b, IDMerchantName, toASCII(p.MerchantName))
+	appendField(&b, IDMerchantCity, toASCII(p.MerchantCity))
+	if p.PostalCode != "" {
+		appendField(&b, IDPostalCode, p.PostalCode)
+	}
+
+	var additional strings.Builder
+	txID := p.TxID
+	if txID == "" || p.IsDynamic() {
+		txID = noTxID
+	}
+	appendField(&additional, "05", txID)
+	appendField(&b, IDAdditionalDataFieldTemplate, additional.String())
+
+	b.WriteString(IDCRC + "04")
+	return b.String() + CRC16(b.String()), nil
+}
+
+// merchantAccount returns the value of the merchant account information template
+func (p *Payload) merchantAccount() string {
+	var b strings.Builder
+	appendField(&b, "00", GUI)
+	if p.IsDynamic() {
+		appendField(&b, "25", strings.TrimPrefix(p.URL, "https://"))
+		return b.String()
+	}
+	key, err := normalizeKey(p.Key)
+	if err != nil {
+		key = p.Key
+	}
+	appendField(&b, "01", key)
+	if p.AdditionalInfo != "" {
+		appendField(&b, "02", toASCII(p.AdditionalInfo))
+	}
+	return b.String()
+}
+
+// Decode verifies the CRC of a BR Code and extracts its PIX payload. Surrounding
+// whitespace, common in pasted codes, is ignored.
+func Decode(code string) (*Payload, error) {
+	code = strings.TrimSpace(code)
+	fields, err := Parse(code)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyCRC(code); err != nil {
+		return nil, err
+	}
+	if fields.Value(IDPayloadFormatIndicator) != "01" {
+		return nil, fmt.Errorf("%w: unsupported payload format indicator", ErrMalformed)
+	}
+
+	var account Fields
+	for _, f := range fields {
+		if isMerchantAccountTemplate(f.ID) && strings.EqualFold(f.Fields.Value("00"), GUI) {
+			account = f.Fields
+			break
+		}
+	}
+	if account == nil {
+		return nil, ErrNotPix
+	}
+
+	p := &Payload{
+		Key:                  account.Value("01"),
+		AdditionalInfo:       account.Value("02"),
+		MerchantName:         fields.Value(IDMerchantName),
+		MerchantCity:         fields.Value(IDMerchantCity),
+		PostalCode:           fields.Value(IDPostalCode),
+		MerchantCategoryCode: fields.Value(IDMerchantCategoryCode),
+		SingleUse:            fields.Value(IDPointOfInitiationMethod) == "12",
+	}
+	if url := account.Value("25"); url != "" {
+		p.URL = "https://" + url
+	}
+	if additional, ok := fields.Get(IDAdditionalDataFieldTemplate); ok {
+		p.TxID = additional.Fields.Value("05")
+	}
+	if amount := fields.Value(IDTransactionAmount); amount != "" {
+		if p.Amount, err = types.ParseMoney(amount); err != nil {
+			return nil, fmt.Errorf("%w: invalid amount %q", ErrMalformed, amount)
+		}
+	}
+	return p, nil
+}
+
+// normalizeKey returns the PIX key in the form stored in the DICT. Digits that are both a
+// valid CPF and a phone number are taken as a CPF; write phones as +55... to avoid that.
+func normalizeKey(key string) (string, error) {
+	k, err := types.ParsePixKey(key)
+	if errors.Is(err, types.ErrInvalidPixKey) {
+		return "", err
+	}
+	return k.Value, nil
+}
+
+// isAlphanumeric reports whether s is made only of ASCII letters and digits
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// accents maps accented Portuguese letters to their unaccented forms
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "í", "i", "ï", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ö", "o", "ú", "u", "ü", "u", "ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "Ê", "E", "È", "E", "Í", "I", "Ï", "I",
+	"Ó", "O", "Ô", "O", "Õ", "O", "Ö", "O", "Ú", "U", "Ü", "U", "Ç", "C", "Ñ", "N",
+)
+
+// toASCII removes accents from s and drops any remaining non-printable or non-ASCII character
+func toASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, accents.Replace(s))
+}
@@ -0,0 +1,151 @@
+package brcode
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
+
+// manualExample is the static BR Code example from the BCB BR Code manual
+const manualExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestEncodeStatic(t *testing.T) {
+	p := &Payload{
+		Key:          "123e4567-e12b-12d1-a456-426655440000",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}
+	code, err := p.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != manualExample {
+		t.Errorf("Encode =\n%s\nwant\n%s", code, manualExample)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Payload
+		want Payload
+	}{
+		{
+			name: "static with amount",
+			in: Payload{
+				Key: "529.982.247-25", AdditionalInfo: "Pedido 42", MerchantName: "Padaria São João",
+				MerchantCity: "São Paulo", PostalCode: "01310100", Amount: types.MustParseMoney("10.50"), TxID: "PEDIDO42",
+			},
+			want: Payload{
+				Key: "52998224725", AdditionalInfo: "Pedido 42", MerchantName: "Padaria Sao Joao",
+				MerchantCity: "Sao Paulo", PostalCode: "01310100", MerchantCategoryCode: "0000",
+				Amount: types.MustParseMoney("10.50"), TxID: "PEDIDO42",
+			},
+		},
+		{
+			name: "dynamic",
+			in: Payload{
+				URL: "https://pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25", MerchantName: "Loja",
+				MerchantCity: "Curitiba", MerchantCategoryCode: "5411", SingleUse: true,
+			},
+			want: Payload{
+				URL: "https://pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25", MerchantName: "Loja",
+				MerchantCity: "Curitiba", MerchantCategoryCode: "5411", TxID: "***", SingleUse: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := tt.in.Encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decode(" " + code + "\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("Decode = %+v; want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     Payload
+		field string
+	}{
+		{"no key", Payload{MerchantName: "A", MerchantCity: "B"}, "key"},
+		{"key and url", Payload{Key: "a@b.com", URL: "x.com/1", MerchantName: "A", MerchantCity: "B"}, "url"},
+		{"invalid key", Payload{Key: "12345", MerchantName: "A", MerchantCity: "B"}, "key"},
+		{"long name", Payload{Key: "a@b.com", MerchantName: strings.Repeat("a", 26), MerchantCity: "B"}, "merchantName"},
+		{"no city", Payload{Key: "a@b.com", MerchantName: "A"}, "merchantCity"},
+		{"txid", Payload{Key: "a@b.com", MerchantName: "A", MerchantCity: "B", TxID: "pedido-1"}, "txId"},
+		{"dynamic txid", Payload{URL: "x.com/1", MerchantName: "A", MerchantCity: "B", TxID: "abc"}, "txId"},
+		{"negative amount", Payload{Key: "a@b.com", MerchantName: "A", MerchantCity: "B", Amount: -1}, "amount"},
+	}
+
+	for _, tt := range tests {
+		var errs types.ValidationErrors
+		if err := tt.p.Validate(); !errors.As(err, &errs) || errs[0].Field != tt.field {
+			t.Errorf("%s: Validate = %v; want error on %s", tt.name, err, tt.field)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		want error
+	}{
+		{manualExample[:len(manualExample)-4] + "0000", ErrInvalidCRC},
+		{strings.Replace(manualExample, "Fulano", "Fulana", 1), ErrInvalidCRC},
+		{manualExample[:len(manualExample)-8], ErrMalformed},
+		{"0002010126", ErrMalformed},
+		{"000201" + "5802BR6304" + CRC16("0002015802BR6304"), ErrNotPix},
+	}
+
+	for _, tt := range tests {
+		if _, err := Decode(tt.code); !errors.Is(err, tt.want) {
+			t.Errorf("Decode(%q) = %v; want %v", tt.code, err, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	fields, err := Parse(manualExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, ok := fields.Get(IDMerchantAccountInformation)
+	if !ok || account.Name() != "Merchant Account Information" || account.Fields.Value("00") != GUI {
+		t.Errorf("merchant account = %+v", account)
+	}
+	if got := fields.Value(IDMerchantName); got != "Fulano de Tal" {
+		t.Errorf("merchant name = %q", got)
+	}
+	if crc, _ := fields.Get(IDCRC); crc.Value != "1D3D" || crc.Name() != "CRC" {
+		t.Errorf("crc = %+v", crc)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(manualExample, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := img.Bounds().Dx(); w < 250 || w > 300 {
+		t.Errorf("width = %d; want about 300", w)
+	}
+}
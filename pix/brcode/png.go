@@ -0,0 +1,35 @@
+package brcode
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/internal/qrcode"
+)
+
+// Image renders a BR Code as a QR Code image with error correction level M, scale pixels
+// per module and a 4-module quiet zone
+func Image(code string, scale int) (image.Image, error) {
+	qr, err := qrcode.Encode([]byte(code), qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Image(max(scale, 1)), nil
+}
+
+// PNG renders a BR Code as a PNG image about size pixels wide. The image is never smaller
+// than one pixel per module, so short sizes produce slightly larger images.
+func PNG(code string, size int) ([]byte, error) {
+	qr, err := qrcode.Encode([]byte(code), qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	scale := max(size/(qr.Size+8), 1)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, qr.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
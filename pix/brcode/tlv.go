@@ -0,0 +1,165 @@
+package brcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EMV data object IDs used by BR Code
+const (
+	IDPayloadFormatIndicator      = "00"
+	IDPointOfInitiationMethod     = "01"
+	IDMerchantAccountInformation  = "26"
+	IDMerchantCategoryCode        = "52"
+	IDTransactionCurrency         = "53"
+	IDTransactionAmount           = "54"
+	IDCountryCode                 = "58"
+	IDMerchantName                = "59"
+	IDMerchantCity                = "60"
+	IDPostalCode                  = "61"
+	IDAdditionalDataFieldTemplate = "62"
+	IDCRC                         = "63"
+)
+
+// fieldNames names the top-level EMV data objects
+var fieldNames = map[string]string{
+	IDPayloadFormatIndicator:      "Payload Format Indicator",
+	IDPointOfInitiationMethod:     "Point of Initiation Method",
+	IDMerchantCategoryCode:        "Merchant Category Code",
+	IDTransactionCurrency:         "Transaction Currency",
+	IDTransactionAmount:           "Transaction Amount",
+	IDCountryCode:                 "Country Code",
+	IDMerchantName:                "Merchant Name",
+	IDMerchantCity:                "Merchant City",
+	IDPostalCode:                  "Postal Code",
+	IDAdditionalDataFieldTemplate: "Additional Data Field Template",
+	IDCRC:                         "CRC",
+	"64":                          "Merchant Information - Language Template",
+}
+
+// Field is an EMV data object. Templates (IDs 26-51, 62, 64 and 80-99) hold nested fields.
+type Field struct {
+	ID     string
+	Value  string
+	Fields Fields
+}
+
+// Name returns the EMV name of a top-level field, or "" for unknown IDs
+func (f Field) Name() string {
+	if name, ok := fieldNames[f.ID]; ok {
+		return name
+	}
+	if isMerchantAccountTemplate(f.ID) {
+		return "Merchant Account Information"
+	}
+	if f.ID >= "80" && f.ID <= "99" {
+		return "Unreserved Template"
+	}
+	return ""
+}
+
+// Fields is a list of EMV data objects in payload order
+type Fields []Field
+
+// Get returns the first field with the given ID
+func (fs Fields) Get(id string) (Field, bool) {
+	for _, f := range fs {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Value returns the value of the first field with the given ID, or "" when absent
+func (fs Fields) Value(id string) string {
+	f, _ := fs.Get(id)
+	return f.Value
+}
+
+// Parse splits an EMV payload into its data objects, parsing templates recursively.
+// It does not check the CRC; use Decode or VerifyCRC for that. Lengths are counted in
+// bytes, since BR Codes are restricted to ASCII.
+func Parse(code string) (Fields, error) {
+	return parse(code, true)
+}
+
+// parse splits s into data objects, descending into templates when top is true
+func parse(s string, top bool) (Fields, error) {
+	var fields Fields
+	for pos := 0; pos < len(s); {
+		if len(s)-pos < 4 {
+			return nil, fmt.Errorf("%w: truncated field at position %d", ErrMalformed, pos)
+		}
+		id := s[pos : pos+2]
+		n, err := strconv.Atoi(s[pos+2 : pos+4])
+		if err != nil || !isDigits(id) || n < 0 {
+			return nil, fmt.Errorf("%w: invalid field header %q at position %d", ErrMalformed, s[pos:pos+4], pos)
+		}
+		pos += 4
+		if len(s)-pos < n {
+			return nil, fmt.Errorf("%w: field %s needs %d bytes, %d left", ErrMalformed, id, n, len(s)-pos)
+		}
+		f := Field{ID: id, Value: s[pos : pos+n]}
+		pos += n
+
+		if top && isTemplate(id) {
+			if f.Fields, err = parse(f.Value, false); err != nil {
+				return nil, fmt.Errorf("field %s: %w", id, err)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// isTemplate reports whether a top-level ID holds nested data objects
+func isTemplate(id string) bool {
+	return isMerchantAccountTemplate(id) || id == IDAdditionalDataFieldTemplate || id == "64" || (id >= "80" && id <= "99")
+}
+
+// isMerchantAccountTemplate reports whether id is a Merchant Account Information template
+func isMerchantAccountTemplate(id string) bool {
+	return id >= "26" && id <= "51"
+}
+
+// isDigits reports whether s is made only of ASCII digits
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// appendField appends an EMV data object with a two-digit length
+func appendField(b *strings.Builder, id, value string) {
+	fmt.Fprintf(b, "%s%02d%s", id, len(value), value)
+}
+
+// CRC16 returns the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF) of data as four
+// uppercase hex digits, as used in the BR Code CRC field
+func CRC16(data string) string {
+	crc := uint16(0xFFFF)
+	for i := range len(data) {
+		crc ^= uint16(data[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// VerifyCRC checks that code ends with a CRC field ("6304" and four hex digits) matching
+// the CRC16 of everything before the digits
+func VerifyCRC(code string) error {
+	if len(code) < 8 || code[len(code)-8:len(code)-4] != IDCRC+"04" {
+		return fmt.Errorf("%w: missing CRC field", ErrMalformed)
+	}
+	want := CRC16(code[:len(code)-4])
+	if got := code[len(code)-4:]; !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: got %s, want %s", ErrInvalidCRC, got, want)
+	}
+	return nil
+}
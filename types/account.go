@@ -29,18 +29,18 @@ type UserIdentificationImage struct {
 
 // CreateCreditEngineInfoRequest represents credit engine info request (API: CreateCreditEngineInfoRequest)
 type CreateCreditEngineInfoRequest struct {
-	ResidenceDuration      int32   `json:"residenceDuration"`      // Required (min: 0)
-	ResidenceType          string  `json:"residenceType"`          // Required (enum: Aluguel, Proprio, Outro)
-	RentAmount             float64 `json:"rentAmount"`             // Required (min: 0)
-	MaritalStatus          string  `json:"maritalStatus"`          // Required (enum: Solteiro(a), Casado(a), Viuvo(a))
-	AdditionalExpenses     string  `json:"additionalExpenses"`     // Required
-	ContractType           string  `json:"contractType"`           // Required (enum: CLT, PJ, Outro)
-	MinimumIncome          float64 `json:"minimumIncome"`          // Required (min: 0)
-	MaximumIncome          float64 `json:"maximumIncome"`          // Required (min: 0)
-	ProfessionID           int32   `json:"professionId"`           // Required
-	CurrentCompanyName     string  `json:"currentCompanyName"`     // Required
-	CurrentCompanyDuration int32   `json:"currentCompanyDuration"` // Required (min: 0)
-	JobTitle               string  `json:"jobTitle"`               // Required (length: 1-30)
+	ResidenceDuration      int32  `json:"residenceDuration"`      // Required (min: 0)
+	ResidenceType          string `json:"residenceType"`          // Required (enum: Aluguel, Proprio, Outro)
+	RentAmount             Money  `json:"rentAmount"`             // Required (min: 0)
+	MaritalStatus          string `json:"maritalStatus"`          // Required (enum: Solteiro(a), Casado(a), Viuvo(a))
+	AdditionalExpenses     string `json:"additionalExpenses"`     // Required
+	ContractType           string `json:"contractType"`           // Required (enum: CLT, PJ, Outro)
+	MinimumIncome          Money  `json:"minimumIncome"`          // Required (min: 0)
+	MaximumIncome          Money  `json:"maximumIncome"`          // Required (min: 0)
+	ProfessionID           int32  `json:"professionId"`           // Required
+	CurrentCompanyName     string `json:"currentCompanyName"`     // Required
+	CurrentCompanyDuration int32  `json:"currentCompanyDuration"` // Required (min: 0)
+	JobTitle               string `json:"jobTitle"`               // Required (length: 1-30)
 }
 
 // ProposalAccountRequest represents ProposalAccountRequest from API spec
@@ -150,7 +150,7 @@ type AccountDataResponse struct {
 	CompanyFoundationDate *string `json:"companyFoundationDate,omitempty"`
 
 	// Balance information
-	Balance *int64 `json:"balance,omitempty"` // Amount in cents
+	Balance *MoneyCents `json:"balance,omitempty"` // Amount in cents
 
 	// Additional info
 	MainAccountID *int64 `json:"mainAccountId,omitempty"`
@@ -167,11 +167,11 @@ type AccountListResponse struct {
 // BalanceResponse represents the account balance
 // GET /accounts/{accountId}/balance response from API spec
 type BalanceResponse struct {
-	Message   string    `json:"message"`
-	IDAccount int64     `json:"idAccount"`
-	Balance   int64     `json:"balance"`   // Amount in cents
-	DateTime  time.Time `json:"dateTime"`
-	DACode    int32     `json:"da_code"`
+	Message   string     `json:"message"`
+	IDAccount int64      `json:"idAccount"`
+	Balance   MoneyCents `json:"balance"` // Amount in cents
+	DateTime  time.Time  `json:"dateTime"`
+	DACode    int32      `json:"da_code"`
 }
 
 // StatementEntry represents a single transaction in the statement (EntriesDTO from API spec)
@@ -296,3 +296,172 @@ type CreateCompanyAccountRequest struct {
 	// Password
 	Password *string `json:"password,omitempty"`
 }
+
+// maxAddressNumberLength is the longest street number accepted by the API
+const maxAddressNumberLength = 5
+
+// Validate checks the street, number and postal code
+func (r *AddressRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("streetAvenue", r.StreetAvenue)
+	if f.required("number", r.Number) {
+		f.maxLength("number", &r.Number, maxAddressNumberLength)
+	}
+	f.required("postalCode", r.PostalCode)
+	return f.err()
+}
+
+// Validate checks the token identifier and code
+func (r *TokenValidationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("tokenId", r.TokenID)
+	f.required("tokenCode", r.TokenCode)
+	return f.err()
+}
+
+// Validate checks the applicant, contact data, dates and the optional nested requests
+func (r *ProposalAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.document("personalDocument", Document(r.PersonalDocument))
+	f.required("personalName", r.PersonalName)
+	f.requiredID("productID", int64(r.ProductID))
+	f.date("birthDate", r.BirthDate)
+	f.required("motherName", r.MotherName)
+	f.requiredID("localAreaCodeCellPhone", int64(r.LocalAreaCodeCellPhone))
+	f.requiredID("cellPhoneNumber", int64(r.CellPhoneNumber))
+	f.required("personalEmail", r.PersonalEmail)
+	f.requiredID("branchId", int64(r.BranchID))
+	f.maxLength("identityDocument", r.IdentityDocument, 20)
+	f.nested("address", r.Address.Validate())
+	f.nested("cellPhoneToken", r.CellPhoneToken.Validate())
+	f.nested("emailToken", r.EmailToken.Validate())
+	f.nested("creditEngineInfo", r.CreditEngineInfo.Validate())
+	f.nested("creditLimit", r.CreditLimit.Validate())
+	return f.err()
+}
+
+// Validate checks the residence, income and employment data
+func (r *CreateCreditEngineInfoRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	nonNegative(&f, "residenceDuration", r.ResidenceDuration)
+	oneOf(&f, "residenceType", r.ResidenceType, "Aluguel", "Proprio", "Outro")
+	nonNegative(&f, "rentAmount", r.RentAmount)
+	oneOf(&f, "maritalStatus", r.MaritalStatus, "Solteiro(a)", "Casado(a)", "Viuvo(a)")
+	f.required("additionalExpenses", r.AdditionalExpenses)
+	oneOf(&f, "contractType", r.ContractType, "CLT", "PJ", "Outro")
+	nonNegative(&f, "minimumIncome", r.MinimumIncome)
+	nonNegative(&f, "maximumIncome", r.MaximumIncome)
+	if r.MaximumIncome < r.MinimumIncome {
+		f.add("maximumIncome", ErrInvalidField, "maximumIncome must not be less than minimumIncome")
+	}
+	f.requiredID("professionId", int64(r.ProfessionID))
+	f.required("currentCompanyName", r.CurrentCompanyName)
+	nonNegative(&f, "currentCompanyDuration", r.CurrentCompanyDuration)
+	if f.required("jobTitle", r.JobTitle) {
+		f.maxLength("jobTitle", &r.JobTitle, 30)
+	}
+	return f.err()
+}
+
+// Validate checks the company, its representative and the optional dates and address
+func (r *CreateCompanyAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("companyName", r.CompanyName)
+	companyDocument := Document(r.CompanyDocument)
+	f.document("companyDocument", companyDocument)
+	if companyDocument.Valid() && companyDocument.Kind() != DocumentTypeCNPJ {
+		f.add("companyDocument", ErrDocumentInvalid, "companyDocument must be a CNPJ")
+	}
+	f.optionalDate("companyFoundationDate", r.CompanyFoundationDate)
+	f.required("representativeName", r.RepresentativeName)
+	representativeDocument := Document(r.RepresentativeDocument)
+	f.document("representativeDocument", representativeDocument)
+	if representativeDocument.Valid() && representativeDocument.Kind() != DocumentTypeCPF {
+		f.add("representativeDocument", ErrDocumentInvalid, "representativeDocument must be a CPF")
+	}
+	f.required("representativeEmail", r.RepresentativeEmail)
+	f.required("representativePhone", r.RepresentativePhone)
+	f.optionalDate("representativeBirthDate", r.RepresentativeBirthDate)
+	f.nested("address", r.Address.Validate())
+	return f.err()
+}
+
+// Validate checks the account and the optional birth date and tokens
+func (r *UpdateAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.optionalDate("birthDate", r.BirthDate)
+	f.nested("cellPhoneToken", r.CellPhoneToken.Validate())
+	f.nested("emailToken", r.EmailToken.Validate())
+	return f.err()
+}
+
+// Validate checks the new name
+func (r *UpdateAccountNameRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("name", r.Name)
+	return f.err()
+}
+
+// Validate checks both passwords
+func (r *ChangePasswordRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("oldPassword", r.OldPassword)
+	f.required("newPassword", r.NewPassword)
+	return f.err()
+}
+
+// Validate checks the sub-account
+func (r *LinkAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("subAccountId", r.SubAccountID)
+	return f.err()
+}
+
+// Validate checks the main account and sub-account
+func (r *UnlinkAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("mainAccountId", r.MainAccountID)
+	f.requiredID("subAccountId", r.SubAccountID)
+	return f.err()
+}
+
+// Validate checks the document
+func (r *VerifyAccountExistsRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.document("document", Document(r.Document))
+	return f.err()
+}
@@ -4,8 +4,8 @@ import "time"
 
 // Asset represents an asset used in a transaction
 type Asset struct {
-	Amount    int64     `json:"amount"` // Amount in cents
-	AssetType AssetType `json:"asset_type"`
+	Amount    MoneyCents `json:"amount"` // Amount in cents
+	AssetType AssetType  `json:"asset_type"`
 }
 
 // SummaryPurchaseRequest represents a simplified card purchase request (Autorizador API)
@@ -13,7 +13,7 @@ type SummaryPurchaseRequest struct {
 	AccountID       string            `json:"account_id"`
 	AuthorizationID string            `json:"authorization_id"`
 	Status          TransactionStatus `json:"status"`       // APPROVED or REJECTED
-	TotalAmount     int64             `json:"total_amount"` // Amount in cents
+	TotalAmount     MoneyCents        `json:"total_amount"` // Amount in cents
 	PaymentType     PaymentType       `json:"payment_type"`
 	Assets          []Asset           `json:"assets"`
 
@@ -38,7 +38,7 @@ type ChargebackRequest struct {
 	OriginalAuthorizationID string         `json:"original_authorization_id"`
 	ChargebackID            string         `json:"chargeback_id"`
 	ChargebackMode          ChargebackMode `json:"chargeback_mode"`             // "total" or "partial"
-	ChargebackAmount        *int64         `json:"chargeback_amount,omitempty"` // Amount in cents (for partial)
+	ChargebackAmount        *MoneyCents    `json:"chargeback_amount,omitempty"` // Amount in cents (for partial)
 	ChargebackReason        string         `json:"chargeback_reason"`
 }
 
@@ -55,3 +55,60 @@ type AuthorizationResponse struct {
 	TransactionID      *int64  `json:"transactionId,omitempty"`
 	AuthenticationCode *string `json:"authenticationCode,omitempty"`
 }
+
+// Validate checks the account, authorization, status, amount and payment type
+func (r *SummaryPurchaseRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("account_id", r.AccountID)
+	f.required("authorization_id", r.AuthorizationID)
+	oneOf(&f, "status", r.Status, TransactionStatusApproved, TransactionStatusRejected)
+	positive(&f, "total_amount", r.TotalAmount)
+	oneOf(&f, "payment_type", r.PaymentType,
+		PaymentTypeDebitCard, PaymentTypeCreditCard, PaymentTypePix, PaymentTypeBankSlip, PaymentTypeBalance)
+	return f.err()
+}
+
+// Validate checks the account and the original and new authorizations
+func (r *CancelPurchaseRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("account_id", r.AccountID)
+	f.required("original_authorization_id", r.OriginalAuthorizationID)
+	f.required("authorization_id", r.AuthorizationID)
+	return f.err()
+}
+
+// Validate checks the account, authorization, mode, reason and, for partial chargebacks, the amount
+func (r *ChargebackRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("account_id", r.AccountID)
+	f.required("original_authorization_id", r.OriginalAuthorizationID)
+	f.required("chargeback_id", r.ChargebackID)
+	oneOf(&f, "chargeback_mode", r.ChargebackMode, ChargebackModeTotal, ChargebackModePartial)
+	if r.ChargebackMode == ChargebackModePartial && r.ChargebackAmount == nil {
+		f.add("chargeback_amount", ErrRequiredField, "chargeback_amount is required for partial chargebacks")
+	}
+	optionalPositive(&f, "chargeback_amount", r.ChargebackAmount)
+	f.required("chargeback_reason", r.ChargebackReason)
+	return f.err()
+}
+
+// Validate checks the account and the original and new chargebacks
+func (r *CancelChargebackRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("account_id", r.AccountID)
+	f.required("original_chargeback_id", r.OriginalChargebackID)
+	f.required("chargeback_id", r.ChargebackID)
+	return f.err()
+}
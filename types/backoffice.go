@@ -47,20 +47,20 @@ type ProposalProcessingRequest = UpdateProposalProcessingRequest
 
 // CreateAccountCreditLimitPostPaidRequest represents post-paid credit limit request (API: CreateAccountCreditLimitPostPaidRequest)
 type CreateAccountCreditLimitPostPaidRequest struct {
-	LimitAmount         float64 `json:"limitAmount"`         // Required (min: 0)
-	WithdrawLimitAmount float64 `json:"withdrawLimitAmount"` // Required (min: 0)
-	PaymentDue          int32   `json:"paymentDue"`          // Required (range: 1-28)
+	LimitAmount         Money `json:"limitAmount"`         // Required (min: 0)
+	WithdrawLimitAmount Money `json:"withdrawLimitAmount"` // Required (min: 0)
+	PaymentDue          int32 `json:"paymentDue"`          // Required (range: 1-28)
 }
 
 // ProposalLimitDto represents proposal limit configuration (API: ProposalLimitDto)
 type ProposalLimitDto struct {
-	EnvironmentType       string   `json:"environmentType"`                 // Required (pattern: ^[IES]$)
-	TransactionCode       int32    `json:"transactionCode"`                 // Required (min: 0)
-	DayLimit              *float64 `json:"dayLimit,omitempty"`              // Optional (min: 0)
-	DayTransactionLimit   *float64 `json:"dayTransactionLimit,omitempty"`   // Optional (min: 0)
-	NightLimit            *float64 `json:"nightLimit,omitempty"`            // Optional (min: 0)
-	NightTransactionLimit *float64 `json:"nightTransactionLimit,omitempty"` // Optional (min: 0)
-	TransactionAmount     *int32   `json:"transactionAmount,omitempty"`     // Optional (min: 0)
+	EnvironmentType       string `json:"environmentType"`                 // Required (pattern: ^[IES]$)
+	TransactionCode       int32  `json:"transactionCode"`                 // Required (min: 0)
+	DayLimit              *Money `json:"dayLimit,omitempty"`              // Optional (min: 0)
+	DayTransactionLimit   *Money `json:"dayTransactionLimit,omitempty"`   // Optional (min: 0)
+	NightLimit            *Money `json:"nightLimit,omitempty"`            // Optional (min: 0)
+	NightTransactionLimit *Money `json:"nightTransactionLimit,omitempty"` // Optional (min: 0)
+	TransactionAmount     *int32 `json:"transactionAmount,omitempty"`     // Optional (min: 0)
 }
 
 // CreateMobileAccountRequest represents mobile account creation request
@@ -127,20 +127,20 @@ type SyncProcessorResponse struct {
 
 // PixScanConfigurationResponse represents PIX scan configuration
 type PixScanConfigurationResponse struct {
-	Enabled           bool    `json:"enabled"`
-	ScanFrequency     *int    `json:"scanFrequency,omitempty"`  // in minutes
-	AlertThreshold    *int64  `json:"alertThreshold,omitempty"` // amount in cents
-	AutoBlockEnabled  *bool   `json:"autoBlockEnabled,omitempty"`
-	NotificationEmail *string `json:"notificationEmail,omitempty"`
+	Enabled           bool        `json:"enabled"`
+	ScanFrequency     *int        `json:"scanFrequency,omitempty"`  // in minutes
+	AlertThreshold    *MoneyCents `json:"alertThreshold,omitempty"` // amount in cents
+	AutoBlockEnabled  *bool       `json:"autoBlockEnabled,omitempty"`
+	NotificationEmail *string     `json:"notificationEmail,omitempty"`
 }
 
 // UpdatePixScanConfigurationRequest represents PIX scan config update request
 type UpdatePixScanConfigurationRequest struct {
-	Enabled           *bool   `json:"enabled,omitempty"`
-	ScanFrequency     *int    `json:"scanFrequency,omitempty"`
-	AlertThreshold    *int64  `json:"alertThreshold,omitempty"`
-	AutoBlockEnabled  *bool   `json:"autoBlockEnabled,omitempty"`
-	NotificationEmail *string `json:"notificationEmail,omitempty"`
+	Enabled           *bool       `json:"enabled,omitempty"`
+	ScanFrequency     *int        `json:"scanFrequency,omitempty"`
+	AlertThreshold    *MoneyCents `json:"alertThreshold,omitempty"`
+	AutoBlockEnabled  *bool       `json:"autoBlockEnabled,omitempty"`
+	NotificationEmail *string     `json:"notificationEmail,omitempty"`
 }
 
 // HCE Devices
@@ -192,11 +192,11 @@ func (p *ListHceDevicesParams) QueryString() string {
 
 // DailyStatementResponse represents daily statement entry
 type DailyStatementResponse struct {
-	Date         string `json:"date"`
-	AccountCount int    `json:"accountCount"`
-	TotalBalance int64  `json:"totalBalance"` // in cents
-	TotalCredits int64  `json:"totalCredits"`
-	TotalDebits  int64  `json:"totalDebits"`
+	Date         string     `json:"date"`
+	AccountCount int        `json:"accountCount"`
+	TotalBalance MoneyCents `json:"totalBalance"` // in cents
+	TotalCredits MoneyCents `json:"totalCredits"`
+	TotalDebits  MoneyCents `json:"totalDebits"`
 }
 
 // DailyStatementListResponse represents list of daily statements
@@ -209,11 +209,11 @@ type DailyStatementListResponse struct {
 
 // IssuerBalanceResponse represents issuer total balance
 type IssuerBalanceResponse struct {
-	TotalBalance    int64  `json:"totalBalance"` // in cents
-	AccountCount    int    `json:"accountCount"`
-	ActiveAccounts  int    `json:"activeAccounts"`
-	BlockedAccounts int    `json:"blockedAccounts"`
-	CalculatedAt    string `json:"calculatedAt"`
+	TotalBalance    MoneyCents `json:"totalBalance"` // in cents
+	AccountCount    int        `json:"accountCount"`
+	ActiveAccounts  int        `json:"activeAccounts"`
+	BlockedAccounts int        `json:"blockedAccounts"`
+	CalculatedAt    string     `json:"calculatedAt"`
 }
 
 // Generic Backoffice Response
@@ -224,3 +224,102 @@ type BackofficeGenericResponse struct {
 	Message *string     `json:"message,omitempty"`
 	Data    any `json:"data,omitempty"`
 }
+
+// Validate checks the limits and payment due day
+func (r *CreateAccountCreditLimitPostPaidRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	nonNegative(&f, "limitAmount", r.LimitAmount)
+	nonNegative(&f, "withdrawLimitAmount", r.WithdrawLimitAmount)
+	between(&f, "paymentDue", r.PaymentDue, 1, 28)
+	return f.err()
+}
+
+// Validate checks the proposal, status, maintenance user and optional credit limit
+func (r *UpdateProposalProcessingRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("proposalId", r.ProposalID)
+	oneOf(&f, "proposalStatus", r.ProposalStatus,
+		ProposalProcessingStatusWaitingAutomatic, ProposalProcessingStatusWaitingManual,
+		ProposalProcessingStatusManualApproved, ProposalProcessingStatusAutomaticApproved,
+		ProposalProcessingStatusManualReproved, ProposalProcessingStatusAutomaticReproved)
+	f.required("maintenanceUserId", r.MaintenanceUserID)
+	f.nested("creditLimit", r.CreditLimit.Validate())
+	return f.err()
+}
+
+// Validate checks the document and contact data
+func (r *CreateMobileAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.document("document", Document(r.Document))
+	f.required("name", r.Name)
+	f.required("email", r.Email)
+	f.required("phone", r.Phone)
+	return f.err()
+}
+
+// Validate checks the proposal and analysis type
+func (r *BiroAnalysisRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("proposalId", r.ProposalID)
+	f.required("analysisType", r.AnalysisType)
+	return f.err()
+}
+
+// Validate checks the analysis status
+func (r *UpdateBiroAnalysisRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	oneOf(&f, "status", r.Status,
+		BiroAnalysisStatusPending, BiroAnalysisStatusApproved, BiroAnalysisStatusRejected, BiroAnalysisStatusProcessing)
+	return f.err()
+}
+
+// Validate checks the account and processor account
+func (r *BindProcessorAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("processorAccountId", r.ProcessorAccountID)
+	return f.err()
+}
+
+// Validate checks the account, processor card and card type
+func (r *BindProcessorCardRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("processorCardId", r.ProcessorCardID)
+	f.required("cardType", r.CardType)
+	return f.err()
+}
+
+// Validate checks the optional scan frequency and alert threshold
+func (r *UpdatePixScanConfigurationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if r.ScanFrequency != nil && *r.ScanFrequency <= 0 {
+		f.add("scanFrequency", ErrInvalidField, "scanFrequency must be greater than zero")
+	}
+	optionalNonNegative(&f, "alertThreshold", r.AlertThreshold)
+	return f.err()
+}
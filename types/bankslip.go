@@ -14,24 +14,24 @@ const (
 
 // CreateBankSlipRequest represents a request to generate a bank slip (boleto)
 type CreateBankSlipRequest struct {
-	Amount        int64   `json:"amount"`  // Amount in cents
-	DueDate       string  `json:"dueDate"` // Format: YYYY-MM-DD
-	Description   *string `json:"description,omitempty"`
-	PayerName     *string `json:"payerName,omitempty"`
-	PayerDocument *string `json:"payerDocument,omitempty"`
+	Amount        MoneyCents `json:"amount"`  // Amount in cents
+	DueDate       string     `json:"dueDate"` // Format: YYYY-MM-DD
+	Description   *string    `json:"description,omitempty"`
+	PayerName     *string    `json:"payerName,omitempty"`
+	PayerDocument *Document  `json:"payerDocument,omitempty"`
 
 	// Fine and interest (optional)
-	FinePercentage     *float64 `json:"finePercentage,omitempty"`     // e.g., 2.0 for 2%
-	InterestPercentage *float64 `json:"interestPercentage,omitempty"` // Daily interest, e.g., 0.033 for 1% per month
-	DiscountAmount     *int64   `json:"discountAmount,omitempty"`     // Discount in cents
-	DiscountDueDate    *string  `json:"discountDueDate,omitempty"`    // Format: YYYY-MM-DD
+	FinePercentage     *float64    `json:"finePercentage,omitempty"`     // e.g., 2.0 for 2%
+	InterestPercentage *float64    `json:"interestPercentage,omitempty"` // Daily interest, e.g., 0.033 for 1% per month
+	DiscountAmount     *MoneyCents `json:"discountAmount,omitempty"`     // Discount in cents
+	DiscountDueDate    *string     `json:"discountDueDate,omitempty"`    // Format: YYYY-MM-DD
 }
 
 // BankSlipResponse represents a bank slip (boleto)
 type BankSlipResponse struct {
 	BankSlipID    int64          `json:"bankSlipId"`
 	AccountID     int64          `json:"accountId"`
-	Amount        int64          `json:"amount"` // Amount in cents
+	Amount        MoneyCents     `json:"amount"` // Amount in cents
 	Status        BankSlipStatus `json:"status"`
 	Barcode       string         `json:"barcode"`
 	DigitableLine string         `json:"digitableLine"`
@@ -41,14 +41,14 @@ type BankSlipResponse struct {
 	PayerDocument *string        `json:"payerDocument,omitempty"`
 
 	// Fine and interest
-	FinePercentage     *float64 `json:"finePercentage,omitempty"`
-	InterestPercentage *float64 `json:"interestPercentage,omitempty"`
-	DiscountAmount     *int64   `json:"discountAmount,omitempty"`
-	DiscountDueDate    *string  `json:"discountDueDate,omitempty"`
+	FinePercentage     *float64    `json:"finePercentage,omitempty"`
+	InterestPercentage *float64    `json:"interestPercentage,omitempty"`
+	DiscountAmount     *MoneyCents `json:"discountAmount,omitempty"`
+	DiscountDueDate    *string     `json:"discountDueDate,omitempty"`
 
 	// Payment info
-	PaidAmount *int64  `json:"paidAmount,omitempty"` // Amount in cents
-	PaidDate   *string `json:"paidDate,omitempty"`
+	PaidAmount *MoneyCents `json:"paidAmount,omitempty"` // Amount in cents
+	PaidDate   *string     `json:"paidDate,omitempty"`
 
 	// URLs
 	PDFUrl    *string `json:"pdfUrl,omitempty"`
@@ -111,7 +111,7 @@ type InterestRequestV2 struct {
 // CreateBankSlipRequestV2 represents the V2 bank slip creation request (API: CreateBankSlipRequestV2)
 type CreateBankSlipRequestV2 struct {
 	AccountID   int64              `json:"accountId"`   // Required
-	Amount      float64            `json:"amount"`      // Required
+	Amount      Money              `json:"amount"`      // Required
 	DueDate     string             `json:"dueDate"`     // Required (date format)
 	Description string             `json:"description"` // Required
 	Customer    CustomerRequestV2  `json:"customer"`    // Required
@@ -122,13 +122,27 @@ type CreateBankSlipRequestV2 struct {
 
 // CreateBankSlipResponseV2 represents the V2 bank slip creation response (API: CreateBankSlipResponseV2)
 type CreateBankSlipResponseV2 struct {
-	IDBankslip          int64   `json:"idBankslip"`
-	AccountID           int64   `json:"accountId"`
-	CreatedDate         string  `json:"createdDate"`
-	Amount              float64 `json:"amount"`
-	DueDate             string  `json:"dueDate"`
-	DigitableLine       string  `json:"digitableLine"`
-	Description         string  `json:"description"`
-	PaymentStatus       PaymentStatus  `json:"paymentStatus"`
-	BankslipDownloadURL *string `json:"bankslipDownloadUrl,omitempty"`
+	IDBankslip          int64         `json:"idBankslip"`
+	AccountID           int64         `json:"accountId"`
+	CreatedDate         string        `json:"createdDate"`
+	Amount              Money         `json:"amount"`
+	DueDate             string        `json:"dueDate"`
+	DigitableLine       string        `json:"digitableLine"`
+	Description         string        `json:"description"`
+	PaymentStatus       PaymentStatus `json:"paymentStatus"`
+	BankslipDownloadURL *string       `json:"bankslipDownloadUrl,omitempty"`
+}
+
+// Validate checks the amount, dates and optional payer document
+func (r *CreateBankSlipRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	positive(&f, "amount", r.Amount)
+	f.date("dueDate", r.DueDate)
+	f.optionalDocument("payerDocument", r.PayerDocument)
+	optionalPositive(&f, "discountAmount", r.DiscountAmount)
+	f.optionalDate("discountDueDate", r.DiscountDueDate)
+	return f.err()
 }
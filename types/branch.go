@@ -29,3 +29,14 @@ type BranchResponse struct {
 	CreatedAt   *string `json:"createdAt,omitempty"`
 	UpdatedAt   *string `json:"updatedAt,omitempty"`
 }
+
+// Validate checks the branch identifier and name
+func (r *BranchRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("branchId", r.BranchID)
+	f.required("branchName", r.BranchName)
+	return f.err()
+}
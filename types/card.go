@@ -51,26 +51,26 @@ type ReissueCardRequest struct {
 // CardConfigurationRequest represents the request to configure card settings
 type CardConfigurationRequest struct {
 	// Transaction type limits (amount in cents)
-	ContactlessLimit      *int64 `json:"contactlessLimit,omitempty"`
-	OnlinePurchaseEnabled *bool  `json:"onlinePurchaseEnabled,omitempty"`
-	ContactlessEnabled    *bool  `json:"contactlessEnabled,omitempty"`
-	InternationalEnabled  *bool  `json:"internationalEnabled,omitempty"`
-	WithdrawalEnabled     *bool  `json:"withdrawalEnabled,omitempty"`
+	ContactlessLimit      *MoneyCents `json:"contactlessLimit,omitempty"`
+	OnlinePurchaseEnabled *bool       `json:"onlinePurchaseEnabled,omitempty"`
+	ContactlessEnabled    *bool       `json:"contactlessEnabled,omitempty"`
+	InternationalEnabled  *bool       `json:"internationalEnabled,omitempty"`
+	WithdrawalEnabled     *bool       `json:"withdrawalEnabled,omitempty"`
 
 	// Daily limits (amount in cents)
-	DailyPurchaseLimit   *int64 `json:"dailyPurchaseLimit,omitempty"`
-	DailyWithdrawalLimit *int64 `json:"dailyWithdrawalLimit,omitempty"`
+	DailyPurchaseLimit   *MoneyCents `json:"dailyPurchaseLimit,omitempty"`
+	DailyWithdrawalLimit *MoneyCents `json:"dailyWithdrawalLimit,omitempty"`
 }
 
 // CardConfigurationResponse represents the card configuration settings
 type CardConfigurationResponse struct {
-	ContactlessLimit      *int64 `json:"contactlessLimit,omitempty"`
-	OnlinePurchaseEnabled *bool  `json:"onlinePurchaseEnabled,omitempty"`
-	ContactlessEnabled    *bool  `json:"contactlessEnabled,omitempty"`
-	InternationalEnabled  *bool  `json:"internationalEnabled,omitempty"`
-	WithdrawalEnabled     *bool  `json:"withdrawalEnabled,omitempty"`
-	DailyPurchaseLimit    *int64 `json:"dailyPurchaseLimit,omitempty"`
-	DailyWithdrawalLimit  *int64 `json:"dailyWithdrawalLimit,omitempty"`
+	ContactlessLimit      *MoneyCents `json:"contactlessLimit,omitempty"`
+	OnlinePurchaseEnabled *bool       `json:"onlinePurchaseEnabled,omitempty"`
+	ContactlessEnabled    *bool       `json:"contactlessEnabled,omitempty"`
+	InternationalEnabled  *bool       `json:"internationalEnabled,omitempty"`
+	WithdrawalEnabled     *bool       `json:"withdrawalEnabled,omitempty"`
+	DailyPurchaseLimit    *MoneyCents `json:"dailyPurchaseLimit,omitempty"`
+	DailyWithdrawalLimit  *MoneyCents `json:"dailyWithdrawalLimit,omitempty"`
 }
 
 // CardResponse represents CardVo from API spec
@@ -107,11 +107,11 @@ type CardResponse struct {
 
 // CardSecurityControlsVo represents card security controls from API spec
 type CardSecurityControlsVo struct {
-	ContactlessEnabled   *bool  `json:"contactlessEnabled,omitempty"`
-	OnlineEnabled        *bool  `json:"onlineEnabled,omitempty"`
-	InternationalEnabled *bool  `json:"internationalEnabled,omitempty"`
-	WithdrawalEnabled    *bool  `json:"withdrawalEnabled,omitempty"`
-	ContactlessLimit     *int64 `json:"contactlessLimit,omitempty"`
+	ContactlessEnabled   *bool       `json:"contactlessEnabled,omitempty"`
+	OnlineEnabled        *bool       `json:"onlineEnabled,omitempty"`
+	InternationalEnabled *bool       `json:"internationalEnabled,omitempty"`
+	WithdrawalEnabled    *bool       `json:"withdrawalEnabled,omitempty"`
+	ContactlessLimit     *MoneyCents `json:"contactlessLimit,omitempty"`
 }
 
 // CardListResponse represents a list of cards
@@ -131,9 +131,9 @@ type CardSearchRequest struct {
 
 // PostpaidCardRequest represents the request to create a post-paid card
 type PostpaidCardRequest struct {
-	AccountID   int64   `json:"accountId"`
-	CreditLimit int64   `json:"creditLimit"` // Amount in cents
-	Tag         *string `json:"tag,omitempty"`
+	AccountID   int64      `json:"accountId"`
+	CreditLimit MoneyCents `json:"creditLimit"` // Amount in cents
+	Tag         *string    `json:"tag,omitempty"`
 }
 
 // PostpaidCardSettingsRequest represents settings for post-paid card
@@ -170,11 +170,80 @@ type CardPaysmartResponse struct {
 
 // DefaultCardConfigurationResponse represents default card configuration
 type DefaultCardConfigurationResponse struct {
-	ContactlessLimit      *int64 `json:"contactlessLimit,omitempty"`
-	OnlinePurchaseEnabled bool   `json:"onlinePurchaseEnabled"`
-	ContactlessEnabled    bool   `json:"contactlessEnabled"`
-	InternationalEnabled  bool   `json:"internationalEnabled"`
-	WithdrawalEnabled     bool   `json:"withdrawalEnabled"`
-	DailyPurchaseLimit    *int64 `json:"dailyPurchaseLimit,omitempty"`
-	DailyWithdrawalLimit  *int64 `json:"dailyWithdrawalLimit,omitempty"`
+	ContactlessLimit      *MoneyCents `json:"contactlessLimit,omitempty"`
+	OnlinePurchaseEnabled bool        `json:"onlinePurchaseEnabled"`
+	ContactlessEnabled    bool        `json:"contactlessEnabled"`
+	InternationalEnabled  bool        `json:"internationalEnabled"`
+	WithdrawalEnabled     bool        `json:"withdrawalEnabled"`
+	DailyPurchaseLimit    *MoneyCents `json:"dailyPurchaseLimit,omitempty"`
+	DailyWithdrawalLimit  *MoneyCents `json:"dailyWithdrawalLimit,omitempty"`
+}
+
+// Validate checks the block motivation
+func (r *BlockCardRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("blockMotivation", r.BlockMotivation)
+	return f.err()
+}
+
+// Validate checks the last 4 digits and password
+func (r *ActivateCardRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if f.required("last4Digits", r.Last4Digits) && (len(r.Last4Digits) != 4 || !isDigits(r.Last4Digits)) {
+		f.add("last4Digits", ErrInvalidField, "last4Digits must have 4 digits")
+	}
+	f.required("password", r.Password)
+	return f.err()
+}
+
+// Validate checks that the new PIN is set and confirmed
+func (r *ChangeCardPinRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("newPin", r.NewPin)
+	if f.required("confirmNewPin", r.ConfirmNewPin) && r.ConfirmNewPin != r.NewPin {
+		f.add("confirmNewPin", ErrInvalidField, "confirmNewPin must match newPin")
+	}
+	return f.err()
+}
+
+// Validate checks the tag
+func (r *UpdateCardTagRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("tag", r.Tag)
+	return f.err()
+}
+
+// Validate checks the optional limits
+func (r *CardConfigurationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	optionalNonNegative(&f, "contactlessLimit", r.ContactlessLimit)
+	optionalNonNegative(&f, "dailyPurchaseLimit", r.DailyPurchaseLimit)
+	optionalNonNegative(&f, "dailyWithdrawalLimit", r.DailyWithdrawalLimit)
+	return f.err()
+}
+
+// Validate checks the account and credit limit
+func (r *PostpaidCardRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	positive(&f, "creditLimit", r.CreditLimit)
+	return f.err()
 }
@@ -10,13 +10,13 @@ type GenericResponse struct {
 
 // TransactionResponse represents a standard transaction response
 type TransactionResponse struct {
-	Message            string     `json:"message"`
-	TransactionID      *int64     `json:"transactionId,omitempty"`
-	AuthenticationCode *string    `json:"authenticationCode,omitempty"`
-	FreeField          *string    `json:"freeField,omitempty"`
-	DateTimeTransfer   *time.Time `json:"dateTimeTransfer,omitempty"`
-	Amount             *int64     `json:"amount,omitempty"`
-	DACode             int32      `json:"da_code"`
+	Message            string      `json:"message"`
+	TransactionID      *int64      `json:"transactionId,omitempty"`
+	AuthenticationCode *string     `json:"authenticationCode,omitempty"`
+	FreeField          *string     `json:"freeField,omitempty"`
+	DateTimeTransfer   *time.Time  `json:"dateTimeTransfer,omitempty"`
+	Amount             *MoneyCents `json:"amount,omitempty"`
+	DACode             int32       `json:"da_code"`
 }
 
 // ListResponse represents a paginated list response
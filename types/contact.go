@@ -40,14 +40,14 @@ type PixKeyInfo struct {
 
 // CreditResponse represents credit information
 type CreditResponse struct {
-	CreditID    int64      `json:"creditId"`
-	AccountID   int64      `json:"accountId"`
-	Amount      int64      `json:"amount"` // Amount in cents
-	Description *string    `json:"description,omitempty"`
-	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
-	CreatedAt   *time.Time `json:"createdAt,omitempty"`
-	UsedAt      *time.Time `json:"usedAt,omitempty"`
-	Status      CreditStatus     `json:"status"` // e.g., "AVAILABLE", "USED", "EXPIRED"
+	CreditID    int64        `json:"creditId"`
+	AccountID   int64        `json:"accountId"`
+	Amount      MoneyCents   `json:"amount"` // Amount in cents
+	Description *string      `json:"description,omitempty"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+	CreatedAt   *time.Time   `json:"createdAt,omitempty"`
+	UsedAt      *time.Time   `json:"usedAt,omitempty"`
+	Status      CreditStatus `json:"status"` // e.g., "AVAILABLE", "USED", "EXPIRED"
 }
 
 // CreditListResponse represents a list of credits
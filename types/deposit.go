@@ -15,16 +15,16 @@ const (
 
 // CreateDepositOrderRequest represents a request to create a deposit order
 type CreateDepositOrderRequest struct {
-	Amount      int64   `json:"amount"` // Amount in cents
-	Description *string `json:"description,omitempty"`
-	ExpiryHours *int    `json:"expiryHours,omitempty"` // Hours until expiration
+	Amount      MoneyCents `json:"amount"` // Amount in cents
+	Description *string    `json:"description,omitempty"`
+	ExpiryHours *int       `json:"expiryHours,omitempty"` // Hours until expiration
 }
 
 // DepositOrderResponse represents a deposit order
 type DepositOrderResponse struct {
 	DepositOrderID int64              `json:"depositOrderId"`
 	AccountID      int64              `json:"accountId"`
-	Amount         int64              `json:"amount"` // Amount in cents
+	Amount         MoneyCents         `json:"amount"` // Amount in cents
 	Status         DepositOrderStatus `json:"status"`
 	Description    *string            `json:"description,omitempty"`
 
@@ -47,3 +47,16 @@ type DepositOrderListResponse struct {
 	Orders []DepositOrderResponse `json:"items"`
 	Total  *int                   `json:"total,omitempty"`
 }
+
+// Validate checks the amount and optional expiry
+func (r *CreateDepositOrderRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	positive(&f, "amount", r.Amount)
+	if r.ExpiryHours != nil && *r.ExpiryHours <= 0 {
+		f.add("expiryHours", ErrInvalidField, "expiryHours must be greater than zero")
+	}
+	return f.err()
+}
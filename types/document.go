@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidDocument is returned by ParseDocument for values that are not a valid CPF or CNPJ
+var ErrInvalidDocument = errors.New("types: invalid CPF/CNPJ")
+
+// Document is a Brazilian taxpayer document: a CPF (individuals) or a CNPJ (companies),
+// in the numeric or the alphanumeric CNPJ format. It holds the value as given, formatted
+// or not, and marshals to JSON in the digits-only form the API expects.
+//
+// To keep documents out of logs, String and LogValue return the masked form
+// (e.g. ***.456.789-**). Use Canonical to obtain the value itself.
+type Document string
+
+// documentPattern matches CPFs and CNPJs, formatted or not, inside free text
+var documentPattern = regexp.MustCompile(
+	`\b(?:\d{3}\.?\d{3}\.?\d{3}-?\d{2}|[0-9A-Z]{2}\.?[0-9A-Z]{3}\.?[0-9A-Z]{3}/?[0-9A-Z]{4}-?\d{2})\b`)
+
+// ParseDocument parses a CPF or CNPJ, formatted (529.982.247-25, 12.ABC.345/01DE-35)
+// or not, and validates its check digits. The result is in canonical form.
+func ParseDocument(s string) (Document, error) {
+	d := Document(Document(s).Canonical())
+	if !d.Valid() {
+		return "", ErrInvalidDocument
+	}
+	return d, nil
+}
+
+// Canonical returns the document without punctuation and with letters in uppercase,
+// e.g. 52998224725 or 12ABC34501DE35
+func (d Document) Canonical() string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(string(d)) {
+		switch {
+		case r == '.' || r == '-' || r == '/' || r == ' ':
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Kind returns DocumentTypeCPF or DocumentTypeCNPJ, or an empty DocumentType when the
+// document is not valid
+func (d Document) Kind() DocumentType {
+	canonical := d.Canonical()
+	switch {
+	case isValidCPF(canonical):
+		return DocumentTypeCPF
+	case isValidCNPJ(canonical):
+		return DocumentTypeCNPJ
+	default:
+		return ""
+	}
+}
+
+// Valid reports whether the document is a CPF or CNPJ with valid check digits
+func (d Document) Valid() bool {
+	return d.Kind() != ""
+}
+
+// IsZero reports whether the document is empty
+func (d Document) IsZero() bool {
+	return strings.TrimSpace(string(d)) == ""
+}
+
+// Formatted returns the document with the standard punctuation, e.g. 529.982.247-25
+// or 12.ABC.345/01DE-35. Invalid documents are returned in canonical form.
+func (d Document) Formatted() string {
+	c := d.Canonical()
+	switch d.Kind() {
+	case DocumentTypeCPF:
+		return c[:3] + "." + c[3:6] + "." + c[6:9] + "-" + c[9:]
+	case DocumentTypeCNPJ:
+		return c[:2] + "." + c[2:5] + "." + c[5:8] + "/" + c[8:12] + "-" + c[12:]
+	default:
+		return c
+	}
+}
+
+// Masked returns the formatted document with the leading characters and the check digits
+// hidden, e.g. ***.456.789-** or **.345.678/0001-**. Invalid documents are fully masked.
+func (d Document) Masked() string {
+	f := d.Formatted()
+	switch d.Kind() {
+	case DocumentTypeCPF:
+		return "***" + f[3:12] + "**"
+	case DocumentTypeCNPJ:
+		return "**" + f[2:16] + "**"
+	default:
+		return strings.Repeat("*", len(f))
+	}
+}
+
+// String returns the masked document, so that formatting a Document never leaks it
+func (d Document) String() string {
+	return d.Masked()
+}
+
+// LogValue implements slog.LogValuer, logging the masked document
+func (d Document) LogValue() slog.Value {
+	return slog.StringValue(d.Masked())
+}
+
+// MarshalJSON encodes the document in canonical form
+func (d Document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Canonical())
+}
+
+// RedactDocuments masks every valid CPF and CNPJ found in s, such as a document in a URL path
+func RedactDocuments(s string) string {
+	return documentPattern.ReplaceAllStringFunc(s, func(match string) string {
+		d := Document(match)
+		if !d.Valid() {
+			return match
+		}
+		return d.Masked()
+	})
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		value     string
+		canonical string
+		kind      DocumentType
+		formatted string
+		masked    string
+	}{
+		{"529.982.247-25", "52998224725", DocumentTypeCPF, "529.982.247-25", "***.982.247-**"},
+		{"52998224725", "52998224725", DocumentTypeCPF, "529.982.247-25", "***.982.247-**"},
+		{"11.222.333/0001-81", "11222333000181", DocumentTypeCNPJ, "11.222.333/0001-81", "**.222.333/0001-**"},
+		{"12.abc.345/01de-35", "12ABC34501DE35", DocumentTypeCNPJ, "12.ABC.345/01DE-35", "**.ABC.345/01DE-**"},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDocument(tt.value)
+		if err != nil {
+			t.Errorf("ParseDocument(%q) = %v", tt.value, err)
+			continue
+		}
+		if string(d) != tt.canonical || d.Kind() != tt.kind || d.Formatted() != tt.formatted || d.Masked() != tt.masked {
+			t.Errorf("ParseDocument(%q) = %s, %s, %s, %s; want %s, %s, %s, %s", tt.value,
+				string(d), d.Kind(), d.Formatted(), d.Masked(), tt.canonical, tt.kind, tt.formatted, tt.masked)
+		}
+	}
+
+	for _, value := range []string{"", "52998224724", "11111111111", "12ABC34501DE3X", "1234"} {
+		if _, err := ParseDocument(value); !errors.Is(err, ErrInvalidDocument) {
+			t.Errorf("ParseDocument(%q) = %v; want ErrInvalidDocument", value, err)
+		}
+	}
+}
+
+func TestDocumentDoesNotLeak(t *testing.T) {
+	d := Document("529.982.247-25")
+
+	data, err := json.Marshal(struct {
+		Document Document `json:"document"`
+	}{d})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"document":"52998224725"}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("lookup", "document", d)
+	if got := buf.String() + fmt.Sprint(d); strings.Contains(got, "224") {
+		t.Errorf("document leaked: %s", got)
+	}
+}
+
+func TestRedactDocuments(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"/accounts/52998224725/corporate", "/accounts/***.982.247-**/corporate"},
+		{"/proposal/last/11.222.333/0001-81", "/proposal/last/**.222.333/0001-**"},
+		{"/accounts/12ABC34501DE35/transfer/idid", "/accounts/**.ABC.345/01DE-**/transfer/idid"},
+		{"/accounts/12345678901/balance", "/accounts/12345678901/balance"},
+	}
+
+	for _, tt := range tests {
+		if got := RedactDocuments(tt.value); got != tt.want {
+			t.Errorf("RedactDocuments(%q) = %q; want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -48,39 +48,39 @@ type PhoneCodeCountryResponse struct {
 
 // CreditEngineInfoRequest represents credit engine information creation/update
 type CreditEngineInfoRequest struct {
-	MonthlyIncome      *int64  `json:"monthlyIncome,omitempty"` // Amount in cents
-	EmploymentStatus   *string `json:"employmentStatus,omitempty"`
-	Employer           *string `json:"employer,omitempty"`
-	Occupation         *string `json:"occupation,omitempty"`
-	PoliticallyExposed *bool   `json:"politicallyExposed,omitempty"`
-	NetWorth           *int64  `json:"netWorth,omitempty"` // Amount in cents
-	SourceOfWealth     *string `json:"sourceOfWealth,omitempty"`
+	MonthlyIncome      *MoneyCents `json:"monthlyIncome,omitempty"` // Amount in cents
+	EmploymentStatus   *string     `json:"employmentStatus,omitempty"`
+	Employer           *string     `json:"employer,omitempty"`
+	Occupation         *string     `json:"occupation,omitempty"`
+	PoliticallyExposed *bool       `json:"politicallyExposed,omitempty"`
+	NetWorth           *MoneyCents `json:"netWorth,omitempty"` // Amount in cents
+	SourceOfWealth     *string     `json:"sourceOfWealth,omitempty"`
 }
 
 // CreditEngineInfoResponse represents credit engine information
 type CreditEngineInfoResponse struct {
-	AccountID          int64   `json:"accountId"`
-	MonthlyIncome      *int64  `json:"monthlyIncome,omitempty"`
-	EmploymentStatus   *string `json:"employmentStatus,omitempty"`
-	Employer           *string `json:"employer,omitempty"`
-	Occupation         *string `json:"occupation,omitempty"`
-	PoliticallyExposed *bool   `json:"politicallyExposed,omitempty"`
-	NetWorth           *int64  `json:"netWorth,omitempty"`
-	SourceOfWealth     *string `json:"sourceOfWealth,omitempty"`
-	CreditScore        *int    `json:"creditScore,omitempty"`
-	CreditLimit        *int64  `json:"creditLimit,omitempty"` // Amount in cents
+	AccountID          int64       `json:"accountId"`
+	MonthlyIncome      *MoneyCents `json:"monthlyIncome,omitempty"`
+	EmploymentStatus   *string     `json:"employmentStatus,omitempty"`
+	Employer           *string     `json:"employer,omitempty"`
+	Occupation         *string     `json:"occupation,omitempty"`
+	PoliticallyExposed *bool       `json:"politicallyExposed,omitempty"`
+	NetWorth           *MoneyCents `json:"netWorth,omitempty"`
+	SourceOfWealth     *string     `json:"sourceOfWealth,omitempty"`
+	CreditScore        *int        `json:"creditScore,omitempty"`
+	CreditLimit        *MoneyCents `json:"creditLimit,omitempty"` // Amount in cents
 }
 
 // ProductResponse represents a product
 type ProductResponse struct {
-	ProductID      int64   `json:"productId"`
-	ProductName    string  `json:"productName"`
-	ProductType    string  `json:"productType"`
-	Description    *string `json:"description,omitempty"`
-	Active         bool    `json:"active"`
-	MonthlyFee     *int64  `json:"monthlyFee,omitempty"`     // Amount in cents
-	TransactionFee *int64  `json:"transactionFee,omitempty"` // Amount in cents
-	WithdrawalFee  *int64  `json:"withdrawalFee,omitempty"`  // Amount in cents
+	ProductID      int64       `json:"productId"`
+	ProductName    string      `json:"productName"`
+	ProductType    string      `json:"productType"`
+	Description    *string     `json:"description,omitempty"`
+	Active         bool        `json:"active"`
+	MonthlyFee     *MoneyCents `json:"monthlyFee,omitempty"`     // Amount in cents
+	TransactionFee *MoneyCents `json:"transactionFee,omitempty"` // Amount in cents
+	WithdrawalFee  *MoneyCents `json:"withdrawalFee,omitempty"`  // Amount in cents
 }
 
 // ProductListResponse represents a list of products
@@ -91,22 +91,59 @@ type ProductListResponse struct {
 
 // CreateProductRequest represents a request to create a product
 type CreateProductRequest struct {
-	ProductName    string  `json:"productName"`
-	ProductType    string  `json:"productType"`
-	Description    *string `json:"description,omitempty"`
-	Active         bool    `json:"active"`
-	MonthlyFee     *int64  `json:"monthlyFee,omitempty"`
-	TransactionFee *int64  `json:"transactionFee,omitempty"`
-	WithdrawalFee  *int64  `json:"withdrawalFee,omitempty"`
+	ProductName    string      `json:"productName"`
+	ProductType    string      `json:"productType"`
+	Description    *string     `json:"description,omitempty"`
+	Active         bool        `json:"active"`
+	MonthlyFee     *MoneyCents `json:"monthlyFee,omitempty"`
+	TransactionFee *MoneyCents `json:"transactionFee,omitempty"`
+	WithdrawalFee  *MoneyCents `json:"withdrawalFee,omitempty"`
 }
 
 // UpdateProductRequest represents a request to update a product
 type UpdateProductRequest struct {
-	ProductName    *string `json:"productName,omitempty"`
-	ProductType    *string `json:"productType,omitempty"`
-	Description    *string `json:"description,omitempty"`
-	Active         *bool   `json:"active,omitempty"`
-	MonthlyFee     *int64  `json:"monthlyFee,omitempty"`
-	TransactionFee *int64  `json:"transactionFee,omitempty"`
-	WithdrawalFee  *int64  `json:"withdrawalFee,omitempty"`
+	ProductName    *string     `json:"productName,omitempty"`
+	ProductType    *string     `json:"productType,omitempty"`
+	Description    *string     `json:"description,omitempty"`
+	Active         *bool       `json:"active,omitempty"`
+	MonthlyFee     *MoneyCents `json:"monthlyFee,omitempty"`
+	TransactionFee *MoneyCents `json:"transactionFee,omitempty"`
+	WithdrawalFee  *MoneyCents `json:"withdrawalFee,omitempty"`
+}
+
+// Validate checks the product name, type and optional fees
+func (r *CreateProductRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("productName", r.ProductName)
+	f.required("productType", r.ProductType)
+	optionalNonNegative(&f, "monthlyFee", r.MonthlyFee)
+	optionalNonNegative(&f, "transactionFee", r.TransactionFee)
+	optionalNonNegative(&f, "withdrawalFee", r.WithdrawalFee)
+	return f.err()
+}
+
+// Validate checks the optional fees
+func (r *UpdateProductRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	optionalNonNegative(&f, "monthlyFee", r.MonthlyFee)
+	optionalNonNegative(&f, "transactionFee", r.TransactionFee)
+	optionalNonNegative(&f, "withdrawalFee", r.WithdrawalFee)
+	return f.err()
+}
+
+// Validate checks the optional income and net worth
+func (r *CreditEngineInfoRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	optionalNonNegative(&f, "monthlyIncome", r.MonthlyIncome)
+	optionalNonNegative(&f, "netWorth", r.NetWorth)
+	return f.err()
 }
@@ -17,6 +17,9 @@ func (v ValidationErrors) Error() string {
 	if len(v) == 0 {
 		return "validation error"
 	}
+	if len(v) > 1 {
+		return fmt.Sprintf("validation failed: %s (and %d more)", v[0].Message, len(v)-1)
+	}
 	return fmt.Sprintf("validation failed: %s", v[0].Message)
 }
 
@@ -85,6 +88,8 @@ const (
 	ErrCellphoneInvalid = "cellphone.invalid"
 	ErrDocumentInvalid  = "document.invalid"
 	ErrInvalidField     = "invalid.field"
+	ErrRequiredField    = "required.field"
+	ErrPixKeyInvalid    = "pix.key.invalid"
 
 	// Integration errors
 	ErrIntegrationError = "integration.error"
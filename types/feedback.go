@@ -10,7 +10,7 @@ type FeedbackRequest struct {
 	Message            string   `json:"message"`            // required, minLength: 10
 	AppVersion         string   `json:"appVersion"`         // required
 	OperationalSystem  string   `json:"operationalSystem"`  // required
-	Amount             float64  `json:"amount"`             // required (number)
+	Amount             Money    `json:"amount"`             // required (number)
 	DateAndHourProblem string   `json:"dateAndHourProblem"` // required
 	Images             []string `json:"images,omitempty"`
 }
@@ -30,8 +30,8 @@ type FeedbackStatementRequest struct {
 // GET /accounts/{accountId}/feedback/lastTransactionError
 // All fields are required
 type FeedbackResponse struct {
-	LastTransactionErrorAmount      float64 `json:"lastTransactionErrorAmount"`      // required (number)
-	LastTransactionErrorDateAndHour string  `json:"lastTransactionErrorDateAndHour"` // required (date-time)
+	LastTransactionErrorAmount      Money  `json:"lastTransactionErrorAmount"`      // required (number)
+	LastTransactionErrorDateAndHour string `json:"lastTransactionErrorDateAndHour"` // required (date-time)
 }
 
 // ContaDigitalResponse represents ContaDigitalResponse from API spec
@@ -41,3 +41,34 @@ type ContaDigitalResponse struct {
 	Message any    `json:"message,omitempty"` // Can be string or object
 	Code    *int   `json:"code,omitempty"`
 }
+
+// minFeedbackMessageLength is the shortest feedback message accepted by the API
+const minFeedbackMessageLength = 10
+
+// Validate checks the account, message, app data, amount and problem date
+func (r *FeedbackRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.minLength("message", r.Message, minFeedbackMessageLength)
+	f.required("appVersion", r.AppVersion)
+	f.required("operationalSystem", r.OperationalSystem)
+	nonNegative(&f, "amount", r.Amount)
+	f.required("dateAndHourProblem", r.DateAndHourProblem)
+	return f.err()
+}
+
+// Validate checks the app data, transaction and message
+func (r *FeedbackStatementRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("operationalSystem", r.OperationalSystem)
+	f.required("appVersion", r.AppVersion)
+	f.requiredID("transactionId", r.TransactionID)
+	f.minLength("message", r.Message, minFeedbackMessageLength)
+	return f.err()
+}
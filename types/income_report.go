@@ -5,8 +5,8 @@ package types
 
 // AccountBalanceByDateResponse represents balance at a specific date (API: AccountBalanceByDateResponse)
 type AccountBalanceByDateResponse struct {
-	Balance *float64 `json:"balance,omitempty"`
-	Date    *string  `json:"date,omitempty"` // date format
+	Balance *Money  `json:"balance,omitempty"`
+	Date    *string `json:"date,omitempty"` // date format
 }
 
 // AccountBalanceByYearResponse represents yearly balance for an account (API: AccountBalanceByYearResponse)
@@ -37,12 +37,12 @@ type IncomeReportResponse struct {
 
 // YearlyBalanceResponse represents yearly balance summary (SDK extended type)
 type YearlyBalanceResponse struct {
-	AccountID      int64 `json:"accountId"`
-	Year           int   `json:"year"`
-	OpeningBalance int64 `json:"openingBalance"` // Amount in cents
-	ClosingBalance int64 `json:"closingBalance"` // Amount in cents
-	TotalCredits   int64 `json:"totalCredits"`   // Amount in cents
-	TotalDebits    int64 `json:"totalDebits"`    // Amount in cents
+	AccountID      int64      `json:"accountId"`
+	Year           int        `json:"year"`
+	OpeningBalance MoneyCents `json:"openingBalance"` // Amount in cents
+	ClosingBalance MoneyCents `json:"closingBalance"` // Amount in cents
+	TotalCredits   MoneyCents `json:"totalCredits"`   // Amount in cents
+	TotalDebits    MoneyCents `json:"totalDebits"`    // Amount in cents
 }
 
 // AllAccountsYearlyBalanceResponse represents yearly balances for all accounts (SDK extended type)
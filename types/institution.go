@@ -29,3 +29,13 @@ type InstitutionResponse struct {
 	CreatedAt     *string `json:"createdAt,omitempty"`
 	UpdatedAt     *string `json:"updatedAt,omitempty"`
 }
+
+// Validate checks the institution name
+func (r *InstitutionRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("name", r.Name)
+	return f.err()
+}
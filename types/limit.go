@@ -5,10 +5,10 @@ package types
 // LimitRequest represents LimitRequest from API spec
 // PUT /accounts/limit/{accountId}/{limitType}
 type LimitRequest struct {
-	DayLimit            *float64 `json:"dayLimit,omitempty"`
-	DayTransactionLimit *float64 `json:"dayTransactionLimit,omitempty"`
-	NightLimit          *float64 `json:"nightLimit,omitempty"`
-	NightTransactionLimit *float64 `json:"nightTransactionLimit,omitempty"`
+	DayLimit              *Money `json:"dayLimit,omitempty"`
+	DayTransactionLimit   *Money `json:"dayTransactionLimit,omitempty"`
+	NightLimit            *Money `json:"nightLimit,omitempty"`
+	NightTransactionLimit *Money `json:"nightTransactionLimit,omitempty"`
 }
 
 // LimitNightTimeRequest represents LimitNightTimeRequest from API spec
@@ -25,12 +25,12 @@ type UpdateLimitRequest = LimitRequest
 
 // LimitDto represents LimitDto from API spec
 type LimitDto struct {
-	StartNightTime        string  `json:"startNightTime"`
-	DayLimit              float64 `json:"dayLimit"`              // number
-	DayTransactionLimit   float64 `json:"dayTransactionLimit"`   // number
-	NightLimit            float64 `json:"nightLimit"`            // number
-	NightTransactionLimit float64 `json:"nightTransactionLimit"` // number
-	Status                int32   `json:"status"`                // int32
+	StartNightTime        string `json:"startNightTime"`
+	DayLimit              Money  `json:"dayLimit"`              // reais
+	DayTransactionLimit   Money  `json:"dayTransactionLimit"`   // reais
+	NightLimit            Money  `json:"nightLimit"`            // reais
+	NightTransactionLimit Money  `json:"nightTransactionLimit"` // reais
+	Status                int32  `json:"status"`                // int32
 }
 
 // LimitResponse represents LimitResponse from API spec
@@ -43,14 +43,14 @@ type LimitResponse struct {
 
 // TransactionLimit represents TransactionLimit from API spec
 type TransactionLimit struct {
-	TotalLimitFormatted           float64 `json:"totalLimitFormatted"`           // number
-	TotalNightLimitFormatted      float64 `json:"totalNightLimitFormatted"`      // number
-	TotalLimit                    int64   `json:"totalLimit"`                    // int64
-	TotalNightLimit               int64   `json:"totalNightLimit"`               // int64
-	LimitTransactionFormatted     float64 `json:"limitTransactionFormatted"`     // number
-	NightLimitTransactionFormatted float64 `json:"nightLimitTransactionFormatted"` // number
-	LimitTransaction              int64   `json:"limitTransaction"`              // int64
-	NightLimitTransaction         int64   `json:"nightLimitTransaction"`         // int64
+	TotalLimitFormatted            Money      `json:"totalLimitFormatted"`            // reais
+	TotalNightLimitFormatted       Money      `json:"totalNightLimitFormatted"`       // reais
+	TotalLimit                     MoneyCents `json:"totalLimit"`                     // cents
+	TotalNightLimit                MoneyCents `json:"totalNightLimit"`                // cents
+	LimitTransactionFormatted      Money      `json:"limitTransactionFormatted"`      // reais
+	NightLimitTransactionFormatted Money      `json:"nightLimitTransactionFormatted"` // reais
+	LimitTransaction               MoneyCents `json:"limitTransaction"`               // cents
+	NightLimitTransaction          MoneyCents `json:"nightLimitTransaction"`          // cents
 }
 
 // GetLimitResponse represents GetLimitResponse from API spec
@@ -67,12 +67,12 @@ type MaximumLimitResponse = GetLimitResponse
 // UpdateProductLimitRequest represents UpdateProductLimitRequest from API spec
 // PUT /limit/{limitType}/productLimit
 type UpdateProductLimitRequest struct {
-	Environment            string   `json:"environment"`
-	RecurrenceType         string   `json:"recurrenceType"`
-	DayLimit               *float64 `json:"dayLimit,omitempty"`
-	DayTransactionLimit    *float64 `json:"dayTransactionLimit,omitempty"`
-	NightLimit             *float64 `json:"nightLimit,omitempty"`
-	NightTransactionLimit  *float64 `json:"nightTransactionLimit,omitempty"`
+	Environment           string `json:"environment"`
+	RecurrenceType        string `json:"recurrenceType"`
+	DayLimit              *Money `json:"dayLimit,omitempty"`
+	DayTransactionLimit   *Money `json:"dayTransactionLimit,omitempty"`
+	NightLimit            *Money `json:"nightLimit,omitempty"`
+	NightTransactionLimit *Money `json:"nightTransactionLimit,omitempty"`
 }
 
 // ProductLimitRequest alias for UpdateProductLimitRequest
@@ -133,3 +133,54 @@ type UpdateProductLimitDaySchedulingResponse struct {
 	LimitScheduling *LimitDaySchedulingDTO `json:"limitScheduling,omitempty"`
 	DaCode          int32                  `json:"da_code"`
 }
+
+// Validate checks that the optional limits are not negative
+func (r *LimitRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	optionalNonNegative(&f, "dayLimit", r.DayLimit)
+	optionalNonNegative(&f, "dayTransactionLimit", r.DayTransactionLimit)
+	optionalNonNegative(&f, "nightLimit", r.NightLimit)
+	optionalNonNegative(&f, "nightTransactionLimit", r.NightTransactionLimit)
+	return f.err()
+}
+
+// Validate checks the night-time start
+func (r *LimitNightTimeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.clockTime("startNightTime", r.StartNightTime)
+	return f.err()
+}
+
+// Validate checks the environment, recurrence type and optional limits
+func (r *UpdateProductLimitRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("environment", r.Environment)
+	f.required("recurrenceType", r.RecurrenceType)
+	optionalNonNegative(&f, "dayLimit", r.DayLimit)
+	optionalNonNegative(&f, "dayTransactionLimit", r.DayTransactionLimit)
+	optionalNonNegative(&f, "nightLimit", r.NightLimit)
+	optionalNonNegative(&f, "nightTransactionLimit", r.NightTransactionLimit)
+	return f.err()
+}
+
+// Validate checks the product and optional night-time start
+func (r *UpdateProductLimitDaySchedulingRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("productId", r.ProductID)
+	if r.StartNightTime != nil {
+		f.clockTime("startNightTime", *r.StartNightTime)
+	}
+	return f.err()
+}
@@ -193,27 +193,27 @@ type CloseInfractionReportRequest struct {
 type RefundSolicitationRequest struct {
 	TransactionID string       `json:"transactionId"` // 32 chars alphanumeric (E2E)
 	RefundReason  RefundReason `json:"refundReason"`
-	RefundAmount  float64      `json:"refundAmount"`
+	RefundAmount  Money        `json:"refundAmount"`
 	RefundDetails *string      `json:"refundDetails,omitempty"` // Max 2000 chars
 }
 
 // RefundResponse represents a refund response (API: RefundResponse)
 type RefundResponse struct {
-	RefundID               string             `json:"id"`                    // Required (API: id)
-	TransactionID          string             `json:"transactionId"`         // Required (32-char alphanumeric)
-	Status                 RefundStatus       `json:"status"`                // Required (OPEN/CLOSED/CANCELLED)
-	RefundReason           RefundReason       `json:"refundReason"`          // Required
-	RefundAmount           float64            `json:"refundAmount"`          // Required
-	RequestingParticipant  string             `json:"requestingParticipant"` // Required (8-digit ISPB)
-	ContestedParticipant   string             `json:"contestedParticipant"`  // Required (8-digit ISPB)
-	RefundTransactionID    string             `json:"refundTransactionId"`   // Required (32-char alphanumeric)
-	CreationDateTime       string             `json:"creationTime"`          // Required
-	LastModifiedDateTime   string             `json:"lastModified"`          // Required
-	RefundDetails          *string            `json:"refundDetails,omitempty"`
-	InfractionReportID     *string            `json:"infractionReportId,omitempty"`
-	AnalysisResult         *RefundResult      `json:"analysisResult,omitempty"`
-	AnalysisDetails        *string            `json:"analysisDetails,omitempty"`
-	RefundRejectionReason  *RefundRejectReason `json:"refundRejectionReason,omitempty"`
+	RefundID              string              `json:"id"`                    // Required (API: id)
+	TransactionID         string              `json:"transactionId"`         // Required (32-char alphanumeric)
+	Status                RefundStatus        `json:"status"`                // Required (OPEN/CLOSED/CANCELLED)
+	RefundReason          RefundReason        `json:"refundReason"`          // Required
+	RefundAmount          Money               `json:"refundAmount"`          // Required
+	RequestingParticipant string              `json:"requestingParticipant"` // Required (8-digit ISPB)
+	ContestedParticipant  string              `json:"contestedParticipant"`  // Required (8-digit ISPB)
+	RefundTransactionID   string              `json:"refundTransactionId"`   // Required (32-char alphanumeric)
+	CreationDateTime      string              `json:"creationTime"`          // Required
+	LastModifiedDateTime  string              `json:"lastModified"`          // Required
+	RefundDetails         *string             `json:"refundDetails,omitempty"`
+	InfractionReportID    *string             `json:"infractionReportId,omitempty"`
+	AnalysisResult        *RefundResult       `json:"analysisResult,omitempty"`
+	AnalysisDetails       *string             `json:"analysisDetails,omitempty"`
+	RefundRejectionReason *RefundRejectReason `json:"refundRejectionReason,omitempty"`
 }
 
 // CloseRefundRequest represents a request to close a refund
@@ -276,3 +276,67 @@ type ListRefundsResponse struct {
 	HasMoreElements bool             `json:"hasMoreElements"` // Required
 	WarningMessage  *string          `json:"warningMessage,omitempty"`
 }
+
+// maxMEDDetailsLength is the longest free-text detail accepted by MED endpoints
+const maxMEDDetailsLength = 2000
+
+// Validate checks the transaction, reason, situation and details length
+func (r *InfractionReportRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("transactionId", r.TransactionID)
+	oneOf(&f, "reason", r.Reason, InfractionReasonRefundRequest, InfractionReasonRefundCancelled)
+	oneOf(&f, "situation", r.Situation,
+		InfractionSituationScam, InfractionSituationAccountTakeover, InfractionSituationCoercion,
+		InfractionSituationFraudulentAccess, InfractionSituationOther, InfractionSituationUnknown)
+	f.maxLength("details", r.Details, maxMEDDetailsLength)
+	return f.err()
+}
+
+// Validate checks the report, analysis result, fraud type and details length
+func (r *CloseInfractionReportRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("infractionReportId", r.InfractionReportID)
+	oneOf(&f, "analysisResult", r.AnalysisResult, AnalysisResultAgreed, AnalysisResultDisagreed)
+	optionalOneOf(&f, "fraudType", r.FraudType,
+		FraudTypeApplicationFraud, FraudTypeMuleAccount, FraudTypeScammerAccount, FraudTypeOther, FraudTypeUnknown)
+	f.maxLength("analysisDetails", r.AnalysisDetails, maxMEDDetailsLength)
+	return f.err()
+}
+
+// Validate checks the EndToEnd ID, reason, amount and details length
+func (r *RefundSolicitationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.endToEndID("transactionId", r.TransactionID)
+	oneOf(&f, "refundReason", r.RefundReason,
+		RefundReasonFraud, RefundReasonOperationalFlaw, RefundReasonRefundCancelled)
+	positive(&f, "refundAmount", r.RefundAmount)
+	f.maxLength("refundDetails", r.RefundDetails, maxMEDDetailsLength)
+	return f.err()
+}
+
+// Validate checks the refund, EndToEnd ID, result and reject reason
+func (r *CloseRefundRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("refundId", r.RefundID)
+	f.endToEndID("transactionId", r.TransactionID)
+	oneOf(&f, "result", r.Result, RefundResultTotallyAccepted, RefundResultPartiallyAccepted, RefundResultRejected)
+	optionalOneOf(&f, "refundRejectReason", r.RefundRejectReason,
+		RefundRejectNoBalance, RefundRejectAccountClosure, RefundRejectOther, RefundRejectInvalidRequest)
+	if r.Result == RefundResultRejected && r.RefundRejectReason == nil {
+		f.add("refundRejectReason", ErrRequiredField, "refundRejectReason is required when the refund is rejected")
+	}
+	f.maxLength("refundAnalysisDetails", r.RefundAnalysisDetails, maxMEDDetailsLength)
+	return f.err()
+}
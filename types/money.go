@@ -0,0 +1,316 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in Brazilian reais, stored as an exact number of centavos.
+// The API uses two wire formats for amounts: Money marshals to JSON as a decimal
+// number of reais (e.g. 1234.56), while MoneyCents marshals to an integer number of
+// centavos (e.g. 123456). Both convert to each other without loss.
+//
+// Amounts are built like time.Duration values:
+//
+//	price := 12*types.Real + 50*types.Centavo // R$ 12,50
+type Money int64
+
+// MoneyCents is an amount in centavos that marshals to JSON as an integer number of
+// centavos. It is used by the fields that the API sends in cents; convert it with
+// Money for formatting and arithmetic.
+type MoneyCents int64
+
+// Money units
+const (
+	Centavo Money = 1
+	Real    Money = 100
+)
+
+// MoneyFromCents returns the amount of the given number of centavos
+func MoneyFromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// MoneyFromFloat converts a float number of reais to Money, rounding to the nearest
+// centavo (half away from zero). The float's shortest decimal representation is used,
+// so MoneyFromFloat(1.005) is R$ 1,01. NaN converts to zero and out-of-range values
+// saturate.
+func MoneyFromFloat(reais float64) Money {
+	switch {
+	case math.IsNaN(reais):
+		return 0
+	case reais >= math.MaxInt64/float64(Real):
+		return math.MaxInt64
+	case reais <= math.MinInt64/float64(Real):
+		return math.MinInt64
+	}
+	m, _ := parseDecimal(strconv.FormatFloat(reais, 'f', -1, 64), true)
+	return m
+}
+
+// ParseMoney parses an amount in reais. It accepts the dot decimal format ("1234.56"),
+// the Brazilian format with thousands separators ("1.234,56") and an optional "R$"
+// prefix ("R$ 1.234,56"). Amounts with fractions of a centavo are rejected.
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		negative, text = true, strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(text, "R$"); ok {
+		text = strings.TrimSpace(rest)
+	}
+	if strings.Contains(text, ",") {
+		text = strings.ReplaceAll(text, ".", "")
+		text = strings.Replace(text, ",", ".", 1)
+	}
+	if text == "" || strings.Trim(text, "0123456789.") != "" || strings.Count(text, ".") > 1 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	m, err := parseDecimal(text, false)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// MustParseMoney is like ParseMoney but panics if s cannot be parsed.
+// It simplifies the initialization of amounts known at compile time.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Cents returns the amount as a number of centavos
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Float64 returns the amount in reais as a float. Prefer Money arithmetic: the
+// result is only as exact as a float64 can be.
+func (m Money) Float64() float64 {
+	return float64(m) / float64(Real)
+}
+
+// InCents converts the amount to its centavos wire format
+func (m Money) InCents() MoneyCents {
+	return MoneyCents(m)
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul returns m multiplied by n
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return -m
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Cmp compares m and other, returning -1, 0 or +1
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m < other:
+		return -1
+	case m > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// IsNegative reports whether the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// Allocate splits m into n parts whose sum is exactly m. The parts differ by at most
+// one centavo; the leftover centavos go to the first parts. It panics if n <= 0.
+func (m Money) Allocate(n int) []Money {
+	if n <= 0 {
+		panic("types: Money.Allocate with non-positive number of parts")
+	}
+	parts := make([]Money, n)
+	share, remainder := m/Money(n), m%Money(n)
+	unit := Centavo
+	if remainder < 0 {
+		unit, remainder = -Centavo, -remainder
+	}
+	for i := range parts {
+		parts[i] = share
+		if Money(i) < remainder {
+			parts[i] += unit
+		}
+	}
+	return parts
+}
+
+// Decimal formats the amount as a dot decimal number of reais with two decimals,
+// e.g. "1234.56", as used in the API's JSON
+func (m Money) Decimal() string {
+	sign := ""
+	cents := uint64(m) //nolint:gosec // the sign is handled below
+	if m < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// String formats the amount in BRL, e.g. "R$ 1.234,56" or "-R$ 0,50"
+func (m Money) String() string {
+	decimal := m.Decimal()
+	sign := ""
+	if rest, ok := strings.CutPrefix(decimal, "-"); ok {
+		sign, decimal = "-", rest
+	}
+	whole, fraction, _ := strings.Cut(decimal, ".")
+
+	var b strings.Builder
+	b.WriteString(sign + "R$ ")
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString("," + fraction)
+	return b.String()
+}
+
+// MarshalJSON encodes the amount as a decimal number of reais
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON decodes a decimal number of reais, given as a JSON number or string.
+// Values are decoded exactly, without going through float64; digits beyond the
+// centavos are rounded half away from zero. null leaves the amount unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text, ok, err := jsonAmount(data)
+	if err != nil || !ok {
+		return err
+	}
+	v, err := parseDecimal(text, true)
+	if err != nil {
+		return fmt.Errorf("types: invalid amount %s: %w", data, err)
+	}
+	*m = v
+	return nil
+}
+
+// Money returns the amount as Money
+func (c MoneyCents) Money() Money {
+	return Money(c)
+}
+
+// String formats the amount in BRL, e.g. "R$ 1.234,56"
+func (c MoneyCents) String() string {
+	return Money(c).String()
+}
+
+// MarshalJSON encodes the amount as an integer number of centavos
+func (c MoneyCents) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(c), 10), nil
+}
+
+// UnmarshalJSON decodes an integer number of centavos, given as a JSON number or
+// string. null leaves the amount unchanged.
+func (c *MoneyCents) UnmarshalJSON(data []byte) error {
+	text, ok, err := jsonAmount(data)
+	if err != nil || !ok {
+		return err
+	}
+	// Accept integral values written with a fraction or exponent, e.g. 1050.0
+	r, valid := new(big.Rat).SetString(text)
+	if !valid || !r.IsInt() || !r.Num().IsInt64() {
+		return fmt.Errorf("types: invalid amount in cents %s", data)
+	}
+	*c = MoneyCents(r.Num().Int64())
+	return nil
+}
+
+// jsonAmount extracts the text of a JSON number or string. ok is false for null.
+func jsonAmount(data []byte) (text string, ok bool, err error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return "", false, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return "", false, err
+		}
+		return strings.TrimSpace(text), true, nil
+	}
+	return string(data), true, nil
+}
+
+// parseDecimal parses a dot decimal number of reais. With round, fractions of a
+// centavo are rounded half away from zero; otherwise they are an error.
+func parseDecimal(text string, round bool) (Money, error) {
+	r, ok := new(big.Rat).SetString(text)
+	if !ok || strings.Contains(text, "/") {
+		return 0, errors.New("not a decimal number")
+	}
+	r.Mul(r, big.NewRat(int64(Real), 1))
+
+	cents := new(big.Int)
+	if r.IsInt() {
+		cents.Set(r.Num())
+	} else {
+		if !round {
+			return 0, errors.New("fractions of a centavo are not allowed")
+		}
+		// Round half away from zero: truncate |r| + 1/2
+		half := new(big.Rat).Abs(r)
+		half.Add(half, big.NewRat(1, 2))
+		cents.Quo(half.Num(), half.Denom())
+		if r.Sign() < 0 {
+			cents.Neg(cents)
+		}
+	}
+	if !cents.IsInt64() {
+		return 0, errors.New("out of range")
+	}
+	return Money(cents.Int64()), nil
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Money
+		ok    bool
+	}{
+		{"1234.56", 123456, true},
+		{"1.234,56", 123456, true},
+		{"R$ 1.234,56", 123456, true},
+		{"-R$ 0,50", -50, true},
+		{"10", 1000, true},
+		{"0.1", 10, true},
+		{"1.005", 0, false},
+		{"1e3", 0, false},
+		{"1/3", 0, false},
+		{"12.34.56", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, %v; want %d, ok=%v", tt.value, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		value Money
+		want  string
+	}{
+		{0, "R$ 0,00"},
+		{5, "R$ 0,05"},
+		{123456, "R$ 1.234,56"},
+		{123456789, "R$ 1.234.567,89"},
+		{-50, "-R$ 0,50"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q; want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var v struct {
+		Reais Money      `json:"reais"`
+		Cents MoneyCents `json:"cents"`
+	}
+	// 0.1 + 0.2 style values are decoded exactly, without float rounding
+	if err := json.Unmarshal([]byte(`{"reais":0.30000000000000004,"cents":30}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Reais != 30 || v.Cents != 30 {
+		t.Errorf("decoded %d and %d; want 30 and 30", v.Reais, v.Cents)
+	}
+	if err := json.Unmarshal([]byte(`{"reais":"1234.565","cents":"123456"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Reais != 123457 || v.Cents != 123456 {
+		t.Errorf("decoded %d and %d; want 123457 and 123456", v.Reais, v.Cents)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"reais":1234.57,"cents":123456}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"cents":10.5}`), &v); err == nil {
+		t.Error("expected error for fractional cents")
+	}
+	if err := json.Unmarshal([]byte(`{"reais":"abc"}`), &v); err == nil {
+		t.Error("expected error for non-numeric amount")
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  Money
+	}{
+		{1.005, 101},
+		{0.1 + 0.2, 30},
+		{-2.675, -268},
+		{100.5, 10050},
+	}
+
+	for _, tt := range tests {
+		if got := MoneyFromFloat(tt.value); got != tt.want {
+			t.Errorf("MoneyFromFloat(%v) = %d; want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	tests := []struct {
+		value Money
+		n     int
+		want  string
+	}{
+		{100, 3, "[R$ 0,34 R$ 0,33 R$ 0,33]"},
+		{-100, 3, "[-R$ 0,34 -R$ 0,33 -R$ 0,33]"},
+		{2, 4, "[R$ 0,01 R$ 0,01 R$ 0,00 R$ 0,00]"},
+	}
+
+	for _, tt := range tests {
+		parts := tt.value.Allocate(tt.n)
+		var sum Money
+		for _, p := range parts {
+			sum = sum.Add(p)
+		}
+		if got := fmt.Sprint(parts); got != tt.want || sum != tt.value {
+			t.Errorf("Allocate(%d, %d) = %s (sum %d); want %s", tt.value, tt.n, got, sum, tt.want)
+		}
+	}
+}
@@ -79,9 +79,9 @@ type PixPaymentRequest struct {
 	RecipientBranchCode       string              `json:"recipientBranchCode"`                 // Required
 	RecipientAccountNumber    string              `json:"recipientAccountNumber"`              // Required
 	RecipientAccountType      PixAccountType      `json:"recipientAccountType"`                // Required (CACC|SLRY|SVGS|TRAN)
-	RecipientCpfCnpj          string              `json:"recipientCpfCnpj"`                    // Required
+	RecipientCpfCnpj          Document            `json:"recipientCpfCnpj"`                    // Required
 	RecipientName             string              `json:"recipientName"`                       // Required
-	OperationAmount           float64             `json:"operationAmount"`                     // Required
+	OperationAmount           Money               `json:"operationAmount"`                     // Required
 	PayerName                 *string             `json:"payerName,omitempty"`                 // Optional
 	InternalReference         *string             `json:"internalReference,omitempty"`         // Optional
 	EndToEnd                  *string             `json:"endToEnd,omitempty"`                  // Optional
@@ -91,7 +91,7 @@ type PixPaymentRequest struct {
 	TransactionPurpose        *TransactionPurpose `json:"transactionPurpose,omitempty"`        // Optional (TROCO|SAQUE)
 	WithdrawalServiceProvider *string             `json:"withdrawalServiceProvider,omitempty"` // Optional
 	AgentMode                 *AgentMode          `json:"agentMode,omitempty"`                 // Optional (AGFSS|AGTEC|AGTOT)
-	CashMoney                 *float64            `json:"cashMoney,omitempty"`                 // Optional
+	CashMoney                 *Money              `json:"cashMoney,omitempty"`                 // Optional
 	Latitude                  *string             `json:"latitude,omitempty"`                  // Optional
 	Longitude                 *string             `json:"longitude,omitempty"`                 // Optional
 	SaveContact               *bool               `json:"saveContact,omitempty"`               // Optional
@@ -106,7 +106,7 @@ type QRCodeParseRequest struct {
 type QRCodeParseResponse struct {
 	KeyType           *PixKeyType `json:"keyType,omitempty"`
 	KeyValue          *string     `json:"keyValue,omitempty"`
-	Amount            *int64      `json:"amount,omitempty"` // Amount in cents
+	Amount            *MoneyCents `json:"amount,omitempty"` // Amount in cents
 	RecipientName     *string     `json:"recipientName,omitempty"`
 	RecipientDocument *string     `json:"recipientDocument,omitempty"`
 	Description       *string     `json:"description,omitempty"`
@@ -116,9 +116,9 @@ type QRCodeParseResponse struct {
 
 // QRCodePaymentRequest represents a PIX payment via QR code
 type QRCodePaymentRequest struct {
-	QRCodeData  string  `json:"qrCodeData"`
-	Amount      *int64  `json:"amount,omitempty"` // Amount in cents (if not in QR code)
-	Description *string `json:"description,omitempty"`
+	QRCodeData  string      `json:"qrCodeData"`
+	Amount      *MoneyCents `json:"amount,omitempty"` // Amount in cents (if not in QR code)
+	Description *string     `json:"description,omitempty"`
 
 	// Location (for fraud prevention)
 	Latitude  *string `json:"latitude,omitempty"`
@@ -129,28 +129,28 @@ type QRCodePaymentRequest struct {
 type PixChargebackRequest struct {
 	AccountID     int64            `json:"accountId"`            // Required
 	IDTransaction int64            `json:"idTransaction"`        // Required
-	Amount        float64          `json:"amount"`               // Required
+	Amount        Money            `json:"amount"`               // Required
 	ReasonCode    ChargebackReason `json:"reasonCode"`           // Required (MD06|FR01|BE08|SL02)
 	ReasonInfo    *string          `json:"reasonInfo,omitempty"` // Optional
 }
 
 // PixLimitRequest represents the request to update PIX limit
 type PixLimitRequest struct {
-	DailyLimit       *int64 `json:"dailyLimit,omitempty"`       // Amount in cents
-	NightlyLimit     *int64 `json:"nightlyLimit,omitempty"`     // Amount in cents
-	TransactionLimit *int64 `json:"transactionLimit,omitempty"` // Amount in cents
+	DailyLimit       *MoneyCents `json:"dailyLimit,omitempty"`       // Amount in cents
+	NightlyLimit     *MoneyCents `json:"nightlyLimit,omitempty"`     // Amount in cents
+	TransactionLimit *MoneyCents `json:"transactionLimit,omitempty"` // Amount in cents
 }
 
 // PixLimitResponse represents PIX limit information
 type PixLimitResponse struct {
 	AccountID        int64      `json:"accountId"`
-	DailyLimit       int64      `json:"dailyLimit"`               // Amount in cents
-	NightlyLimit     int64      `json:"nightlyLimit"`             // Amount in cents
-	TransactionLimit int64      `json:"transactionLimit"`         // Amount in cents
-	DailyUsed        int64      `json:"dailyUsed"`                // Amount in cents
-	NightlyUsed      int64      `json:"nightlyUsed"`              // Amount in cents
-	DailyRemaining   int64      `json:"dailyRemaining"`           // Amount in cents
-	NightlyRemaining int64      `json:"nightlyRemaining"`         // Amount in cents
+	DailyLimit       MoneyCents `json:"dailyLimit"`               // Amount in cents
+	NightlyLimit     MoneyCents `json:"nightlyLimit"`             // Amount in cents
+	TransactionLimit MoneyCents `json:"transactionLimit"`         // Amount in cents
+	DailyUsed        MoneyCents `json:"dailyUsed"`                // Amount in cents
+	NightlyUsed      MoneyCents `json:"nightlyUsed"`              // Amount in cents
+	DailyRemaining   MoneyCents `json:"dailyRemaining"`           // Amount in cents
+	NightlyRemaining MoneyCents `json:"nightlyRemaining"`         // Amount in cents
 	NightTimeStart   *string    `json:"nightTimeStart,omitempty"` // Format: HH:MM
 	NightTimeEnd     *string    `json:"nightTimeEnd,omitempty"`   // Format: HH:MM
 	UpdatedAt        *time.Time `json:"updatedAt,omitempty"`
@@ -239,12 +239,12 @@ type ProcessLimitRequestData struct {
 type RaiseLimitRequestResponse struct {
 	RequestID      int64              `json:"requestId"`
 	AccountID      int64              `json:"accountId"`
-	RequestedLimit int64              `json:"requestedLimit"` // Amount in cents
-	CurrentLimit   int64              `json:"currentLimit"`   // Amount in cents
+	RequestedLimit MoneyCents         `json:"requestedLimit"` // Amount in cents
+	CurrentLimit   MoneyCents         `json:"currentLimit"`   // Amount in cents
 	Status         LimitRequestStatus `json:"status"`
-	Reason         *string `json:"reason,omitempty"`
-	CreatedAt      *string `json:"createdAt,omitempty"`
-	ProcessedAt    *string `json:"processedAt,omitempty"`
+	Reason         *string            `json:"reason,omitempty"`
+	CreatedAt      *string            `json:"createdAt,omitempty"`
+	ProcessedAt    *string            `json:"processedAt,omitempty"`
 }
 
 // RaiseLimitRequestListResponse represents a list of raise limit requests
@@ -255,9 +255,9 @@ type RaiseLimitRequestListResponse struct {
 
 // MaximumPixLimitIssuerResponse represents the maximum PIX limit allowed by issuer
 type MaximumPixLimitIssuerResponse struct {
-	MaxDailyLimit       int64 `json:"maxDailyLimit"`       // Amount in cents
-	MaxNightlyLimit     int64 `json:"maxNightlyLimit"`     // Amount in cents
-	MaxTransactionLimit int64 `json:"maxTransactionLimit"` // Amount in cents
+	MaxDailyLimit       MoneyCents `json:"maxDailyLimit"`       // Amount in cents
+	MaxNightlyLimit     MoneyCents `json:"maxNightlyLimit"`     // Amount in cents
+	MaxTransactionLimit MoneyCents `json:"maxTransactionLimit"` // Amount in cents
 }
 
 // PixPaymentResponse represents a PIX payment transaction response
@@ -290,10 +290,10 @@ type PixPrecautionaryBlockRequest struct {
 
 // PixPrecautionaryBlockResponse represents a precautionary block response
 type PixPrecautionaryBlockResponse struct {
-	IDAccount     int64   `json:"idAccount"`
-	IDTransaction int64   `json:"idTransaction"`
-	Message       string  `json:"message"`
-	Value         float64 `json:"value"`
+	IDAccount     int64  `json:"idAccount"`
+	IDTransaction int64  `json:"idTransaction"`
+	Message       string `json:"message"`
+	Value         Money  `json:"value"`
 }
 
 // PixUpdatePrecautionaryBlockRequest represents an update to precautionary block (POST /pix/backoffice/precautionaryBlock/update)
@@ -309,10 +309,10 @@ type PixUpdatePrecautionaryBlockResponse struct {
 
 // PixTransactionLimit represents PIX transaction limits
 type PixTransactionLimit struct {
-	TotalAvailableLimitFormatted          float64 `json:"totalAvailableLimitFormatted"`
-	TotalAvailableLimit                   int64   `json:"totalAvailableLimit"`
-	LimitPerAvailableTransaction          int64   `json:"limitPerAvailableTransaction"`
-	LimitPerAvailableTransactionFormatted float64 `json:"limitPerAvailableTransactionFormatted"`
+	TotalAvailableLimitFormatted          Money      `json:"totalAvailableLimitFormatted"`
+	TotalAvailableLimit                   MoneyCents `json:"totalAvailableLimit"`
+	LimitPerAvailableTransaction          MoneyCents `json:"limitPerAvailableTransaction"`
+	LimitPerAvailableTransactionFormatted Money      `json:"limitPerAvailableTransactionFormatted"`
 }
 
 // PixGetLimitResponse represents PIX limit information (GET /pix/transactions/{accountId}/limit)
@@ -392,3 +392,233 @@ type SearchKeyResponse struct {
 	ReivindicacaoAbertaDesde *string           `json:"reivindicacaoAbertaDesde,omitempty"`
 	FraudIdentified          *bool             `json:"fraudIdentified,omitempty"`
 }
+
+// Validate checks the key type and, except for random (EVP) keys generated by the API, the key format
+func (r *CreatePixKeyRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.KeyType == PixKeyTypeRandom && r.Key == "" {
+		return nil
+	}
+	var f fieldErrors
+	f.pixKey("keyType", "key", r.KeyType, r.Key)
+	return f.err()
+}
+
+// Validate checks the key type and key format
+func (r *DeletePixKeyRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.pixKey("keyType", "key", r.KeyType, r.Key)
+	return f.err()
+}
+
+// Validate checks the key and claim type
+func (r *CreatePixClaimRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.pixKey("keyType", "keyValue", r.KeyType, r.KeyValue)
+	oneOf(&f, "claimType", r.ClaimType, "PORTABILITY", "OWNERSHIP")
+	return f.err()
+}
+
+// Validate checks required recipient data, the amount and the optional enums, key and scheduling date
+func (r *PixPaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("recipientInstitutionCode", r.RecipientInstitutionCode)
+	f.required("recipientBranchCode", r.RecipientBranchCode)
+	f.required("recipientAccountNumber", r.RecipientAccountNumber)
+	oneOf(&f, "recipientAccountType", r.RecipientAccountType,
+		PixAccountTypeCACC, PixAccountTypeSLRY, PixAccountTypeSVGS, PixAccountTypeTRAN)
+	f.document("recipientCpfCnpj", r.RecipientCpfCnpj)
+	f.required("recipientName", r.RecipientName)
+	positive(&f, "operationAmount", r.OperationAmount)
+	f.optionalDateTime("schedulingDate", r.SchedulingDate)
+	optionalOneOf(&f, "transactionPurpose", r.TransactionPurpose, TransactionPurposeTROCO, TransactionPurposeSAQUE)
+	optionalOneOf(&f, "agentMode", r.AgentMode, AgentModeAGFSS, AgentModeAGTEC, AgentModeAGTOT)
+	optionalPositive(&f, "cashMoney", r.CashMoney)
+	return f.err()
+}
+
+// Validate checks the QR code data and the optional amount
+func (r *QRCodePaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("qrCodeData", r.QRCodeData)
+	optionalPositive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the transaction, amount and reason code
+func (r *PixChargebackRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.requiredID("idTransaction", r.IDTransaction)
+	positive(&f, "amount", r.Amount)
+	oneOf(&f, "reasonCode", r.ReasonCode,
+		ChargebackReasonMD06, ChargebackReasonFR01, ChargebackReasonBE08, ChargebackReasonSL02)
+	return f.err()
+}
+
+// Validate checks the night-time window bounds
+func (r *UpdatePixNightTimeLimitRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.clockTime("startTime", r.StartTime)
+	f.clockTime("endTime", r.EndTime)
+	return f.err()
+}
+
+// Validate checks the account and schedule identifiers
+func (r *PixCancelScheduleRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.requiredID("scheduleId", r.ScheduleID)
+	return f.err()
+}
+
+// Validate checks the transaction log and block status
+func (r *PixUpdatePrecautionaryBlockRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("idOnlineTransactionLog", r.IDOnlineTransactionLog)
+	oneOf(&f, "pixPrecautionaryEnum", r.PixPrecautionaryEnum,
+		PrecautionaryBlockNotCompleted, PrecautionaryBlockReleased,
+		PrecautionaryBlockConvertedToFraudAnalysis, PrecautionaryBlockReleasedWithoutAnalysis)
+	return f.err()
+}
+
+// Validate checks the claim identifier
+func (r *ConfirmPortabilityRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("claimId", r.ClaimID)
+	return f.err()
+}
+
+// Validate checks the claim identifier
+func (r *CompletePortabilityRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("claimId", r.ClaimID)
+	return f.err()
+}
+
+// Validate checks the claim identifier
+func (r *CancelPortabilityRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("claimId", r.ClaimID)
+	return f.err()
+}
+
+// Validate checks the key and claim type
+func (r *CreateClaimFromKeyRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.pixKey("keyType", "keyValue", r.KeyType, r.KeyValue)
+	oneOf(&f, "claimType", r.ClaimType, "PORTABILITY", "OWNERSHIP")
+	return f.err()
+}
+
+// Validate checks the device identifier and name
+func (r *PixDeviceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("deviceId", r.DeviceID)
+	f.required("deviceName", r.DeviceName)
+	return f.err()
+}
+
+// Validate checks the device identifier
+func (r *BlockPixDeviceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("deviceId", r.DeviceID)
+	return f.err()
+}
+
+// Validate checks the device identifier
+func (r *UnblockPixDeviceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("deviceId", r.DeviceID)
+	return f.err()
+}
+
+// Validate checks the device identifier
+func (r *DeletePixDeviceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("deviceId", r.DeviceID)
+	return f.err()
+}
+
+// Validate checks that the optional limits are not negative
+func (r *PixLimitRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	optionalNonNegative(&f, "dailyLimit", r.DailyLimit)
+	optionalNonNegative(&f, "nightlyLimit", r.NightlyLimit)
+	optionalNonNegative(&f, "transactionLimit", r.TransactionLimit)
+	return f.err()
+}
+
+// Validate checks the transaction
+func (r *PixPrecautionaryBlockRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("idTransaction", r.IDTransaction)
+	return f.err()
+}
+
+// Validate checks the QR code data
+func (r *QRCodeParseRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("qrCodeData", r.QRCodeData)
+	return f.err()
+}
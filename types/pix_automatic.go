@@ -73,8 +73,8 @@ type StartAutomaticPixRequest struct {
 	AccountID                  int64         `json:"accountId"`
 	PayerBranch                *string       `json:"payerBranch,omitempty"`
 	PayerAccount               string        `json:"payerAccount"`
-	DebtorDocument             *string       `json:"debtorDocument,omitempty"`
-	PayerDocument              *string       `json:"payerDocument,omitempty"`
+	DebtorDocument             *Document     `json:"debtorDocument,omitempty"`
+	PayerDocument              *Document     `json:"payerDocument,omitempty"`
 	RecurrenceEndDate          *string       `json:"recurrenceEndDate,omitempty"` // Format: YYYY-MM-DD
 	RecurrenceStartDate        string        `json:"recurrenceStartDate"`         // Format: YYYY-MM-DD
 	RecurrenceID               string        `json:"recurrenceId"`                // Max 29 chars
@@ -84,9 +84,9 @@ type StartAutomaticPixRequest struct {
 	DebtorName                 *string       `json:"debtorName,omitempty"`
 	ContractNumber             string        `json:"contractNumber"`
 	PayerParticipant           string        `json:"payerParticipant"`
-	FloorMaximumValue          *float64      `json:"floorMaximumValue,omitempty"`
+	FloorMaximumValue          *Money        `json:"floorMaximumValue,omitempty"`
 	FrequencyType              FrequencyType `json:"frequencyType"`
-	Value                      *float64      `json:"value,omitempty"`
+	Value                      *Money        `json:"value,omitempty"`
 }
 
 // StartAutomaticPixResponse represents response from starting automatic PIX
@@ -111,28 +111,28 @@ type RejectAutomaticPixResponse struct {
 
 // PixPaymentRequest represents PIX payment request (for automatic PIX journey three)
 type PixPaymentRequestAutomatic struct {
-	AccountID                 int64    `json:"accountId"`
-	RecipientInstitutionCode  string   `json:"recipientInstitutionCode"`
-	RecipientBranchCode       string   `json:"recipientBranchCode"`
-	RecipientAccountNumber    string   `json:"recipientAccountNumber"`
-	RecipientAccountType      string   `json:"recipientAccountType"` // CACC, SLRY, SVGS, TRAN
-	RecipientCpfCnpj          string   `json:"recipientCpfCnpj"`
-	RecipientName             string   `json:"recipientName"`
-	PayerName *string `json:"payerName,omitempty"`
+	AccountID                int64    `json:"accountId"`
+	RecipientInstitutionCode string   `json:"recipientInstitutionCode"`
+	RecipientBranchCode      string   `json:"recipientBranchCode"`
+	RecipientAccountNumber   string   `json:"recipientAccountNumber"`
+	RecipientAccountType     string   `json:"recipientAccountType"` // CACC, SLRY, SVGS, TRAN
+	RecipientCpfCnpj         Document `json:"recipientCpfCnpj"`
+	RecipientName            string   `json:"recipientName"`
+	PayerName                *string  `json:"payerName,omitempty"`
 	// InternalReference uses "internalReferente" (API typo preserved for compatibility)
-	InternalReference *string `json:"internalReferente,omitempty"`
-	OperationAmount           float64  `json:"operationAmount"`
-	EndToEnd                  *string  `json:"endToEnd,omitempty"`
-	RecipientAddressingKey    *string  `json:"recipientAddressingKey,omitempty"`
-	FreeField                 *string  `json:"freeField,omitempty"`
-	SchedulingDate            *string  `json:"schedulingDate,omitempty"`
-	TransactionPurpose        *string  `json:"transactionPurpose,omitempty"` // TROCO, SAQUE
-	WithdrawalServiceProvider *string  `json:"withdrawalServiceProvider,omitempty"`
-	AgentMode                 *string  `json:"agentMode,omitempty"` // AGFSS, AGTEC, AGTOT
-	CashMoney                 *float64 `json:"cashMoney,omitempty"`
-	Latitude                  *string  `json:"latitude,omitempty"`
-	Longitude                 *string  `json:"longitude,omitempty"`
-	SaveContact               *bool    `json:"saveContact,omitempty"`
+	InternalReference         *string `json:"internalReferente,omitempty"`
+	OperationAmount           Money   `json:"operationAmount"`
+	EndToEnd                  *string `json:"endToEnd,omitempty"`
+	RecipientAddressingKey    *string `json:"recipientAddressingKey,omitempty"`
+	FreeField                 *string `json:"freeField,omitempty"`
+	SchedulingDate            *string `json:"schedulingDate,omitempty"`
+	TransactionPurpose        *string `json:"transactionPurpose,omitempty"` // TROCO, SAQUE
+	WithdrawalServiceProvider *string `json:"withdrawalServiceProvider,omitempty"`
+	AgentMode                 *string `json:"agentMode,omitempty"` // AGFSS, AGTEC, AGTOT
+	CashMoney                 *Money  `json:"cashMoney,omitempty"`
+	Latitude                  *string `json:"latitude,omitempty"`
+	Longitude                 *string `json:"longitude,omitempty"`
+	SaveContact               *bool   `json:"saveContact,omitempty"`
 }
 
 // QRCodeUserAcceptRequest represents QR code acceptance request
@@ -154,8 +154,8 @@ type QRCodeUserAcceptRequest struct {
 	ReceiverParticipant         string        `json:"receiverParticipant"`
 	FrequencyType               FrequencyType `json:"frequencyType"`
 	RecurrenceType              *string       `json:"recurrenceType,omitempty"`
-	Value                       *float64      `json:"value,omitempty"`
-	AuthorizedValue             *float64      `json:"authorizedValue,omitempty"`
+	Value                       *Money        `json:"value,omitempty"`
+	AuthorizedValue             *Money        `json:"authorizedValue,omitempty"`
 	IbgeMunicipalCode           *string       `json:"ibgeMunicipalCode,omitempty"`
 	Journey                     JourneyType   `json:"journey"` // AUT2, AUT3, AUT4
 }
@@ -223,10 +223,10 @@ type CancelAutomaticPixResponse struct {
 
 // AcceptAutomaticPixRequest represents request to accept automatic PIX
 type AcceptAutomaticPixRequest struct {
-	AccountID    int64    `json:"accountId"`
-	RecurrenceID string   `json:"recurrenceId"`
-	ZipCode      *string  `json:"zipCode,omitempty"` // 8 digits
-	Value        *float64 `json:"value,omitempty"`
+	AccountID    int64   `json:"accountId"`
+	RecurrenceID string  `json:"recurrenceId"`
+	ZipCode      *string `json:"zipCode,omitempty"` // 8 digits
+	Value        *Money  `json:"value,omitempty"`
 }
 
 // AutomaticPixResponse represents full automatic PIX response
@@ -235,19 +235,19 @@ type AutomaticPixResponse struct {
 	RecurrenceRequestID      *string          `json:"recurrenceRequestId,omitempty"`
 	Status                   RecurrenceStatus `json:"status"`
 	FrequencyType            FrequencyType    `json:"frequencyType,omitempty"`
-	RecurrenceStartDate      *string       `json:"recurrenceStartDate,omitempty"`
-	RecurrenceEndDate        *string       `json:"recurrenceEndDate,omitempty"`
-	Value                    *float64      `json:"value,omitempty"`
-	FloorMaximumValue        *float64      `json:"floorMaximumValue,omitempty"`
-	ContractNumber           *string       `json:"contractNumber,omitempty"`
-	Description              *string       `json:"description,omitempty"`
-	PayerDocument            *string       `json:"payerDocument,omitempty"`
-	PayerName                *string       `json:"payerName,omitempty"`
-	ReceiverDocument         *string       `json:"receiverDocument,omitempty"`
-	ReceiverName             *string       `json:"receiverName,omitempty"`
-	ReceiverParticipant      *string       `json:"receiverParticipant,omitempty"`
-	CreationDateTime         *string       `json:"creationDateTime,omitempty"`
-	LastModificationDateTime *string       `json:"lastModificationDateTime,omitempty"`
+	RecurrenceStartDate      *string          `json:"recurrenceStartDate,omitempty"`
+	RecurrenceEndDate        *string          `json:"recurrenceEndDate,omitempty"`
+	Value                    *Money           `json:"value,omitempty"`
+	FloorMaximumValue        *Money           `json:"floorMaximumValue,omitempty"`
+	ContractNumber           *string          `json:"contractNumber,omitempty"`
+	Description              *string          `json:"description,omitempty"`
+	PayerDocument            *string          `json:"payerDocument,omitempty"`
+	PayerName                *string          `json:"payerName,omitempty"`
+	ReceiverDocument         *string          `json:"receiverDocument,omitempty"`
+	ReceiverName             *string          `json:"receiverName,omitempty"`
+	ReceiverParticipant      *string          `json:"receiverParticipant,omitempty"`
+	CreationDateTime         *string          `json:"creationDateTime,omitempty"`
+	LastModificationDateTime *string          `json:"lastModificationDateTime,omitempty"`
 }
 
 // ListAutomaticPixParams represents query params for listing automatic PIX
@@ -305,11 +305,11 @@ type AutomaticPixPaymentScheduleDTO struct {
 	ID                 int64            `json:"id"`
 	RecurrenceID       string           `json:"recurrenceId"`
 	ScheduledDate      string           `json:"scheduledDate"`
-	Value              float64          `json:"value"`
+	Value              Money            `json:"value"`
 	Status             RecurrenceStatus `json:"status"`
-	PaymentDate        *string `json:"paymentDate,omitempty"`
-	EndToEnd           *string `json:"endToEnd,omitempty"`
-	AuthenticationCode *string `json:"authenticationCode,omitempty"`
+	PaymentDate        *string          `json:"paymentDate,omitempty"`
+	EndToEnd           *string          `json:"endToEnd,omitempty"`
+	AuthenticationCode *string          `json:"authenticationCode,omitempty"`
 }
 
 // AutomaticPixChargeListResponse represents list of automatic PIX charges
@@ -329,3 +329,150 @@ type AutomaticPixContractResponse = CreateAutomaticPixContractResponse
 type AcceptQRCodeJourneyThreeRequest = QRCodeAcceptJourneyThreeRequest
 type AcceptQRCodeRequest = QRCodeUserAcceptRequest
 type AutomaticPixChargeResponse = AutomaticPixPaymentScheduleDTO
+
+// maxRecurrenceIDLength is the longest recurrence identifier accepted by the API
+const maxRecurrenceIDLength = 29
+
+// recurrenceID checks that a required recurrence identifier fits the API limit
+func (f *fieldErrors) recurrenceID(value string) {
+	if f.required("recurrenceId", value) {
+		f.maxLength("recurrenceId", &value, maxRecurrenceIDLength)
+	}
+}
+
+// Validate checks the payer, recurrence, contract, frequency, dates and amounts
+func (r *StartAutomaticPixRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("payerAccount", r.PayerAccount)
+	f.optionalDocument("debtorDocument", r.DebtorDocument)
+	f.optionalDocument("payerDocument", r.PayerDocument)
+	f.date("recurrenceStartDate", r.RecurrenceStartDate)
+	f.optionalDate("recurrenceEndDate", r.RecurrenceEndDate)
+	if r.RecurrenceEndDate != nil && *r.RecurrenceEndDate < r.RecurrenceStartDate {
+		f.add("recurrenceEndDate", ErrInvalidField, "recurrenceEndDate must not be before recurrenceStartDate")
+	}
+	f.optionalDateTime("expirationDate", r.ExpirationDate)
+	f.recurrenceID(r.RecurrenceID)
+	f.required("contractNumber", r.ContractNumber)
+	f.required("payerParticipant", r.PayerParticipant)
+	oneOf(&f, "frequencyType", r.FrequencyType,
+		FrequencyAnnual, FrequencyMonthly, FrequencyQuarterly, FrequencyWeekly, FrequencyYearly)
+	optionalPositive(&f, "floorMaximumValue", r.FloorMaximumValue)
+	optionalPositive(&f, "value", r.Value)
+	return f.err()
+}
+
+// Validate checks the account, recurrence and reject reason
+func (r *RejectAutomaticPixRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.recurrenceID(r.RecurrenceID)
+	oneOf(&f, "rejectReason", r.RejectReason, RejectReasonAP13, RejectReasonAP14)
+	return f.err()
+}
+
+// Validate checks the account, recurrence and contract
+func (r *CreateAutomaticPixContractRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.recurrenceID(r.RecurrenceID)
+	f.required("contractNumber", r.ContractNumber)
+	return f.err()
+}
+
+// Validate checks the charge, account and cancellation reason
+func (r *CancelAutomaticPixChargeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("id", r.ID)
+	f.requiredID("accountId", r.AccountID)
+	oneOf(&f, "reason", r.Reason, ChargeCancelReasonSLBD, ChargeCancelReasonFAIL)
+	return f.err()
+}
+
+// Validate checks the account, recurrence and cancellation reason
+func (r *CancelAutomaticPixRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.recurrenceID(r.RecurrenceID)
+	oneOf(&f, "cancellationReason", r.CancellationReason,
+		CancellationReasonACCL, CancellationReasonCPCL, CancellationReasonDCSD, CancellationReasonERSL,
+		CancellationReasonFRUD, CancellationReasonNRES, CancellationReasonPCFD, CancellationReasonSLCR,
+		CancellationReasonSLDB)
+	return f.err()
+}
+
+// Validate checks the account, recurrence, zip code and optional value
+func (r *AcceptAutomaticPixRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.recurrenceID(r.RecurrenceID)
+	if r.ZipCode != nil && (len(*r.ZipCode) != 8 || !isDigits(*r.ZipCode)) {
+		f.add("zipCode", ErrInvalidField, "zipCode must have 8 digits")
+	}
+	optionalPositive(&f, "value", r.Value)
+	return f.err()
+}
+
+// Validate checks the account, payer, recurrence, contract, receiver, frequency, journey, dates and amounts
+func (r *QRCodeUserAcceptRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.document("payerDocument", Document(r.PayerDocument))
+	if f.required("requestCreationDateTime", r.RequestCreationDateTime) {
+		f.optionalDateTime("requestCreationDateTime", &r.RequestCreationDateTime)
+	}
+	if f.required("creationDateTimeForIssuance", r.CreationDateTimeForIssuance) {
+		f.optionalDateTime("creationDateTimeForIssuance", &r.CreationDateTimeForIssuance)
+	}
+	f.date("recurrenceStartDate", r.RecurrenceStartDate)
+	f.optionalDate("recurrenceEndDate", r.RecurrenceEndDate)
+	if r.RecurrenceEndDate != nil && *r.RecurrenceEndDate < r.RecurrenceStartDate {
+		f.add("recurrenceEndDate", ErrInvalidField, "recurrenceEndDate must not be before recurrenceStartDate")
+	}
+	f.recurrenceID(r.RecurrenceID)
+	f.required("receiverName", r.ReceiverName)
+	f.required("contractNumber", r.ContractNumber)
+	f.required("receiverParticipant", r.ReceiverParticipant)
+	oneOf(&f, "frequencyType", r.FrequencyType,
+		FrequencyAnnual, FrequencyMonthly, FrequencyQuarterly, FrequencyWeekly, FrequencyYearly)
+	oneOf(&f, "journey", r.Journey, JourneyTypeAUT2, JourneyTypeAUT3, JourneyTypeAUT4)
+	optionalPositive(&f, "value", r.Value)
+	optionalPositive(&f, "authorizedValue", r.AuthorizedValue)
+	return f.err()
+}
+
+// Validate checks the acceptance and the account, recipient and amount of the payment
+func (r *QRCodeAcceptJourneyThreeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.nested("pain012", r.Pain012.Validate())
+	f.requiredID("pixPayment.accountId", r.PixPayment.AccountID)
+	f.document("pixPayment.recipientCpfCnpj", r.PixPayment.RecipientCpfCnpj)
+	f.required("pixPayment.recipientName", r.PixPayment.RecipientName)
+	positive(&f, "pixPayment.operationAmount", r.PixPayment.OperationAmount)
+	return f.err()
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidPixKey is returned by ParsePixKey for inputs that are not a PIX key of any type
+	ErrInvalidPixKey = errors.New("types: invalid PIX key")
+
+	// ErrAmbiguousPixKey is matched by the AmbiguousPixKeyError returned for inputs that
+	// are valid keys of more than one type
+	ErrAmbiguousPixKey = errors.New("types: ambiguous PIX key")
+)
+
+// brazilianPhonePattern matches a Brazilian phone number without the country code:
+// a two-digit area code followed by a 9-digit mobile or an 8-digit landline number
+var brazilianPhonePattern = regexp.MustCompile(`^[1-9]{2}(9[0-9]{8}|[2-5][0-9]{7})$`)
+
+// PixKey is a PIX key normalized per DICT rules: CPFs and CNPJs without punctuation,
+// e-mails and EVPs in lowercase and phones in E.164 format (+5511987654321)
+type PixKey struct {
+	Type  PixKeyType
+	Value string
+}
+
+// String returns the normalized key
+func (k PixKey) String() string {
+	return k.Value
+}
+
+// AmbiguousPixKeyError is returned by ParsePixKey when the input is a valid key of more
+// than one type, such as 11 digits that are both a valid CPF and a mobile number.
+// Callers should ask the user which key was meant.
+type AmbiguousPixKeyError struct {
+	// Candidates lists the possible keys, most likely first
+	Candidates []PixKey
+}
+
+// Error implements the error interface
+func (e *AmbiguousPixKeyError) Error() string {
+	kinds := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		kinds[i] = string(c.Type)
+	}
+	return ErrAmbiguousPixKey.Error() + ": could be " + strings.Join(kinds, " or ")
+}
+
+// Unwrap returns ErrAmbiguousPixKey
+func (e *AmbiguousPixKeyError) Unwrap() error {
+	return ErrAmbiguousPixKey
+}
+
+// ParsePixKey detects the type of a PIX key typed by a user and normalizes it. It
+// recognizes CPFs and CNPJs (formatted or not), e-mails, EVPs (UUIDs) and Brazilian
+// phones, with or without +55 and punctuation.
+//
+// Inputs that are valid keys of several types return the most likely key together with
+// an *AmbiguousPixKeyError listing all candidates. Invalid inputs return ErrInvalidPixKey.
+func ParsePixKey(s string) (PixKey, error) {
+	input := strings.TrimSpace(s)
+	switch {
+	case input == "":
+		return PixKey{}, ErrInvalidPixKey
+	case strings.Contains(input, "@"):
+		key := PixKey{Type: PixKeyTypeEmail, Value: strings.ToLower(input)}
+		if !isValidPixKey(key.Type, key.Value) {
+			return PixKey{}, ErrInvalidPixKey
+		}
+		return key, nil
+	case pixEVPPattern.MatchString(input):
+		return PixKey{Type: PixKeyTypeRandom, Value: strings.ToLower(input)}, nil
+	}
+
+	candidates := pixKeyCandidates(input)
+	switch len(candidates) {
+	case 0:
+		return PixKey{}, ErrInvalidPixKey
+	case 1:
+		return candidates[0], nil
+	default:
+		return candidates[0], &AmbiguousPixKeyError{Candidates: candidates}
+	}
+}
+
+// pixKeyCandidates returns the document and phone keys that input can be. Punctuation
+// settles the type: dots and slashes only appear in documents, "+" and parentheses
+// only in phones.
+func pixKeyCandidates(input string) []PixKey {
+	var candidates []PixKey
+	if !strings.ContainsAny(input, "+()") {
+		if doc := Document(input); doc.Valid() {
+			candidates = append(candidates, PixKey{Type: PixKeyType(doc.Kind()), Value: doc.Canonical()})
+		}
+	}
+	if !strings.ContainsAny(input, "./") {
+		if phone, ok := normalizePhone(input); ok {
+			candidates = append(candidates, PixKey{Type: PixKeyTypePhone, Value: phone})
+		}
+	}
+	return candidates
+}
+
+// normalizePhone converts a Brazilian phone number to E.164 format
+func normalizePhone(input string) (string, bool) {
+	international := strings.HasPrefix(input, "+")
+	var b strings.Builder
+	for _, r := range input {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' || r == ' ' || r == '(' || r == ')' || r == '-':
+		default:
+			return "", false
+		}
+	}
+	digits := b.String()
+
+	national := digits
+	if rest, ok := strings.CutPrefix(digits, "55"); ok && (international || len(digits) > 11) {
+		national = rest
+	} else if international {
+		return "", false
+	}
+	if !brazilianPhonePattern.MatchString(national) {
+		return "", false
+	}
+	return "+55" + national, true
+}
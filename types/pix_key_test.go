@@ -0,0 +1,63 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParsePixKey(t *testing.T) {
+	tests := []struct {
+		input   string
+		keyType PixKeyType
+		value   string
+	}{
+		{"529.982.247-25", PixKeyTypeCPF, "52998224725"},
+		{"11.222.333/0001-81", PixKeyTypeCNPJ, "11222333000181"},
+		{"11222333000181", PixKeyTypeCNPJ, "11222333000181"},
+		{"12.ABC.345/01DE-35", PixKeyTypeCNPJ, "12ABC34501DE35"},
+		{" Maria@Example.com ", PixKeyTypeEmail, "maria@example.com"},
+		{"123E4567-E89B-12D3-A456-426614174000", PixKeyTypeRandom, "123e4567-e89b-12d3-a456-426614174000"},
+		{"+55 (11) 98765-4321", PixKeyTypePhone, "+5511987654321"},
+		{"(11) 98765-4321", PixKeyTypePhone, "+5511987654321"},
+		{"11987654321", PixKeyTypePhone, "+5511987654321"},
+		{"5511987654321", PixKeyTypePhone, "+5511987654321"},
+		{"1133334444", PixKeyTypePhone, "+551133334444"},
+	}
+
+	for _, tt := range tests {
+		key, err := ParsePixKey(tt.input)
+		if err != nil || key.Type != tt.keyType || key.Value != tt.value {
+			t.Errorf("ParsePixKey(%q) = %+v, %v; want %s %s", tt.input, key, err, tt.keyType, tt.value)
+		}
+	}
+
+	for _, input := range []string{"", "abc", "user@example", "52898224724", "+1 202 555 0100", "1234"} {
+		if _, err := ParsePixKey(input); !errors.Is(err, ErrInvalidPixKey) {
+			t.Errorf("ParsePixKey(%q) = %v; want ErrInvalidPixKey", input, err)
+		}
+	}
+}
+
+func TestParsePixKeyAmbiguous(t *testing.T) {
+	// 11 digits that are both a valid CPF and a mobile number in area code 19
+	key, err := ParsePixKey("19912345622")
+	if !errors.Is(err, ErrAmbiguousPixKey) {
+		t.Fatalf("ParsePixKey = %+v, %v; want ErrAmbiguousPixKey", key, err)
+	}
+	var ambiguous *AmbiguousPixKeyError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected *AmbiguousPixKeyError, got %T", err)
+	}
+	if got := fmt.Sprint(ambiguous.Candidates); got != "[19912345622 +5519912345622]" || key != ambiguous.Candidates[0] {
+		t.Errorf("candidates = %s, key = %v", got, key)
+	}
+
+	// Punctuation settles the type
+	if key, err := ParsePixKey("199.123.456-22"); err != nil || key.Type != PixKeyTypeCPF {
+		t.Errorf("formatted CPF = %+v, %v", key, err)
+	}
+	if key, err := ParsePixKey("(19) 91234-5622"); err != nil || key.Type != PixKeyTypePhone {
+		t.Errorf("formatted phone = %+v, %v", key, err)
+	}
+}
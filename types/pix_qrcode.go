@@ -112,7 +112,7 @@ type AdditionalInfo struct {
 // DiscountFixedDate represents discount fixed date
 type DiscountFixedDate struct {
 	Data             string  `json:"data"`
-	ValorDescontoAbs float64 `json:"valorDescontoAbs,omitempty"`
+	ValorDescontoAbs Money   `json:"valorDescontoAbs,omitzero"`
 	ValorPerc        float64 `json:"valorPerc,omitempty"`
 }
 
@@ -125,7 +125,7 @@ type StaticQRCodeRequest struct {
 	PostalCode    *string      `json:"postalCode,omitempty"`    // Optional
 	Identificador string       `json:"identificador"`           // Required: QR code identifier
 	Descricao     *string      `json:"descricao,omitempty"`     // Optional
-	Amount        *float64     `json:"amount,omitempty"`        // Optional: Value in reals
+	Amount        *Money       `json:"amount,omitempty"`        // Optional: Value in reals
 	InvoiceDate   *Date        `json:"invoiceDate,omitempty"`   // Optional
 	InvoiceQRCode *bool        `json:"invoiceQrCode,omitempty"` // Optional
 }
@@ -135,13 +135,13 @@ type StaticQRCodeRequest struct {
 type DynamicQRCodeRequest struct {
 	AccountID             int64               `json:"accountId"`                       // Required
 	AddressKey            string              `json:"addressKey"`                      // Required: PIX key
-	Amount                float64             `json:"amount"`                          // Required: minimum 1
+	Amount                Money               `json:"amount"`                          // Required: minimum 1
 	QRCodeFormat          QRCodeFormat        `json:"qrCodeFormat"`                    // Required: 1=BR CODE, 2=BASE64, 3=IMAGE BASE64
 	PostalCode            *string             `json:"postalCode,omitempty"`            // Optional
 	ExpirationSeconds     *int64              `json:"expirationSeconds,omitempty"`     // Optional
 	ValityAfterExpiration *int32              `json:"valityAfterExpiration,omitempty"` // Optional: Days
 	DueDate               *string             `json:"dueDate,omitempty"`               // Optional
-	PayerCpfCnpj          *string             `json:"payerCpfCnpj,omitempty"`          // Optional
+	PayerCpfCnpj          *Document           `json:"payerCpfCnpj,omitempty"`          // Optional
 	PayerType             *PayerType          `json:"payerType,omitempty"`             // Optional: 1=PF, 2=PJ
 	PayerName             *string             `json:"payerName,omitempty"`             // Optional
 	InterestValue         *float64            `json:"interestValue,omitempty"`         // Optional
@@ -186,8 +186,8 @@ type QRCodeQueryProcessingRequest struct {
 
 // Estatisticas represents statistics in query processing response
 type Estatisticas struct {
-	QuantidadeRecebida *int32   `json:"quantidadeRecebida,omitempty"`
-	ValorRecebido      *float64 `json:"valorRecebido,omitempty"`
+	QuantidadeRecebida *int32 `json:"quantidadeRecebida,omitempty"`
+	ValorRecebido      *Money `json:"valorRecebido,omitempty"`
 }
 
 // InfoAdicional represents additional info in query processing response
@@ -219,7 +219,7 @@ type QRCodeQueryProcessingResponse struct {
 	DataAbertura                  *string         `json:"dataAbertura,omitempty"`
 	Referencia                    *string         `json:"referencia,omitempty"`
 	Info                          *string         `json:"info,omitempty"`
-	Valor                         *float64        `json:"valor,omitempty"`
+	Valor                         *Money          `json:"valor,omitempty"`
 	TipoQRCode                    *string         `json:"tipoQRCode,omitempty"`
 	EndToEnd                      *string         `json:"endToEnd,omitempty"`
 	Estatisticas                  *Estatisticas   `json:"estatisticas,omitempty"`
@@ -236,11 +236,11 @@ type QRCodeQueryProcessingResponse struct {
 	PagadorCpf                    *string         `json:"pagadorCpf,omitempty"`
 	PagadorCnpj                   *string         `json:"pagadorCnpj,omitempty"`
 	PagadorNome                   *string         `json:"pagadorNome,omitempty"`
-	ValorFinal                    *float64        `json:"valorFinal,omitempty"`
-	ValorJuros                    *float64        `json:"valorJuros,omitempty"`
-	ValorMulta                    *float64        `json:"valorMulta,omitempty"`
-	ValorDesconto                 *float64        `json:"valorDesconto,omitempty"`
-	ValorAbatimento               *float64        `json:"valorAbatimento,omitempty"`
+	ValorFinal                    *Money          `json:"valorFinal,omitempty"`
+	ValorJuros                    *Money          `json:"valorJuros,omitempty"`
+	ValorMulta                    *Money          `json:"valorMulta,omitempty"`
+	ValorDesconto                 *Money          `json:"valorDesconto,omitempty"`
+	ValorAbatimento               *Money          `json:"valorAbatimento,omitempty"`
 	SolicitacaoPagador            *string         `json:"solicitacaoPagador,omitempty"`
 	CodMun                        *string         `json:"codMun,omitempty"`
 	TipoChave                     *string         `json:"tipoChave,omitempty"`
@@ -249,7 +249,7 @@ type QRCodeQueryProcessingResponse struct {
 	Identificador                 *string         `json:"identificador,omitempty"`
 	Descricao                     *string         `json:"descricao,omitempty"`
 	TipoPix                       *PIXType        `json:"tipoPix,omitempty"` // SAQUE, TROCO, NORMAL
-	ValorTrocoSaque               *float64        `json:"valorTrocoSaque,omitempty"`
+	ValorTrocoSaque               *Money          `json:"valorTrocoSaque,omitempty"`
 	ModalidadeAgente              *string         `json:"modalidadeAgente,omitempty"`
 	PrestadorDoServicoDeSaque     *string         `json:"prestadorDoServicoDeSaque,omitempty"`
 	RecebedorNomeFantasia         *string         `json:"recebedorNomeFantasia,omitempty"`
@@ -277,15 +277,15 @@ type DecodeQRCodeV3Request struct {
 // BillingDueDate represents billing due date information
 type BillingDueDate struct {
 	// Add fields based on actual API response structure
-	Vencimento *string  `json:"vencimento,omitempty"`
-	Valor      *float64 `json:"valor,omitempty"`
+	Vencimento *string `json:"vencimento,omitempty"`
+	Valor      *Money  `json:"valor,omitempty"`
 	// Add more fields as needed from API spec
 }
 
 // ImmediateBilling represents immediate billing information
 type ImmediateBilling struct {
 	// Add fields based on actual API response structure
-	Valor *float64 `json:"valor,omitempty"`
+	Valor *Money `json:"valor,omitempty"`
 	// Add more fields as needed from API spec
 }
 
@@ -367,3 +367,60 @@ type (
 	// QRCodeQueryResponse is deprecated, use QRCodeQueryProcessingResponse
 	QRCodeQueryResponse = QRCodeQueryProcessingResponse
 )
+
+// Validate checks the account, identifier, format and optional amount
+func (r *StaticQRCodeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("identificador", r.Identificador)
+	oneOf(&f, "qrCodeFormat", r.QRCodeFormat, QRCodeFormatBRCode, QRCodeFormatBase64, QRCodeFormatImageBase64)
+	optionalPositive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the account, key, amount, format, due date and payer data
+func (r *DynamicQRCodeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("addressKey", r.AddressKey)
+	if r.Amount < Real {
+		f.add("amount", ErrInvalidField, "amount must be at least R$ 1,00")
+	}
+	oneOf(&f, "qrCodeFormat", r.QRCodeFormat, QRCodeFormatBRCode, QRCodeFormatBase64, QRCodeFormatImageBase64)
+	f.optionalDateTime("dueDate", r.DueDate)
+	f.optionalDocument("payerCpfCnpj", r.PayerCpfCnpj)
+	optionalOneOf(&f, "payerType", r.PayerType, PayerTypePF, PayerTypePJ)
+	return f.err()
+}
+
+// Validate checks the account, QR code value and format
+func (r *QRCodeQueryProcessingRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("qrCodeValue", r.QRCodeValue)
+	oneOf(&f, "qrCodeFormat", r.QRCodeFormat, QRCodeFormatBRCode, QRCodeFormatBase64, QRCodeFormatImageBase64)
+	return f.err()
+}
+
+// Validate checks the account, QR code data and IBGE city code
+func (r *DecodeQRCodeV3Request) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if f.required("cityCode", r.CityCode) && (len(r.CityCode) != 7 || !isDigits(r.CityCode)) {
+		f.add("cityCode", ErrInvalidField, "cityCode must be a 7-digit IBGE code")
+	}
+	f.required("qrCodeData", r.QRCodeData)
+	f.requiredID("accountId", r.AccountID)
+	return f.err()
+}
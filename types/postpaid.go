@@ -4,14 +4,14 @@ package types
 
 // FormattedAmountDTO represents FormattedAmountDTO from API spec
 type FormattedAmountDTO struct {
-	Amount       float64 `json:"amount"`       // number
-	CurrencyCode int32   `json:"currencyCode"` // int32
+	Amount       Money `json:"amount"`       // reais
+	CurrencyCode int32 `json:"currencyCode"` // int32
 }
 
 // AmountDTO represents AmountDTO from API spec
 type AmountDTO struct {
-	Amount       int32 `json:"amount"`       // int32
-	CurrencyCode int32 `json:"currencyCode"` // int32
+	Amount       MoneyCents `json:"amount"`       // cents
+	CurrencyCode int32      `json:"currencyCode"` // int32
 }
 
 // SourceAudit represents SourceAudit from API spec
@@ -44,3 +44,25 @@ type UpdatePaysmartProductRequest struct {
 }
 
 // Note: SearchProductLimitRequest is defined in limit.go with official API schema
+
+// Validate checks the number of days
+func (r *ProductLimitSchedulingRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if r.LimitDays <= 0 {
+		f.add("limitDays", ErrInvalidField, "limitDays must be greater than zero")
+	}
+	return f.err()
+}
+
+// Validate checks the product name
+func (r *CreatePaysmartProductRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("name", r.Name)
+	return f.err()
+}
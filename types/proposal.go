@@ -102,3 +102,27 @@ type ProposalImageResponse struct {
 type ProposalImageListResponse struct {
 	Images []ProposalImageResponse `json:"items"`
 }
+
+// Validate checks the optional birth date
+func (r *UpdateProposalRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.optionalDate("birthDate", r.BirthDate)
+	return f.err()
+}
+
+// Validate checks the proposal, action and, for rejections, the reason
+func (r *ProcessProposalRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("proposalId", r.ProposalID)
+	oneOf(&f, "action", r.Action, "APPROVE", "REJECT")
+	if r.Action == "REJECT" && (r.RejectionReason == nil || *r.RejectionReason == "") {
+		f.add("rejectionReason", ErrRequiredField, "rejectionReason is required when the proposal is rejected")
+	}
+	return f.err()
+}
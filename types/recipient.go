@@ -60,3 +60,26 @@ type GetRecipientResponse struct {
 	Recipient *RecipientDTO `json:"recipient,omitempty"`
 	DaCode    int32         `json:"da_code"` // int32
 }
+
+// Validate checks the recipient document and name
+func (r *CreateRecipientRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.document("recipientDocument", Document(r.RecipientDocument))
+	f.required("recipientName", r.RecipientName)
+	return f.err()
+}
+
+// Validate checks the recipient identifier, document and name
+func (r *UpdateRecipientRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("id", r.ID)
+	f.document("recipientDocument", Document(r.RecipientDocument))
+	f.required("recipientName", r.RecipientName)
+	return f.err()
+}
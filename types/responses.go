@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // CreateAccountResponse represents the response for account creation
 // POST /accounts/proposal response from API spec
@@ -98,7 +101,7 @@ type InternalTransferResponse struct {
 	Message            string     `json:"message"`
 	TransactionID      int64      `json:"transactionId"`
 	DateTimeTransfer   *time.Time `json:"dateTimeTransfer,omitempty"`
-	Amount             int64      `json:"amount"`
+	Amount             MoneyCents `json:"amount"`
 	RecipientAccountID *int64     `json:"recipientAccountId,omitempty"`
 	RecipientName      *string    `json:"recipientName,omitempty"`
 	AuthenticationCode *string    `json:"authenticationCode,omitempty"`
@@ -107,20 +110,20 @@ type InternalTransferResponse struct {
 // BankTransferRequest represents bank transfer request
 type BankTransferRequest struct {
 	Recipient         BankTransferRecipient `json:"recipient"`
-	TransactionAmount int64                 `json:"transactionAmount"`
+	TransactionAmount MoneyCents            `json:"transactionAmount"`
 	SchedulingDate    *string               `json:"schedulingDate,omitempty"`
 	Description       *string               `json:"description,omitempty"`
 }
 
 // BankTransferRecipient represents bank transfer recipient
 type BankTransferRecipient struct {
-	Name         string  `json:"name"`
-	Document     string  `json:"document"`
-	BankCode     string  `json:"bankCode"`
-	Branch       string  `json:"branch"`
-	Account      string  `json:"account"`
-	AccountDigit *string `json:"accountDigit,omitempty"`
-	AccountType  string  `json:"accountType"`
+	Name         string   `json:"name"`
+	Document     Document `json:"document"`
+	BankCode     string   `json:"bankCode"`
+	Branch       string   `json:"branch"`
+	Account      string   `json:"account"`
+	AccountDigit *string  `json:"accountDigit,omitempty"`
+	AccountType  string   `json:"accountType"`
 }
 
 // BankTransferResponse represents bank transfer response
@@ -154,9 +157,9 @@ type BatchTransferResponse struct {
 // BatchTransferItem represents a single item in batch transfer
 type BatchTransferItem struct {
 	RecipientAccountID int64             `json:"recipientAccountId"`
-	Amount             int64             `json:"amount"`
+	Amount             MoneyCents        `json:"amount"`
 	Status             TransactionStatus `json:"status"`
-	Error              *string `json:"error,omitempty"`
+	Error              *string           `json:"error,omitempty"`
 }
 
 // CancelInternalTransferRequest represents cancel internal transfer request
@@ -172,28 +175,28 @@ type CancelInternalTransferResponse struct {
 
 // TransferByIDRequest represents transfer by ID request
 type TransferByIDRequest struct {
-	TargetAccountID int64   `json:"targetAccountId"`
-	Amount          int64   `json:"amount"`
-	MerchantName    *string `json:"merchantName,omitempty"`
+	TargetAccountID int64      `json:"targetAccountId"`
+	Amount          MoneyCents `json:"amount"`
+	MerchantName    *string    `json:"merchantName,omitempty"`
 }
 
 // BillPaymentResponse represents bill payment response
 type BillPaymentResponse struct {
-	Message            string     `json:"message"`
-	AuthenticationCode *string    `json:"authenticationCode,omitempty"`
-	AmountPaid         *int64     `json:"amountPaid,omitempty"`
-	PaymentDate        *time.Time `json:"paymentDate,omitempty"`
+	Message            string      `json:"message"`
+	AuthenticationCode *string     `json:"authenticationCode,omitempty"`
+	AmountPaid         *MoneyCents `json:"amountPaid,omitempty"`
+	PaymentDate        *time.Time  `json:"paymentDate,omitempty"`
 }
 
 // GetBillInfoResponse represents bill info response
 type GetBillInfoResponse struct {
-	OriginalValue int64   `json:"originalValue"`
-	DueDate       string  `json:"dueDate"`
-	Assignor      *string `json:"assignor,omitempty"`
-	Payer         *string `json:"payer,omitempty"`
-	Amount        *int64  `json:"amount,omitempty"`
-	Fine          *int64  `json:"fine,omitempty"`
-	Discount      *int64  `json:"discount,omitempty"`
+	OriginalValue MoneyCents  `json:"originalValue"`
+	DueDate       string      `json:"dueDate"`
+	Assignor      *string     `json:"assignor,omitempty"`
+	Payer         *string     `json:"payer,omitempty"`
+	Amount        *MoneyCents `json:"amount,omitempty"`
+	Fine          *MoneyCents `json:"fine,omitempty"`
+	Discount      *MoneyCents `json:"discount,omitempty"`
 }
 
 // CancelBillResponse represents cancel bill response
@@ -211,7 +214,7 @@ type ScheduledBillsResponse struct {
 type ScheduledBill struct {
 	SchedulingID int64            `json:"schedulingId"`
 	Digitable    string           `json:"digitable"`
-	Amount       int64            `json:"amount"`
+	Amount       MoneyCents       `json:"amount"`
 	DueDate      string           `json:"dueDate"`
 	Status       SchedulingStatus `json:"status"`
 }
@@ -226,17 +229,17 @@ type BankslipItem struct {
 	ID            int64          `json:"id"`
 	DigitableLine string         `json:"digitableLine"`
 	Barcode       string         `json:"barcode"`
-	Amount        int64          `json:"amount"`
+	Amount        MoneyCents     `json:"amount"`
 	DueDate       string         `json:"dueDate"`
 	Status        BankSlipStatus `json:"status"`
-	DownloadURL   *string `json:"downloadUrl,omitempty"`
+	DownloadURL   *string        `json:"downloadUrl,omitempty"`
 }
 
 // CreateBankslipRequest represents create bankslip request
 type CreateBankslipRequest struct {
-	Amount      int64   `json:"amount"`
-	DueDate     string  `json:"dueDate"`
-	Description *string `json:"description,omitempty"`
+	Amount      MoneyCents `json:"amount"`
+	DueDate     string     `json:"dueDate"`
+	Description *string    `json:"description,omitempty"`
 }
 
 // CreateBankslipResponse represents create bankslip response
@@ -250,13 +253,13 @@ type CreateBankslipResponse struct {
 
 // BankslipV2Request represents create bankslip v2 request
 type BankslipV2Request struct {
-	AccountID     int64   `json:"accountId"`
-	Amount        int64   `json:"amount"`  // Amount in cents
-	DueDate       string  `json:"dueDate"` // Format: YYYY-MM-DD
-	PayerDocument string  `json:"payerDocument"`
-	PayerName     string  `json:"payerName"`
-	Description   *string `json:"description,omitempty"`
-	Instructions  *string `json:"instructions,omitempty"`
+	AccountID     int64      `json:"accountId"`
+	Amount        MoneyCents `json:"amount"`  // Amount in cents
+	DueDate       string     `json:"dueDate"` // Format: YYYY-MM-DD
+	PayerDocument Document   `json:"payerDocument"`
+	PayerName     string     `json:"payerName"`
+	Description   *string    `json:"description,omitempty"`
+	Instructions  *string    `json:"instructions,omitempty"`
 }
 
 // BankslipV2Response represents create bankslip v2 response
@@ -265,7 +268,7 @@ type BankslipV2Response struct {
 	Barcode       string         `json:"barcode"`
 	DigitableLine string         `json:"digitableLine"`
 	PDFBase64     string         `json:"pdfBase64"`
-	Amount        int64          `json:"amount"`
+	Amount        MoneyCents     `json:"amount"`
 	DueDate       string         `json:"dueDate"`
 	Status        BankSlipStatus `json:"status"`
 }
@@ -277,9 +280,9 @@ type DepositOrdersResponse struct {
 
 // DepositOrder represents a deposit order
 type DepositOrder struct {
-	ID       int64            `json:"id"`
-	Amount   int64            `json:"amount"`
-	Covenant string           `json:"covenant"`
+	ID       int64              `json:"id"`
+	Amount   MoneyCents         `json:"amount"`
+	Covenant string             `json:"covenant"`
 	Status   DepositOrderStatus `json:"status"`
 }
 
@@ -292,17 +295,17 @@ type CreateDepositOrderResponse struct {
 
 // DoRechargeRequest represents recharge request
 type DoRechargeRequest struct {
-	AreaCode        string  `json:"areaCode"`
-	PhoneNumber     string  `json:"phoneNumber"`
-	RechargeValue   int64   `json:"rechargeValue"`
-	FreeDescription *string `json:"freeDescription,omitempty"`
+	AreaCode        string     `json:"areaCode"`
+	PhoneNumber     string     `json:"phoneNumber"`
+	RechargeValue   MoneyCents `json:"rechargeValue"`
+	FreeDescription *string    `json:"freeDescription,omitempty"`
 }
 
 // DoRechargeResponse represents recharge response
 type DoRechargeResponse struct {
-	ProviderName       string  `json:"providerName"`
-	RechargeValue      int64   `json:"rechargeValue"`
-	AuthenticationCode *string `json:"authenticationCode,omitempty"`
+	ProviderName       string     `json:"providerName"`
+	RechargeValue      MoneyCents `json:"rechargeValue"`
+	AuthenticationCode *string    `json:"authenticationCode,omitempty"`
 }
 
 // RechargeValuesResponse represents recharge values response
@@ -313,15 +316,15 @@ type RechargeValuesResponse struct {
 
 // RechargeVoucher represents a recharge voucher option
 type RechargeVoucher struct {
-	Value       int64   `json:"value"`
-	Description *string `json:"description,omitempty"`
+	Value       MoneyCents `json:"value"`
+	Description *string    `json:"description,omitempty"`
 }
 
 // DoVoucherRechargeRequest represents voucher recharge request
 type DoVoucherRechargeRequest struct {
-	ProviderID string  `json:"providerId"`
-	Amount     int64   `json:"amount"`
-	SignerCode *string `json:"signerCode,omitempty"`
+	ProviderID string     `json:"providerId"`
+	Amount     MoneyCents `json:"amount"`
+	SignerCode *string    `json:"signerCode,omitempty"`
 }
 
 // VoucherProvidersResponse represents voucher providers response
@@ -331,10 +334,10 @@ type VoucherProvidersResponse struct {
 
 // VoucherProvider represents a voucher provider
 type VoucherProvider struct {
-	ProviderID   string `json:"providerId"`
-	ProviderName string `json:"providerName"`
-	MinValue     *int64 `json:"minValue,omitempty"`
-	MaxValue     *int64 `json:"maxValue,omitempty"`
+	ProviderID   string      `json:"providerId"`
+	ProviderName string      `json:"providerName"`
+	MinValue     *MoneyCents `json:"minValue,omitempty"`
+	MaxValue     *MoneyCents `json:"maxValue,omitempty"`
 }
 
 // SimpleQRCodePaymentRequest represents simple QR code payment request
@@ -357,11 +360,11 @@ type ParseQRCodeRequest struct {
 
 // ParseQRCodeResponse represents parse QR code response
 type ParseQRCodeResponse struct {
-	MerchantName      *string `json:"merchantName,omitempty"`
-	TransactionAmount *int64  `json:"transactionAmount,omitempty"`
-	MerchantCity      *string `json:"merchantCity,omitempty"`
-	PixKey            *string `json:"pixKey,omitempty"`
-	TxID              *string `json:"txId,omitempty"`
+	MerchantName      *string     `json:"merchantName,omitempty"`
+	TransactionAmount *MoneyCents `json:"transactionAmount,omitempty"`
+	MerchantCity      *string     `json:"merchantCity,omitempty"`
+	PixKey            *string     `json:"pixKey,omitempty"`
+	TxID              *string     `json:"txId,omitempty"`
 }
 
 // QRCodePublicKeyResponse represents QR code public key response
@@ -429,24 +432,24 @@ type UpdateCreditExpirationRequest struct {
 
 // CreditsInfoResponse represents credits info response
 type CreditsInfoResponse struct {
-	Amount       int64               `json:"amount"`
+	Amount       MoneyCents          `json:"amount"`
 	Transactions []CreditTransaction `json:"transactions,omitempty"`
 }
 
 // CreditTransaction represents a credit transaction
 type CreditTransaction struct {
 	ID          int64      `json:"id"`
-	Amount      int64      `json:"amount"`
+	Amount      MoneyCents `json:"amount"`
 	Date        *time.Time `json:"date,omitempty"`
 	Description *string    `json:"description,omitempty"`
 }
 
 // PostPaidPaymentBalanceRequest represents post-paid payment balance request
 type PostPaidPaymentBalanceRequest struct {
-	AccountID       int64   `json:"accountId"`
-	Amount          int64   `json:"amount"`
-	ScheduleDate    *string `json:"scheduleDate,omitempty"`
-	PaymentTypeEnum *string `json:"paymentTypeEnum,omitempty"`
+	AccountID       int64      `json:"accountId"`
+	Amount          MoneyCents `json:"amount"`
+	ScheduleDate    *string    `json:"scheduleDate,omitempty"`
+	PaymentTypeEnum *string    `json:"paymentTypeEnum,omitempty"`
 }
 
 // PostPaidPaymentResponse represents post-paid payment response
@@ -457,10 +460,10 @@ type PostPaidPaymentResponse struct {
 
 // PostPaidInstallmentSimulationRequest represents installment simulation request
 type PostPaidInstallmentSimulationRequest struct {
-	AccountID       int64  `json:"accountId"`
-	Amount          int64  `json:"amount"`
-	DownPayment     *int64 `json:"downPayment,omitempty"`
-	NumInstallments []int  `json:"numInstallments"`
+	AccountID       int64       `json:"accountId"`
+	Amount          MoneyCents  `json:"amount"`
+	DownPayment     *MoneyCents `json:"downPayment,omitempty"`
+	NumInstallments []int       `json:"numInstallments"`
 }
 
 // PostPaidInstallmentSimulationResponse represents installment simulation response
@@ -470,19 +473,19 @@ type PostPaidInstallmentSimulationResponse struct {
 
 // InstallmentSimulation represents an installment simulation
 type InstallmentSimulation struct {
-	NumInstallments      int     `json:"numInstallments"`
-	AmountPerInstallment int64   `json:"amountPerInstallment"`
-	MonthlyInterest      float64 `json:"monthlyInterest"`
-	TotalAmount          int64   `json:"totalAmount"`
+	NumInstallments      int        `json:"numInstallments"`
+	AmountPerInstallment MoneyCents `json:"amountPerInstallment"`
+	MonthlyInterest      float64    `json:"monthlyInterest"`
+	TotalAmount          MoneyCents `json:"totalAmount"`
 }
 
 // PostPaidInstallmentRequest represents installment request
 type PostPaidInstallmentRequest struct {
-	AccountID       int64   `json:"accountId"`
-	Amount          int64   `json:"amount"`
-	DownPayment     *int64  `json:"downPayment,omitempty"`
-	NumInstallments int     `json:"numInstallments"`
-	InvoiceDate     *string `json:"invoiceDate,omitempty"`
+	AccountID       int64       `json:"accountId"`
+	Amount          MoneyCents  `json:"amount"`
+	DownPayment     *MoneyCents `json:"downPayment,omitempty"`
+	NumInstallments int         `json:"numInstallments"`
+	InvoiceDate     *string     `json:"invoiceDate,omitempty"`
 }
 
 // PostPaidInstallmentPixResponse represents installment via PIX response
@@ -526,7 +529,7 @@ type ScheduledOperationsResponse struct {
 // ScheduledPixOperation represents a scheduled PIX operation
 type ScheduledPixOperation struct {
 	ID            int64            `json:"id"`
-	Amount        int64            `json:"amount"`
+	Amount        MoneyCents       `json:"amount"`
 	ScheduledDate string           `json:"scheduledDate"`
 	Status        SchedulingStatus `json:"status"`
 }
@@ -534,7 +537,7 @@ type ScheduledPixOperation struct {
 // ScheduledInvoice represents a scheduled invoice
 type ScheduledInvoice struct {
 	ID            int64            `json:"id"`
-	Amount        int64            `json:"amount"`
+	Amount        MoneyCents       `json:"amount"`
 	ScheduledDate string           `json:"scheduledDate"`
 	Status        SchedulingStatus `json:"status"`
 }
@@ -548,7 +551,231 @@ type IntegrationStatusResponse struct {
 
 // UpdatePostPaidAccountRequest represents post-paid account update request
 type UpdatePostPaidAccountRequest struct {
-	AccountID   int64  `json:"accountId"`
-	DueDate     *int   `json:"dueDate,omitempty"`     // Day of month (1-28)
-	CreditLimit *int64 `json:"creditLimit,omitempty"` // Amount in cents
+	AccountID   int64       `json:"accountId"`
+	DueDate     *int        `json:"dueDate,omitempty"`     // Day of month (1-28)
+	CreditLimit *MoneyCents `json:"creditLimit,omitempty"` // Amount in cents
+}
+
+// Validate checks the recipient, amount and optional scheduling date
+func (r *BankTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("recipient.name", r.Recipient.Name)
+	f.document("recipient.document", r.Recipient.Document)
+	f.required("recipient.bankCode", r.Recipient.BankCode)
+	f.required("recipient.branch", r.Recipient.Branch)
+	f.required("recipient.account", r.Recipient.Account)
+	f.required("recipient.accountType", r.Recipient.AccountType)
+	positive(&f, "transactionAmount", r.TransactionAmount)
+	f.optionalDate("schedulingDate", r.SchedulingDate)
+	return f.err()
+}
+
+// Validate checks the target account and amount
+func (r *TransferByIDRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("targetAccountId", r.TargetAccountID)
+	positive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the amount and due date
+func (r *CreateBankslipRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	positive(&f, "amount", r.Amount)
+	f.date("dueDate", r.DueDate)
+	return f.err()
+}
+
+// Validate checks the account, amount, due date and payer
+func (r *BankslipV2Request) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	positive(&f, "amount", r.Amount)
+	f.date("dueDate", r.DueDate)
+	f.document("payerDocument", r.PayerDocument)
+	f.required("payerName", r.PayerName)
+	return f.err()
+}
+
+// Validate checks the tag
+func (r *UpdateVirtualCardTagRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("tag", r.Tag)
+	return f.err()
+}
+
+// Validate checks the card number and last 4 digits
+func (r *BindAnonymousCardRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("cardNumber", r.CardNumber)
+	if f.required("lastFourDigits", r.LastFourDigits) && (len(r.LastFourDigits) != 4 || !isDigits(r.LastFourDigits)) {
+		f.add("lastFourDigits", ErrInvalidField, "lastFourDigits must have 4 digits")
+	}
+	return f.err()
+}
+
+// Validate checks the transaction
+func (r *CancelInternalTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("transactionId", r.TransactionID)
+	return f.err()
+}
+
+// Validate checks the phone number and amount
+func (r *DoRechargeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("areaCode", r.AreaCode)
+	f.required("phoneNumber", r.PhoneNumber)
+	positive(&f, "rechargeValue", r.RechargeValue)
+	return f.err()
+}
+
+// Validate checks the provider and amount
+func (r *DoVoucherRechargeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("providerId", r.ProviderID)
+	positive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the QR code
+func (r *SimpleQRCodePaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("qrCode", r.QRCode)
+	return f.err()
+}
+
+// Validate checks the QR code
+func (r *ParseQRCodeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("qrCode", r.QRCode)
+	return f.err()
+}
+
+// Validate checks the image type and data
+func (r *UpdateProposalImageRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("imageType", r.ImageType)
+	f.required("imageData", r.ImageData)
+	return f.err()
+}
+
+// Validate checks the credit and expiration date
+func (r *UpdateCreditExpirationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.date("expirationDate", r.ExpirationDate)
+	f.requiredID("id", r.ID)
+	return f.err()
+}
+
+// Validate checks the account, amount and optional scheduling date
+func (r *PostPaidPaymentBalanceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	positive(&f, "amount", r.Amount)
+	f.optionalDate("scheduleDate", r.ScheduleDate)
+	return f.err()
+}
+
+// Validate checks the account, amounts and installment counts
+func (r *PostPaidInstallmentSimulationRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	positive(&f, "amount", r.Amount)
+	optionalNonNegative(&f, "downPayment", r.DownPayment)
+	if len(r.NumInstallments) == 0 {
+		f.add("numInstallments", ErrRequiredField, "numInstallments is required")
+	}
+	for i, n := range r.NumInstallments {
+		if n <= 0 {
+			f.add(fmt.Sprintf("numInstallments[%d]", i), ErrInvalidField, "installment count must be greater than zero")
+		}
+	}
+	return f.err()
+}
+
+// Validate checks the account, amounts, installment count and optional invoice date
+func (r *PostPaidInstallmentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	positive(&f, "amount", r.Amount)
+	optionalNonNegative(&f, "downPayment", r.DownPayment)
+	if r.NumInstallments <= 0 {
+		f.add("numInstallments", ErrInvalidField, "numInstallments must be greater than zero")
+	}
+	f.optionalDate("invoiceDate", r.InvoiceDate)
+	return f.err()
+}
+
+// Validate checks the account and schedule
+func (r *CancelInvoiceScheduleRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.requiredID("scheduleId", r.ScheduleID)
+	return f.err()
+}
+
+// Validate checks the account, optional due day and optional credit limit
+func (r *UpdatePostPaidAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	if r.DueDate != nil {
+		between(&f, "dueDate", *r.DueDate, 1, 28)
+	}
+	optionalNonNegative(&f, "creditLimit", r.CreditLimit)
+	return f.err()
 }
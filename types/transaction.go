@@ -1,13 +1,16 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // InternalTransferRequest represents an internal transfer between accounts
 type InternalTransferRequest struct {
-	RecipientAccountID int64   `json:"recipientAccountId"`
-	TransferAmount     int64   `json:"transferAmount"` // Amount in cents
-	FreeDescription    *string `json:"freeDescription,omitempty"`
-	ScheduledDate      *string `json:"scheduledDate,omitempty"` // Format: YYYY-MM-DD
+	RecipientAccountID int64      `json:"recipientAccountId"`
+	TransferAmount     MoneyCents `json:"transferAmount"` // Amount in cents
+	FreeDescription    *string    `json:"freeDescription,omitempty"`
+	ScheduledDate      *string    `json:"scheduledDate,omitempty"` // Format: YYYY-MM-DD
 
 	// Location (for fraud prevention)
 	Latitude  *string `json:"latitude,omitempty"`
@@ -16,9 +19,9 @@ type InternalTransferRequest struct {
 
 // IDToIDTransferRequest represents a transfer using document ID
 type IDToIDTransferRequest struct {
-	RecipientDocument string  `json:"recipientDocument"` // CPF or CNPJ
-	TransferAmount    int64   `json:"transferAmount"`    // Amount in cents
-	FreeDescription   *string `json:"freeDescription,omitempty"`
+	RecipientDocument Document   `json:"recipientDocument"` // CPF or CNPJ
+	TransferAmount    MoneyCents `json:"transferAmount"`    // Amount in cents
+	FreeDescription   *string    `json:"freeDescription,omitempty"`
 
 	// Location (for fraud prevention)
 	Latitude  *string `json:"latitude,omitempty"`
@@ -33,10 +36,10 @@ type BatchTransferRequest struct {
 
 // ArrangementTransferRequest represents an arrangement transfer
 type ArrangementTransferRequest struct {
-	RecipientAccountID int64   `json:"recipientAccountId"`
-	TransferAmount     int64   `json:"transferAmount"` // Amount in cents
-	ArrangementType    string  `json:"arrangementType"`
-	FreeDescription    *string `json:"freeDescription,omitempty"`
+	RecipientAccountID int64      `json:"recipientAccountId"`
+	TransferAmount     MoneyCents `json:"transferAmount"` // Amount in cents
+	ArrangementType    string     `json:"arrangementType"`
+	FreeDescription    *string    `json:"freeDescription,omitempty"`
 }
 
 // CancelTransferRequest represents the request to cancel a scheduled transfer
@@ -68,13 +71,13 @@ type ExternalTransferRequest struct {
 	AccountType  string  `json:"accountType"` // e.g., "CHECKING", "SAVINGS"
 
 	// Recipient details
-	RecipientDocument string `json:"recipientDocument"` // CPF or CNPJ
-	RecipientName     string `json:"recipientName"`
+	RecipientDocument Document `json:"recipientDocument"` // CPF or CNPJ
+	RecipientName     string   `json:"recipientName"`
 
 	// Transfer details
-	TransferType string  `json:"transferType"` // "TED" or "DOC"
-	Amount       int64   `json:"amount"`       // Amount in cents
-	Description  *string `json:"description,omitempty"`
+	TransferType string     `json:"transferType"` // "TED" or "DOC"
+	Amount       MoneyCents `json:"amount"`       // Amount in cents
+	Description  *string    `json:"description,omitempty"`
 
 	// Scheduling (optional)
 	ScheduledDate *string `json:"scheduledDate,omitempty"` // Format: YYYY-MM-DD
@@ -90,7 +93,7 @@ type ScheduledTransferResponse struct {
 	TransactionID     *int64            `json:"transactionId,omitempty"`
 	Type              TransactionType   `json:"type"`
 	Status            TransactionStatus `json:"status"`
-	Amount            int64             `json:"amount"` // Amount in cents
+	Amount            MoneyCents        `json:"amount"` // Amount in cents
 	ScheduledDate     string            `json:"scheduledDate"`
 	RecipientName     *string           `json:"recipientName,omitempty"`
 	RecipientDocument *string           `json:"recipientDocument,omitempty"`
@@ -112,10 +115,10 @@ type CancelScheduledTransferRequest struct {
 
 // BillPaymentRequest represents a bill payment request
 type BillPaymentRequest struct {
-	Barcode       string  `json:"barcode"`                 // Bill barcode/digitable line
-	Amount        *int64  `json:"amount,omitempty"`        // Amount in cents (if different from bill)
-	ScheduledDate *string `json:"scheduledDate,omitempty"` // Format: YYYY-MM-DD
-	Description   *string `json:"description,omitempty"`
+	Barcode       string      `json:"barcode"`                 // Bill barcode/digitable line
+	Amount        *MoneyCents `json:"amount,omitempty"`        // Amount in cents (if different from bill)
+	ScheduledDate *string     `json:"scheduledDate,omitempty"` // Format: YYYY-MM-DD
+	Description   *string     `json:"description,omitempty"`
 
 	// Location (for fraud prevention)
 	Latitude  *string `json:"latitude,omitempty"`
@@ -134,23 +137,23 @@ type GetBillInfoRequest struct {
 
 // BillInfoResponse represents bill information
 type BillInfoResponse struct {
-	Barcode           string  `json:"barcode"`
-	Amount            int64   `json:"amount"`  // Amount in cents
-	DueDate           string  `json:"dueDate"` // Format: YYYY-MM-DD
-	RecipientName     string  `json:"recipientName"`
-	RecipientDocument string  `json:"recipientDocument"`
-	Description       *string `json:"description,omitempty"`
-	Fine              *int64  `json:"fine,omitempty"`        // Fine in cents
-	Interest          *int64  `json:"interest,omitempty"`    // Interest in cents
-	Discount          *int64  `json:"discount,omitempty"`    // Discount in cents
-	TotalAmount       *int64  `json:"totalAmount,omitempty"` // Total with fees in cents
+	Barcode           string      `json:"barcode"`
+	Amount            MoneyCents  `json:"amount"`  // Amount in cents
+	DueDate           string      `json:"dueDate"` // Format: YYYY-MM-DD
+	RecipientName     string      `json:"recipientName"`
+	RecipientDocument string      `json:"recipientDocument"`
+	Description       *string     `json:"description,omitempty"`
+	Fine              *MoneyCents `json:"fine,omitempty"`        // Fine in cents
+	Interest          *MoneyCents `json:"interest,omitempty"`    // Interest in cents
+	Discount          *MoneyCents `json:"discount,omitempty"`    // Discount in cents
+	TotalAmount       *MoneyCents `json:"totalAmount,omitempty"` // Total with fees in cents
 }
 
 // ScheduledBillPaymentResponse represents a scheduled bill payment
 type ScheduledBillPaymentResponse struct {
 	SchedulingID  int64             `json:"schedulingId"`
 	Barcode       string            `json:"barcode"`
-	Amount        int64             `json:"amount"` // Amount in cents
+	Amount        MoneyCents        `json:"amount"` // Amount in cents
 	ScheduledDate string            `json:"scheduledDate"`
 	Status        TransactionStatus `json:"status"`
 	RecipientName *string           `json:"recipientName,omitempty"`
@@ -166,10 +169,10 @@ type ScheduledBillPaymentListResponse struct {
 
 // MobileRechargeRequest represents a mobile phone recharge request
 type MobileRechargeRequest struct {
-	PhoneAreaCode string  `json:"phoneAreaCode"`      // Area code (e.g., "11")
-	PhoneNumber   string  `json:"phoneNumber"`        // Phone number
-	Amount        int64   `json:"amount"`             // Amount in cents
-	Operator      *string `json:"operator,omitempty"` // Operator name (e.g., "Vivo", "Claro")
+	PhoneAreaCode string     `json:"phoneAreaCode"`      // Area code (e.g., "11")
+	PhoneNumber   string     `json:"phoneNumber"`        // Phone number
+	Amount        MoneyCents `json:"amount"`             // Amount in cents
+	Operator      *string    `json:"operator,omitempty"` // Operator name (e.g., "Vivo", "Claro")
 }
 
 // AvailableRechargeValuesRequest represents the request to get available recharge values
@@ -186,19 +189,19 @@ type AvailableRechargeValuesResponse struct {
 
 // VoucherRechargeRequest represents a voucher/electronic voucher recharge
 type VoucherRechargeRequest struct {
-	ProviderID    string  `json:"providerId"`
-	Amount        int64   `json:"amount"`                  // Amount in cents
-	RecipientInfo *string `json:"recipientInfo,omitempty"` // Phone, email, or account
+	ProviderID    string     `json:"providerId"`
+	Amount        MoneyCents `json:"amount"`                  // Amount in cents
+	RecipientInfo *string    `json:"recipientInfo,omitempty"` // Phone, email, or account
 }
 
 // VoucherProviderResponse represents a voucher provider
 type VoucherProviderResponse struct {
-	ProviderID   string  `json:"providerId"`
-	ProviderName string  `json:"providerName"`
-	Category     *string `json:"category,omitempty"`
-	MinAmount    *int64  `json:"minAmount,omitempty"`   // Min amount in cents
-	MaxAmount    *int64  `json:"maxAmount,omitempty"`   // Max amount in cents
-	FixedValues  []int64 `json:"fixedValues,omitempty"` // Fixed amounts in cents
+	ProviderID   string      `json:"providerId"`
+	ProviderName string      `json:"providerName"`
+	Category     *string     `json:"category,omitempty"`
+	MinAmount    *MoneyCents `json:"minAmount,omitempty"`   // Min amount in cents
+	MaxAmount    *MoneyCents `json:"maxAmount,omitempty"`   // Max amount in cents
+	FixedValues  []int64     `json:"fixedValues,omitempty"` // Fixed amounts in cents
 }
 
 // RecipientRequest represents a recipient/beneficiary creation request
@@ -268,12 +271,12 @@ type BankListResponse struct {
 type BalanceLockResponse struct {
 	LockID     int64             `json:"lockId"`
 	AccountID  int64             `json:"accountId"`
-	Amount     int64             `json:"amount"` // Amount in cents
+	Amount     MoneyCents        `json:"amount"` // Amount in cents
 	Reason     *string           `json:"reason,omitempty"`
 	Status     BalanceLockStatus `json:"status"`
-	CreatedAt  *time.Time `json:"createdAt,omitempty"`
-	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
-	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+	CreatedAt  *time.Time        `json:"createdAt,omitempty"`
+	ExpiresAt  *time.Time        `json:"expiresAt,omitempty"`
+	ReleasedAt *time.Time        `json:"releasedAt,omitempty"`
 }
 
 // BalanceLockListResponse represents a list of balance locks
@@ -299,14 +302,14 @@ type TransactionListResponse struct {
 
 // PixCallbackRequest represents a PIX callback/notification request
 type PixCallbackRequest struct {
-	EndToEndID        string  `json:"endToEndId"`
-	TransactionID     *int64  `json:"transactionId,omitempty"`
-	Amount            int64   `json:"amount"` // Amount in cents
-	PayerName         *string `json:"payerName,omitempty"`
-	PayerDocument     *string `json:"payerDocument,omitempty"`
-	ReceiverAccountID *int64  `json:"receiverAccountId,omitempty"`
-	Description       *string `json:"description,omitempty"`
-	TransactionDate   string  `json:"transactionDate"`
+	EndToEndID        string     `json:"endToEndId"`
+	TransactionID     *int64     `json:"transactionId,omitempty"`
+	Amount            MoneyCents `json:"amount"` // Amount in cents
+	PayerName         *string    `json:"payerName,omitempty"`
+	PayerDocument     *string    `json:"payerDocument,omitempty"`
+	ReceiverAccountID *int64     `json:"receiverAccountId,omitempty"`
+	Description       *string    `json:"description,omitempty"`
+	TransactionDate   string     `json:"transactionDate"`
 }
 
 // PixCallbackResponse represents the response for PIX callback processing
@@ -317,9 +320,229 @@ type PixCallbackResponse struct {
 
 // AccountBillPaymentRequest represents bill payment request via account endpoint
 type AccountBillPaymentRequest struct {
-	AccountID     int64   `json:"accountId"`
-	Barcode       string  `json:"barcode"`
-	Amount        *int64  `json:"amount,omitempty"` // Amount in cents
-	ScheduledDate *string `json:"scheduledDate,omitempty"`
-	Description   *string `json:"description,omitempty"`
+	AccountID     int64       `json:"accountId"`
+	Barcode       string      `json:"barcode"`
+	Amount        *MoneyCents `json:"amount,omitempty"` // Amount in cents
+	ScheduledDate *string     `json:"scheduledDate,omitempty"`
+	Description   *string     `json:"description,omitempty"`
+}
+
+// Validate checks the recipient, amount and optional scheduling date
+func (r *InternalTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("recipientAccountId", r.RecipientAccountID)
+	positive(&f, "transferAmount", r.TransferAmount)
+	f.optionalDate("scheduledDate", r.ScheduledDate)
+	return f.err()
+}
+
+// Validate checks the recipient document and amount
+func (r *IDToIDTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.document("recipientDocument", r.RecipientDocument)
+	positive(&f, "transferAmount", r.TransferAmount)
+	return f.err()
+}
+
+// Validate checks the expiration date and every transfer in the batch
+func (r *BatchTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.date("expiresIn", r.ExpiresIn)
+	if len(r.Transfers) == 0 {
+		f.add("transfers", ErrRequiredField, "transfers is required")
+	}
+	for i := range r.Transfers {
+		f.nested(fmt.Sprintf("transfers[%d]", i), r.Transfers[i].Validate())
+	}
+	return f.err()
+}
+
+// Validate checks the recipient bank details, document, transfer type, amount and optional scheduling date
+func (r *ExternalTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("bankCode", r.BankCode)
+	f.required("branch", r.Branch)
+	f.required("account", r.Account)
+	f.required("accountType", r.AccountType)
+	f.document("recipientDocument", r.RecipientDocument)
+	f.required("recipientName", r.RecipientName)
+	oneOf(&f, "transferType", r.TransferType, string(TransactionTypeTED), string(TransactionTypeDOC))
+	positive(&f, "amount", r.Amount)
+	f.optionalDate("scheduledDate", r.ScheduledDate)
+	return f.err()
+}
+
+// Validate checks the barcode, optional amount and optional scheduling date
+func (r *BillPaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("barcode", r.Barcode)
+	optionalPositive(&f, "amount", r.Amount)
+	f.optionalDate("scheduledDate", r.ScheduledDate)
+	return f.err()
+}
+
+// Validate checks every payment in the batch
+func (r *BatchBillPaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if len(r.Payments) == 0 {
+		f.add("payments", ErrRequiredField, "payments is required")
+	}
+	for i := range r.Payments {
+		f.nested(fmt.Sprintf("payments[%d]", i), r.Payments[i].Validate())
+	}
+	return f.err()
+}
+
+// Validate checks the account, barcode, optional amount and optional scheduling date
+func (r *AccountBillPaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("accountId", r.AccountID)
+	f.required("barcode", r.Barcode)
+	optionalPositive(&f, "amount", r.Amount)
+	f.optionalDate("scheduledDate", r.ScheduledDate)
+	return f.err()
+}
+
+// Validate checks the phone number and amount
+func (r *MobileRechargeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("phoneAreaCode", r.PhoneAreaCode)
+	f.required("phoneNumber", r.PhoneNumber)
+	positive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the recipient, amount and arrangement type
+func (r *ArrangementTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("recipientAccountId", r.RecipientAccountID)
+	positive(&f, "transferAmount", r.TransferAmount)
+	f.required("arrangementType", r.ArrangementType)
+	return f.err()
+}
+
+// Validate checks the transaction
+func (r *CancelTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("transactionId", r.TransactionID)
+	return f.err()
+}
+
+// Validate checks the recipient account
+func (r *CheckRecipientAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("recipientAccountId", r.RecipientAccountID)
+	return f.err()
+}
+
+// Validate checks the scheduled transfer
+func (r *CancelScheduledTransferRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.requiredID("schedulingId", r.SchedulingID)
+	return f.err()
+}
+
+// Validate checks the barcode
+func (r *GetBillInfoRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("barcode", r.Barcode)
+	return f.err()
+}
+
+// Validate checks the phone number
+func (r *AvailableRechargeValuesRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("phoneAreaCode", r.PhoneAreaCode)
+	f.required("phoneNumber", r.PhoneNumber)
+	return f.err()
+}
+
+// Validate checks the provider and amount
+func (r *VoucherRechargeRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("providerId", r.ProviderID)
+	positive(&f, "amount", r.Amount)
+	return f.err()
+}
+
+// Validate checks the name, document and optional PIX key
+func (r *RecipientRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("name", r.Name)
+	f.document("document", Document(r.Document))
+	oneOf(&f, "documentType", r.DocumentType, DocumentTypeCPF, DocumentTypeCNPJ)
+	if r.PixKeyType != nil || r.PixKeyValue != nil {
+		var key string
+		if r.PixKeyValue != nil {
+			key = *r.PixKeyValue
+		}
+		var keyType PixKeyType
+		if r.PixKeyType != nil {
+			keyType = *r.PixKeyType
+		}
+		f.pixKey("pixKeyType", "pixKeyValue", keyType, key)
+	}
+	return f.err()
+}
+
+// Validate checks the EndToEnd ID, amount and transaction date
+func (r *PixCallbackRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.endToEndID("endToEndId", r.EndToEndID)
+	positive(&f, "amount", r.Amount)
+	if f.required("transactionDate", r.TransactionDate) {
+		f.optionalDateTime("transactionDate", &r.TransactionDate)
+	}
+	return f.err()
 }
@@ -64,3 +64,40 @@ type ContaDigitalGenericResponse struct {
 	Message *string `json:"message,omitempty"`
 	DaCode  *int    `json:"da_code,omitempty"`
 }
+
+// Validate checks the travel dates and countries
+func (r *NotifyTripRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.date("travelStartDate", r.TravelStartDate)
+	f.date("travelEndDate", r.TravelEndDate)
+	if r.TravelEndDate < r.TravelStartDate {
+		f.add("travelEndDate", ErrInvalidField, "travelEndDate must not be before travelStartDate")
+	}
+	if len(r.Countries) == 0 {
+		f.add("countries", ErrRequiredField, "countries is required")
+	}
+	return f.err()
+}
+
+// Validate checks the password hash
+func (r *ChangeUserPasswordRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("hashPassword", r.HashPassword)
+	return f.err()
+}
+
+// Validate checks the name
+func (r *UpdateNameInAccountRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("name", r.Name)
+	return f.err()
+}
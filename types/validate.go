@@ -0,0 +1,339 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Validator is implemented by request types that can check their fields before being sent.
+// Validate returns ValidationErrors listing every invalid field, or nil.
+type Validator interface {
+	Validate() error
+}
+
+var (
+	// pixEmailPattern matches e-mail PIX keys (DICT limits them to 77 characters)
+	pixEmailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+	// pixPhonePattern matches phone PIX keys in E.164 format, e.g. +5511999998888
+	pixPhonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+	// pixEVPPattern matches random (EVP) PIX keys, which are UUIDs
+	pixEVPPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// endToEndPattern matches 32-character PIX EndToEnd identifiers
+	endToEndPattern = regexp.MustCompile(`^[A-Za-z0-9]{32}$`)
+
+	// clockTimePattern matches HH:MM times
+	clockTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// maxPixEmailLength is the longest e-mail accepted as a PIX key
+const maxPixEmailLength = 77
+
+// fieldErrors accumulates field validation errors
+type fieldErrors struct {
+	errs ValidationErrors
+}
+
+// add records a validation error for field
+func (f *fieldErrors) add(field, code, message string) {
+	f.errs = append(f.errs, ValidationError{Code: code, Field: field, Message: message})
+}
+
+// err returns the accumulated errors, or nil when there are none
+func (f *fieldErrors) err() error {
+	if len(f.errs) == 0 {
+		return nil
+	}
+	return f.errs
+}
+
+// nested records the errors of a nested value under prefix (e.g. "transfers[0]")
+func (f *fieldErrors) nested(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		f.add(prefix, ErrInvalidField, err.Error())
+		return
+	}
+	for _, e := range errs {
+		if e.Field != "" {
+			e.Field = prefix + "." + e.Field
+		} else {
+			e.Field = prefix
+		}
+		f.errs = append(f.errs, e)
+	}
+}
+
+// required checks that a string field is not blank
+func (f *fieldErrors) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		f.add(field, ErrRequiredField, field+" is required")
+		return false
+	}
+	return true
+}
+
+// requiredID checks that an identifier field is set
+func (f *fieldErrors) requiredID(field string, value int64) {
+	if value <= 0 {
+		f.add(field, ErrRequiredField, field+" is required")
+	}
+}
+
+// maxLength checks that an optional string field does not exceed n characters
+func (f *fieldErrors) maxLength(field string, value *string, n int) {
+	if value != nil && utf8.RuneCountInString(*value) > n {
+		f.add(field, ErrInvalidField, fmt.Sprintf("%s must be at most %d characters", field, n))
+	}
+}
+
+// minLength checks that a required string field has at least n characters
+func (f *fieldErrors) minLength(field, value string, n int) {
+	if f.required(field, value) && utf8.RuneCountInString(value) < n {
+		f.add(field, ErrInvalidField, fmt.Sprintf("%s must be at least %d characters", field, n))
+	}
+}
+
+// date checks that a required field is a YYYY-MM-DD date
+func (f *fieldErrors) date(field, value string) {
+	if !f.required(field, value) {
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		f.add(field, ErrInvalidField, field+" must be a date in YYYY-MM-DD format")
+	}
+}
+
+// optionalDate checks that an optional field, when set, is a YYYY-MM-DD date
+func (f *fieldErrors) optionalDate(field string, value *string) {
+	if value != nil {
+		f.date(field, *value)
+	}
+}
+
+// optionalDateTime checks that an optional field, when set, is a YYYY-MM-DD date or an ISO 8601 date-time
+func (f *fieldErrors) optionalDateTime(field string, value *string) {
+	if value == nil {
+		return
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339, "2006-01-02T15:04:05"} {
+		if _, err := time.Parse(layout, *value); err == nil {
+			return
+		}
+	}
+	f.add(field, ErrInvalidField, field+" must be a date (YYYY-MM-DD) or ISO 8601 date-time")
+}
+
+// clockTime checks that a required field is an HH:MM time
+func (f *fieldErrors) clockTime(field, value string) {
+	if f.required(field, value) && !clockTimePattern.MatchString(value) {
+		f.add(field, ErrInvalidField, field+" must be a time in HH:MM format")
+	}
+}
+
+// endToEndID checks that a required field is a 32-character alphanumeric EndToEnd identifier
+func (f *fieldErrors) endToEndID(field, value string) {
+	if f.required(field, value) && !endToEndPattern.MatchString(value) {
+		f.add(field, ErrInvalidField, field+" must be a 32-character alphanumeric EndToEnd ID")
+	}
+}
+
+// document checks that a required field holds a CPF or CNPJ with valid check digits
+func (f *fieldErrors) document(field string, value Document) {
+	if !f.required(field, string(value)) {
+		return
+	}
+	if !value.Valid() {
+		f.add(field, ErrDocumentInvalid, field+" must be a valid CPF or CNPJ")
+	}
+}
+
+// optionalDocument checks that an optional field, when set, holds a valid CPF or CNPJ
+func (f *fieldErrors) optionalDocument(field string, value *Document) {
+	if value != nil {
+		f.document(field, *value)
+	}
+}
+
+// pixKey checks that key is valid for keyType
+func (f *fieldErrors) pixKey(typeField, keyField string, keyType PixKeyType, key string) {
+	if !oneOf(f, typeField, keyType, PixKeyTypeCPF, PixKeyTypeCNPJ, PixKeyTypeEmail, PixKeyTypePhone, PixKeyTypeRandom) {
+		return
+	}
+	if !f.required(keyField, key) {
+		return
+	}
+	if !isValidPixKey(keyType, key) {
+		f.add(keyField, ErrPixKeyInvalid, fmt.Sprintf("%s is not a valid %s key", keyField, keyType))
+	}
+}
+
+// positive checks that a required amount is greater than zero
+func positive[T ~int64](f *fieldErrors, field string, value T) {
+	if value <= 0 {
+		f.add(field, ErrInvalidField, field+" must be greater than zero")
+	}
+}
+
+// optionalPositive checks that an optional amount, when set, is greater than zero
+func optionalPositive[T ~int64](f *fieldErrors, field string, value *T) {
+	if value != nil {
+		positive(f, field, *value)
+	}
+}
+
+// nonNegative checks that a required amount or count is not negative
+func nonNegative[T ~int32 | ~int64](f *fieldErrors, field string, value T) {
+	if value < 0 {
+		f.add(field, ErrInvalidField, field+" must not be negative")
+	}
+}
+
+// optionalNonNegative checks that an optional amount, when set, is not negative
+func optionalNonNegative[T ~int64](f *fieldErrors, field string, value *T) {
+	if value != nil {
+		nonNegative(f, field, *value)
+	}
+}
+
+// between checks that a required number is within [lo, hi]
+func between[T ~int | ~int32](f *fieldErrors, field string, value, lo, hi T) {
+	if value < lo || value > hi {
+		f.add(field, ErrInvalidField, fmt.Sprintf("%s must be between %d and %d", field, lo, hi))
+	}
+}
+
+// oneOf checks that a required enum field holds one of the allowed values
+func oneOf[T ~string | ~int32](f *fieldErrors, field string, value T, allowed ...T) bool {
+	var zero T
+	if value == zero {
+		f.add(field, ErrRequiredField, field+" is required")
+		return false
+	}
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	f.add(field, ErrInvalidField, fmt.Sprintf("%s has invalid value %v", field, value))
+	return false
+}
+
+// optionalOneOf checks that an optional enum field, when set, holds one of the allowed values
+func optionalOneOf[T ~string | ~int32](f *fieldErrors, field string, value *T, allowed ...T) {
+	if value != nil {
+		oneOf(f, field, *value, allowed...)
+	}
+}
+
+// isValidPixKey reports whether key is well-formed for the given key type
+func isValidPixKey(keyType PixKeyType, key string) bool {
+	switch keyType {
+	case PixKeyTypeCPF:
+		return isDigits(key) && isValidCPF(key)
+	case PixKeyTypeCNPJ:
+		return key == Document(key).Canonical() && isValidCNPJ(key)
+	case PixKeyTypeEmail:
+		return len(key) <= maxPixEmailLength && pixEmailPattern.MatchString(key)
+	case PixKeyTypePhone:
+		return pixPhonePattern.MatchString(key)
+	case PixKeyTypeRandom:
+		return pixEVPPattern.MatchString(key)
+	default:
+		return false
+	}
+}
+
+// isValidCPF reports whether s is a CPF with valid check digits. Punctuation ("." and "-") is ignored.
+func isValidCPF(s string) bool {
+	digits, ok := documentValues(s, 11, false)
+	if !ok || allSame(digits) {
+		return false
+	}
+	return checkDigit(digits[:9], 10) == digits[9] && checkDigit(digits[:10], 11) == digits[10]
+}
+
+// isValidCNPJ reports whether s is a CNPJ with valid check digits, in the numeric or the
+// alphanumeric format. Punctuation (".", "/" and "-") is ignored.
+func isValidCNPJ(s string) bool {
+	values, ok := documentValues(s, 14, true)
+	if !ok || allSame(values) || values[12] > 9 || values[13] > 9 {
+		return false
+	}
+	weights1 := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	weights2 := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return weightedCheckDigit(values[:12], weights1) == values[12] &&
+		weightedCheckDigit(values[:13], weights2) == values[13]
+}
+
+// documentValues strips document punctuation and returns the character values if there are
+// exactly n characters. Digits are worth their numeric value; with letters, which alphanumeric
+// CNPJs use, uppercase letters are worth their ASCII code minus 48 ('A' is 17).
+func documentValues(s string, n int, letters bool) ([]int, bool) {
+	values := make([]int, 0, n)
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			values = append(values, int(r-'0'))
+		case letters && r >= 'A' && r <= 'Z':
+			values = append(values, int(r-'0'))
+		case r == '.' || r == '-' || r == '/':
+		default:
+			return nil, false
+		}
+	}
+	return values, len(values) == n
+}
+
+// checkDigit computes a CPF check digit with weights descending from first
+func checkDigit(digits []int, first int) int {
+	weights := make([]int, len(digits))
+	for i := range digits {
+		weights[i] = first - i
+	}
+	return weightedCheckDigit(digits, weights)
+}
+
+// weightedCheckDigit computes a modulo 11 check digit
+func weightedCheckDigit(digits, weights []int) int {
+	sum := 0
+	for i, d := range digits {
+		sum += d * weights[i]
+	}
+	if rem := sum % 11; rem >= 2 {
+		return 11 - rem
+	}
+	return 0
+}
+
+// allSame reports whether all digits are equal (such documents pass the checksum but are invalid)
+func allSame(digits []int) bool {
+	for _, d := range digits[1:] {
+		if d != digits[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s is non-empty and only contains ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,395 @@
+package types
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsValidCPFAndCNPJ(t *testing.T) {
+	tests := []struct {
+		value string
+		cpf   bool
+		cnpj  bool
+	}{
+		{"52998224725", true, false},
+		{"529.982.247-25", true, false},
+		{"52998224724", false, false},
+		{"11111111111", false, false},
+		{"11222333000181", false, true},
+		{"11.222.333/0001-81", false, true},
+		{"11222333000180", false, false},
+		{"00000000000000", false, false},
+		{"12ABC34501DE35", false, true},
+		{"12.ABC.345/01DE-35", false, true},
+		{"12ABC34501DE36", false, false},
+		{"5299822472a", false, false},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := isValidCPF(tt.value); got != tt.cpf {
+			t.Errorf("isValidCPF(%q) = %v; want %v", tt.value, got, tt.cpf)
+		}
+		if got := isValidCNPJ(tt.value); got != tt.cnpj {
+			t.Errorf("isValidCNPJ(%q) = %v; want %v", tt.value, got, tt.cnpj)
+		}
+	}
+}
+
+func TestIsValidPixKey(t *testing.T) {
+	tests := []struct {
+		keyType PixKeyType
+		key     string
+		want    bool
+	}{
+		{PixKeyTypeCPF, "52998224725", true},
+		{PixKeyTypeCPF, "529.982.247-25", false},
+		{PixKeyTypeCNPJ, "11222333000181", true},
+		{PixKeyTypeCNPJ, "12ABC34501DE35", true},
+		{PixKeyTypeCNPJ, "11.222.333/0001-81", false},
+		{PixKeyTypeEmail, "user@example.com", true},
+		{PixKeyTypeEmail, "user@example", false},
+		{PixKeyTypePhone, "+5511999998888", true},
+		{PixKeyTypePhone, "11999998888", false},
+		{PixKeyTypeRandom, "123e4567-e89b-12d3-a456-426614174000", true},
+		{PixKeyTypeRandom, "123e4567e89b12d3a456426614174000", false},
+		{PixKeyType("IBAN"), "anything", false},
+	}
+
+	for _, tt := range tests {
+		if got := isValidPixKey(tt.keyType, tt.key); got != tt.want {
+			t.Errorf("isValidPixKey(%s, %q) = %v; want %v", tt.keyType, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPixPaymentRequestValidate(t *testing.T) {
+	valid := PixPaymentRequest{
+		AccountID:                1,
+		RecipientInstitutionCode: "00000000",
+		RecipientBranchCode:      "0001",
+		RecipientAccountNumber:   "123456",
+		RecipientAccountType:     PixAccountTypeCACC,
+		RecipientCpfCnpj:         "52998224725",
+		RecipientName:            "Maria",
+		OperationAmount:          MustParseMoney("10.50"),
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid request, got %v", err)
+	}
+
+	invalid := valid
+	invalid.RecipientCpfCnpj = ""
+	invalid.RecipientAccountType = "XXXX"
+	invalid.OperationAmount = -1
+	schedule := "31/12/2025"
+	invalid.SchedulingDate = &schedule
+
+	err := invalid.Validate()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+
+	want := map[string]string{
+		"recipientCpfCnpj":     ErrRequiredField,
+		"recipientAccountType": ErrInvalidField,
+		"operationAmount":      ErrInvalidField,
+		"schedulingDate":       ErrInvalidField,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors (%v); want %d", len(errs), errs, len(want))
+	}
+	for _, e := range errs {
+		if code, ok := want[e.Field]; !ok || code != e.Code {
+			t.Errorf("unexpected error %+v", e)
+		}
+	}
+}
+
+func TestBatchTransferRequestValidateNested(t *testing.T) {
+	req := BatchTransferRequest{
+		ExpiresIn: "2025-13-01",
+		Transfers: []InternalTransferRequest{
+			{RecipientAccountID: 1, TransferAmount: 100},
+			{RecipientAccountID: 2},
+		},
+	}
+
+	var errs ValidationErrors
+	if !errors.As(req.Validate(), &errs) {
+		t.Fatal("expected ValidationErrors")
+	}
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	if len(fields) != 2 || fields[0] != "expiresIn" || fields[1] != "transfers[1].transferAmount" {
+		t.Errorf("got fields %v; want [expiresIn transfers[1].transferAmount]", fields)
+	}
+}
+
+func TestCreatePixKeyRequestValidateRandomKey(t *testing.T) {
+	req := CreatePixKeyRequest{KeyType: PixKeyTypeRandom}
+	if err := req.Validate(); err != nil {
+		t.Errorf("random key without value should be valid, got %v", err)
+	}
+
+	req = CreatePixKeyRequest{KeyType: PixKeyTypeEmail, Key: "not-an-email"}
+	if err := req.Validate(); err == nil {
+		t.Error("expected error for malformed e-mail key")
+	}
+}
+
+func TestValidateNilRequest(t *testing.T) {
+	var req *PixPaymentRequest
+	if err := req.Validate(); err != nil {
+		t.Errorf("nil request Validate() = %v; want nil", err)
+	}
+}
+
+// validatedRequests lists the request types checked by Validate before being sent
+var validatedRequests = []Validator{
+	&AcceptAutomaticPixRequest{},
+	&AccountBillPaymentRequest{},
+	&ActivateCardRequest{},
+	&AddressRequest{},
+	&ArrangementTransferRequest{},
+	&AvailableRechargeValuesRequest{},
+	&BankTransferRequest{},
+	&BankslipV2Request{},
+	&BatchBillPaymentRequest{},
+	&BatchTransferRequest{},
+	&BillPaymentRequest{},
+	&BindAnonymousCardRequest{},
+	&BindProcessorAccountRequest{},
+	&BindProcessorCardRequest{},
+	&BiroAnalysisRequest{},
+	&BlockCardRequest{},
+	&BlockPixDeviceRequest{},
+	&BranchRequest{},
+	&CancelAutomaticPixChargeRequest{},
+	&CancelAutomaticPixRequest{},
+	&CancelChargebackRequest{},
+	&CancelInternalTransferRequest{},
+	&CancelInvoiceScheduleRequest{},
+	&CancelPortabilityRequest{},
+	&CancelPurchaseRequest{},
+	&CancelScheduledTransferRequest{},
+	&CancelTransferRequest{},
+	&CardConfigurationRequest{},
+	&ChangeCardPinRequest{},
+	&ChangePasswordRequest{},
+	&ChangeUserPasswordRequest{},
+	&ChargebackRequest{},
+	&CheckRecipientAccountRequest{},
+	&CloseInfractionReportRequest{},
+	&CloseRefundRequest{},
+	&CompletePortabilityRequest{},
+	&ConfirmPortabilityRequest{},
+	&CreateAccountCreditLimitPostPaidRequest{},
+	&CreateAutomaticPixContractRequest{},
+	&CreateBankSlipRequest{},
+	&CreateBankslipRequest{},
+	&CreateClaimFromKeyRequest{},
+	&CreateCompanyAccountRequest{},
+	&CreateCreditEngineInfoRequest{},
+	&CreateDepositOrderRequest{},
+	&CreateMobileAccountRequest{},
+	&CreatePaysmartProductRequest{},
+	&CreatePixClaimRequest{},
+	&CreatePixKeyRequest{},
+	&CreateProductRequest{},
+	&CreateRecipientRequest{},
+	&CreditEngineInfoRequest{},
+	&DecodeQRCodeV3Request{},
+	&DeletePixDeviceRequest{},
+	&DeletePixKeyRequest{},
+	&DoRechargeRequest{},
+	&DoVoucherRechargeRequest{},
+	&DynamicQRCodeRequest{},
+	&EmailVisualIdentityRequest{},
+	&ExternalTransferRequest{},
+	&FeedbackRequest{},
+	&FeedbackStatementRequest{},
+	&GetBillInfoRequest{},
+	&IDToIDTransferRequest{},
+	&InfractionReportRequest{},
+	&InstitutionRequest{},
+	&InternalTransferRequest{},
+	&LimitNightTimeRequest{},
+	&LimitRequest{},
+	&LinkAccountRequest{},
+	&MobileRechargeRequest{},
+	&NotifyTripRequest{},
+	&ParseQRCodeRequest{},
+	&PixCallbackRequest{},
+	&PixCancelScheduleRequest{},
+	&PixChargebackRequest{},
+	&PixDeviceRequest{},
+	&PixLimitRequest{},
+	&PixPaymentRequest{},
+	&PixPrecautionaryBlockRequest{},
+	&PixUpdatePrecautionaryBlockRequest{},
+	&PostPaidInstallmentRequest{},
+	&PostPaidInstallmentSimulationRequest{},
+	&PostPaidPaymentBalanceRequest{},
+	&PostpaidCardRequest{},
+	&ProcessProposalRequest{},
+	&ProductLimitSchedulingRequest{},
+	&ProposalAccountRequest{},
+	&QRCodeAcceptJourneyThreeRequest{},
+	&QRCodeParseRequest{},
+	&QRCodePaymentRequest{},
+	&QRCodeQueryProcessingRequest{},
+	&QRCodeUserAcceptRequest{},
+	&RecipientRequest{},
+	&RefundSolicitationRequest{},
+	&RejectAutomaticPixRequest{},
+	&SimpleQRCodePaymentRequest{},
+	&StartAutomaticPixRequest{},
+	&StaticQRCodeRequest{},
+	&SummaryPurchaseRequest{},
+	&TokenValidationRequest{},
+	&TransferByIDRequest{},
+	&UnblockPixDeviceRequest{},
+	&UnlinkAccountRequest{},
+	&UpdateAccountNameRequest{},
+	&UpdateAccountRequest{},
+	&UpdateBiroAnalysisRequest{},
+	&UpdateCardTagRequest{},
+	&UpdateCreditExpirationRequest{},
+	&UpdateEmailVisualIdentityRequest{},
+	&UpdateNameInAccountRequest{},
+	&UpdatePixNightTimeLimitRequest{},
+	&UpdatePixScanConfigurationRequest{},
+	&UpdatePostPaidAccountRequest{},
+	&UpdateProductLimitDaySchedulingRequest{},
+	&UpdateProductLimitRequest{},
+	&UpdateProductRequest{},
+	&UpdateProposalImageRequest{},
+	&UpdateProposalProcessingRequest{},
+	&UpdateProposalRequest{},
+	&UpdateRecipientRequest{},
+	&UpdateVirtualCardTagRequest{},
+	&VerifyAccountExistsRequest{},
+	&VoucherRechargeRequest{},
+}
+
+// unvalidatedRequests lists the request types left to the API: their fields are all
+// optional filters, flags or free text
+var unvalidatedRequests = []any{
+	&CardSearchRequest{},
+	&CreateCardRequest{},
+	&CreateVirtualCardRequest{},
+	&EventHubRequest{},
+	&ListAccountsBackofficeRequest{},
+	&ListPixClaimsRequest{},
+	&NotificationPushRequest{},
+	&PayerInfoRequest{},
+	&PostpaidCardSettingsRequest{},
+	&ReissueCardRequest{},
+	&ReivindicacaoRequest{},
+	&ResendProposalRequest{},
+	&SearchProductLimitRequest{},
+	&TokenOperationRequest{},
+	&UnblockCardRequest{},
+	&UpdatePaysmartProductRequest{},
+}
+
+func TestRequestValidationCoverage(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, v := range validatedRequests {
+		listed[reflect.TypeOf(v).Elem().Name()] = true
+	}
+	for _, v := range unvalidatedRequests {
+		name := reflect.TypeOf(v).Elem().Name()
+		if _, ok := v.(Validator); ok {
+			t.Errorf("%s implements Validator; move it to validatedRequests", name)
+		}
+		listed[name] = true
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+	declared := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok || spec.Assign.IsValid() || !strings.HasSuffix(spec.Name.Name, "Request") {
+					return true
+				}
+				if _, ok := spec.Type.(*ast.StructType); ok {
+					declared[spec.Name.Name] = true
+				}
+				return true
+			})
+		}
+	}
+
+	for name := range declared {
+		if !listed[name] {
+			t.Errorf("%s implements neither Validator nor is listed in unvalidatedRequests", name)
+		}
+	}
+	for name := range listed {
+		if !declared[name] {
+			t.Errorf("%s is listed but not declared as a request struct", name)
+		}
+	}
+}
+
+func TestRequestValidateFields(t *testing.T) {
+	negative := Money(-1)
+	tests := []struct {
+		name   string
+		req    Validator
+		fields []string
+	}{
+		{"empty company account", &CreateCompanyAccountRequest{}, []string{
+			"companyName", "companyDocument", "representativeName", "representativeDocument",
+			"representativeEmail", "representativePhone",
+		}},
+		{"company account with swapped documents", &CreateCompanyAccountRequest{
+			CompanyName: "ACME", CompanyDocument: "52998224725",
+			RepresentativeName: "Maria", RepresentativeDocument: "11222333000181",
+			RepresentativeEmail: "maria@example.com", RepresentativePhone: "11999998888",
+			Address: &AddressRequest{StreetAvenue: "Rua A", Number: "123456", PostalCode: "01001000"},
+		}, []string{"companyDocument", "representativeDocument", "address.number"}},
+		{"empty recipient", &CreateRecipientRequest{}, []string{"recipientDocument", "recipientName"}},
+		{"empty recipient account check", &CheckRecipientAccountRequest{}, []string{"recipientAccountId"}},
+		{"empty bill info", &GetBillInfoRequest{}, []string{"barcode"}},
+		{"account update with bad birth date", &UpdateAccountRequest{AccountID: 1, BirthDate: new(string)}, []string{"birthDate"}},
+		{"empty recharge", &DoRechargeRequest{}, []string{"areaCode", "phoneNumber", "rechargeValue"}},
+		{"negative limit", &LimitRequest{NightLimit: &negative}, []string{"nightLimit"}},
+		{"trip ending before it starts", &NotifyTripRequest{
+			TravelStartDate: "2025-02-10", TravelEndDate: "2025-02-01", Countries: []int64{1},
+		}, []string{"travelEndDate"}},
+		{"valid limits", &PixLimitRequest{}, nil},
+	}
+
+	for _, tt := range tests {
+		var fields []string
+		var errs ValidationErrors
+		if errors.As(tt.req.Validate(), &errs) {
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+		}
+		if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("%s: invalid fields = %v; want %v", tt.name, fields, tt.fields)
+		}
+	}
+}
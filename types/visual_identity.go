@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 // Visual Identity Operations (Email Configuration)
 // Based on openapi-backoffice-identidade-visual.json
 
@@ -24,3 +26,36 @@ type EmailVisualIdentityResponse struct {
 	FooterColor              string `json:"footerColor"`              // Required
 	LogoEmailVisualIdentity  string `json:"logoEmailVisualIdentity"`  // Required
 }
+
+// logoDataURI checks that a logo is an image data URI
+func (f *fieldErrors) logoDataURI(value string) {
+	if !strings.HasPrefix(value, "data:image/") {
+		f.add("logoBase64", ErrInvalidField, "logoBase64 must be a data URI such as data:image/png;base64,...")
+	}
+}
+
+// Validate checks the colors and that the logo is an image data URI
+func (r *EmailVisualIdentityRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	f.required("headerColor", r.HeaderColor)
+	f.required("footerColor", r.FooterColor)
+	if f.required("logoBase64", r.LogoBase64) {
+		f.logoDataURI(r.LogoBase64)
+	}
+	return f.err()
+}
+
+// Validate checks that the optional logo is an image data URI
+func (r *UpdateEmailVisualIdentityRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var f fieldErrors
+	if r.LogoBase64 != nil {
+		f.logoDataURI(*r.LogoBase64)
+	}
+	return f.err()
+}
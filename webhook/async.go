@@ -0,0 +1,297 @@
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AsyncConfig configures asynchronous event processing
+type AsyncConfig struct {
+	// Workers is the number of concurrent workers. Events of the same account are
+	// always processed by the same worker, in the order they were queued.
+	Workers int
+	// MaxAttempts is the maximum number of callback attempts per event, including the first
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultAsyncConfig returns the default async configuration:
+// 4 workers and up to 5 attempts with backoff from 1s to 1m
+func DefaultAsyncConfig() AsyncConfig {
+	return AsyncConfig{
+		Workers:        4,
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	}
+}
+
+// workerBuffer is the number of events buffered per worker
+const workerBuffer = 16
+
+// WithAsync enables asynchronous processing. Verified deliveries are written to
+// queue and acknowledged with 202 Accepted; a worker pool then invokes the callbacks,
+// retrying failures with exponential backoff. Deliveries that cannot be queued get
+// 503 so that Evertec retries them. Zero fields of cfg take their DefaultAsyncConfig
+// values. Call Shutdown to drain the queue before exiting.
+func WithAsync(queue EventQueue, cfg AsyncConfig) HandlerOption {
+	return func(h *Handler) {
+		defaults := DefaultAsyncConfig()
+		if cfg.Workers <= 0 {
+			cfg.Workers = defaults.Workers
+		}
+		if cfg.MaxAttempts <= 0 {
+			cfg.MaxAttempts = defaults.MaxAttempts
+		}
+		if cfg.InitialBackoff <= 0 {
+			cfg.InitialBackoff = defaults.InitialBackoff
+		}
+		if cfg.MaxBackoff <= 0 {
+			cfg.MaxBackoff = defaults.MaxBackoff
+		}
+		h.async = &asyncProcessor{queue: queue, config: cfg}
+	}
+}
+
+// asyncProcessor dispatches queued events to a pool of workers
+type asyncProcessor struct {
+	queue   EventQueue
+	config  AsyncConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers []chan *QueuedEvent
+	wg      sync.WaitGroup
+	done    chan struct{}
+	closing atomic.Bool
+}
+
+// startAsync launches the dispatcher and workers
+func (h *Handler) startAsync() {
+	a := h.async
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.done = make(chan struct{})
+	a.workers = make([]chan *QueuedEvent, a.config.Workers)
+	for i := range a.workers {
+		a.workers[i] = make(chan *QueuedEvent, workerBuffer)
+		a.wg.Add(1)
+		go func(events <-chan *QueuedEvent) {
+			defer a.wg.Done()
+			for event := range events {
+				h.processQueued(a.ctx, event)
+			}
+		}(a.workers[i])
+	}
+	go h.dispatchQueued()
+}
+
+// dispatchQueued moves events from the queue to the worker owning their account
+func (h *Handler) dispatchQueued() {
+	a := h.async
+	defer func() {
+		for _, events := range a.workers {
+			close(events)
+		}
+		a.wg.Wait()
+		close(a.done)
+	}()
+
+	for {
+		event, err := a.queue.Dequeue(a.ctx)
+		if err != nil {
+			if errors.Is(err, ErrQueueClosed) || a.ctx.Err() != nil {
+				return
+			}
+			h.logger.Error("Failed to dequeue webhook event", "error", err)
+			if !sleepContext(a.ctx, a.config.InitialBackoff) {
+				return
+			}
+			continue
+		}
+
+		select {
+		case a.workers[shardIndex(orderingKey(event), len(a.workers))] <- event:
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueue persists an accepted delivery for asynchronous processing
+func (h *Handler) enqueue(ctx context.Context, r *http.Request, eventType EventType, eventKey string,
+	payload, body []byte, receivedAt time.Time) error {
+	if h.async.closing.Load() {
+		return ErrQueueClosed
+	}
+
+	// Carry the server span to the worker through the stored headers
+	header := r.Header.Clone()
+	h.textMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+
+	return h.async.queue.Enqueue(ctx, &QueuedEvent{
+		ID:         newQueuedEventID(),
+		Type:       eventType,
+		Payload:    payload,
+		EventKey:   eventKey,
+		Header:     header,
+		RemoteAddr: r.RemoteAddr,
+		Body:       body,
+		ReceivedAt: receivedAt,
+	})
+}
+
+// processQueued invokes the callback for a queued event, retrying with backoff,
+// and acknowledges the event unless processing was interrupted by Shutdown
+func (h *Handler) processQueued(ctx context.Context, event *QueuedEvent) {
+	a := h.async
+	backoff := a.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := h.processQueuedAttempt(ctx, event, attempt)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			// Leave the event in the queue so that a durable queue redelivers it
+			h.logger.Warn("Webhook processing interrupted", "type", event.Type, "event_id", event.ID, "error", err)
+			return
+		}
+		if attempt >= a.config.MaxAttempts {
+			h.logger.Error("Webhook event failed after retries",
+				"type", event.Type, "event_id", event.ID, "attempts", attempt, "error", err)
+			h.recordDeadLetter(ctx, &DeadLetter{
+				ID:         cmp.Or(event.EventKey, event.ID),
+				Type:       event.Type,
+				Payload:    event.Payload,
+				EventKey:   event.EventKey,
+				Header:     event.Header,
+				RemoteAddr: event.RemoteAddr,
+				Body:       event.Body,
+				Error:      err.Error(),
+				Attempts:   attempt,
+				ReceivedAt: event.ReceivedAt,
+				FailedAt:   time.Now(),
+			})
+			break
+		}
+		h.logger.Warn("Webhook event failed, retrying",
+			"type", event.Type, "event_id", event.ID, "attempt", attempt, "backoff", backoff, "error", err)
+		if !sleepContext(ctx, backoff) {
+			return
+		}
+		backoff = min(backoff*2, a.config.MaxBackoff)
+	}
+
+	if err := a.queue.Ack(context.WithoutCancel(ctx), event.ID); err != nil {
+		h.logger.Error("Failed to acknowledge webhook event", "type", event.Type, "event_id", event.ID, "error", err)
+	}
+}
+
+// processQueuedAttempt runs one callback attempt for a queued event
+func (h *Handler) processQueuedAttempt(ctx context.Context, event *QueuedEvent, attempt int) (err error) {
+	ctx, span := h.startSpan(ctx, event.Header, event.Type, trace.SpanKindConsumer,
+		attribute.String("webhook.event_key", event.EventKey),
+		attribute.String("webhook.event_id", event.ID),
+		attribute.Int("webhook.attempt", attempt),
+	)
+	defer func() {
+		statusCode := http.StatusOK
+		if err != nil {
+			statusCode = http.StatusInternalServerError
+		}
+		endSpan(span, statusCode, err)
+	}()
+
+	duplicate, claimed, err := h.checkEvent(event.EventKey, true)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		h.logger.Info("Duplicate webhook skipped", "type", event.Type, "event_key", event.EventKey)
+		return nil
+	}
+
+	meta := Meta{
+		Header:     event.Header,
+		ReceivedAt: event.ReceivedAt,
+		RemoteAddr: event.RemoteAddr,
+		Body:       event.Body,
+		Attempt:    attempt,
+	}
+	err = h.dispatch(ctx, event.Type, event.Payload, meta)
+	h.finishEvent(event.Type, event.EventKey, claimed, err)
+	if err == nil {
+		h.clearDeadLetter(ctx, event.EventKey)
+	}
+	return err
+}
+
+// Shutdown stops accepting deliveries (answering 503), closes the queue and waits
+// until the events already queued are processed. If ctx ends first, in-flight
+// callbacks are cancelled, unfinished events are left unacknowledged and ctx's error
+// is returned. Shutdown is a no-op without WithAsync.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	a := h.async
+	if a == nil {
+		return nil
+	}
+	a.closing.Store(true)
+	if err := a.queue.Close(); err != nil {
+		return err
+	}
+
+	select {
+	case <-a.done:
+		a.cancel()
+		return nil
+	case <-ctx.Done():
+		a.cancel()
+		return ctx.Err()
+	}
+}
+
+// orderingKey returns the key that determines which worker processes an event:
+// the account ID when the payload has one, the event key or ID otherwise
+func orderingKey(event *QueuedEvent) string {
+	var account struct {
+		AccountID json.Number `json:"accountId"`
+	}
+	if err := json.Unmarshal(event.Payload, &account); err == nil && account.AccountID != "" {
+		return "account:" + account.AccountID.String()
+	}
+	if event.EventKey != "" {
+		return event.EventKey
+	}
+	return event.ID
+}
+
+// shardIndex maps a key to one of n workers
+func shardIndex(key string, n int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(n)) //nolint:gosec // n is a small positive worker count
+}
+
+// sleepContext waits for d, returning false if ctx ends first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// postEvent sends a PIX movement payload to the handler
+func postEvent(h *Handler, body string) int {
+	rec := httptest.NewRecorder()
+	h.HandlePixMovement(rec, httptest.NewRequest(http.MethodPost, "/webhook/movimento_pix", bytes.NewBufferString(body)))
+	return rec.Code
+}
+
+func TestAsyncProcessing(t *testing.T) {
+	var mu sync.Mutex
+	var attempts []int
+	h := NewHandler(
+		WithAsync(NewMemoryQueue(10), AsyncConfig{Workers: 2, MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+		OnPixMovementCtx(func(_ context.Context, _ *PixMovementEvent, meta Meta) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts = append(attempts, meta.Attempt)
+			if meta.Attempt < 3 {
+				return errors.New("ledger unavailable")
+			}
+			return nil
+		}),
+	)
+
+	if code := postEvent(h, testBody); code != http.StatusAccepted {
+		t.Fatalf("status = %d; want 202", code)
+	}
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(attempts) != "[1 2 3]" {
+		t.Errorf("attempts = %v; want [1 2 3]", attempts)
+	}
+	if code := postEvent(h, testBody); code != http.StatusServiceUnavailable {
+		t.Errorf("after Shutdown: status = %d; want 503", code)
+	}
+}
+
+func TestAsyncPerAccountOrdering(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[int64][]string)
+	h := NewHandler(
+		WithAsync(NewMemoryQueue(100), AsyncConfig{Workers: 4}),
+		OnPixMovement(func(e *PixMovementEvent) error {
+			time.Sleep(time.Duration(e.AccountID) * 100 * time.Microsecond)
+			mu.Lock()
+			seen[e.AccountID] = append(seen[e.AccountID], e.EndToEnd)
+			mu.Unlock()
+			return nil
+		}),
+	)
+
+	for i := range 10 {
+		for account := 1; account <= 3; account++ {
+			body := fmt.Sprintf(`{"accountId":%d,"endToEnd":"E%02d"}`, account, i)
+			if code := postEvent(h, body); code != http.StatusAccepted {
+				t.Fatalf("status = %d; want 202", code)
+			}
+		}
+	}
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	for account := int64(1); account <= 3; account++ {
+		got := seen[account]
+		if len(got) != 10 {
+			t.Fatalf("account %d: got %d events; want 10", account, len(got))
+		}
+		for i, e2e := range got {
+			if want := fmt.Sprintf("E%02d", i); e2e != want {
+				t.Errorf("account %d: event %d = %s; want %s", account, i, e2e, want)
+			}
+		}
+	}
+}
+
+func TestAsyncQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	h := NewHandler(
+		WithAsync(NewMemoryQueue(1), AsyncConfig{Workers: 1}),
+		OnPixMovement(func(*PixMovementEvent) error { <-release; return nil }),
+	)
+
+	full := false
+	for i := 0; i < 100 && !full; i++ {
+		full = postEvent(h, fmt.Sprintf(`{"endToEnd":"E%d"}`, i)) == http.StatusServiceUnavailable
+	}
+	close(release)
+	if !full {
+		t.Error("expected 503 once the queue is full")
+	}
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}
+
+func TestAsyncShutdownTimeoutKeepsUnfinishedEvents(t *testing.T) {
+	dir := t.TempDir()
+	queue, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(
+		WithAsync(queue, AsyncConfig{Workers: 1}),
+		OnPixMovementCtx(func(ctx context.Context, _ *PixMovementEvent, _ Meta) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	)
+
+	if code := postEvent(h, testBody); code != http.StatusAccepted {
+		t.Fatalf("status = %d; want 202", code)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := h.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v; want deadline exceeded", err)
+	}
+
+	reopened, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 1 {
+		t.Errorf("reopened queue has %d events; want 1", reopened.Len())
+	}
+}
+
+func TestFileQueueEnqueueDuringClose(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	q, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every accepted event is drained after Close; rejected ones leave no file behind
+	drained := make(chan int)
+	first := make(chan struct{})
+	go func() {
+		n := 0
+		for {
+			event, err := q.Dequeue(ctx)
+			if err != nil {
+				drained <- n
+				return
+			}
+			if n++; n == 1 {
+				close(first)
+			}
+			_ = q.Ack(ctx, event.ID)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if q.Enqueue(ctx, &QueuedEvent{ID: fmt.Sprintf("id-%d", i), Payload: []byte(`{}`)}) == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	// Close while the other events are being written
+	<-first
+	q.Close()
+	wg.Wait()
+
+	if n := <-drained; n != accepted {
+		t.Errorf("drained %d events; want the %d accepted", n, accepted)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("%d event files left on disk; want 0", len(entries))
+	}
+}
+
+func TestFileQueue(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	q, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 3 {
+		event := &QueuedEvent{ID: fmt.Sprintf("id-%d", i), Type: EventTypePixMovement, Payload: []byte(`{}`)}
+		if err := q.Enqueue(ctx, event); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	first, err := q.Dequeue(ctx)
+	if err != nil || first.ID != "id-0" {
+		t.Fatalf("Dequeue = %v, %v; want id-0", first, err)
+	}
+	if err := q.Ack(ctx, first.ID); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	second, _ := q.Dequeue(ctx)
+	if second.ID != "id-1" {
+		t.Fatalf("Dequeue = %s; want id-1", second.ID)
+	}
+	q.Close()
+	if err := q.Enqueue(ctx, &QueuedEvent{ID: "late"}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Enqueue after Close = %v; want ErrQueueClosed", err)
+	}
+
+	// Unacknowledged events survive a restart, in order
+	if err := os.WriteFile(filepath.Join(dir, "garbage.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	q, err = NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for q.Len() > 0 {
+		event, err := q.Dequeue(ctx)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, event.ID)
+	}
+	if fmt.Sprint(ids) != "[id-1 id-2]" {
+		t.Errorf("recovered %v; want [id-1 id-2]", ids)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "garbage.json.corrupt")); err != nil {
+		t.Errorf("expected corrupt file to be set aside: %v", err)
+	}
+
+	q.Close()
+	if _, err := q.Dequeue(ctx); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Dequeue after Close = %v; want ErrQueueClosed", err)
+	}
+}
+
+func TestMemoryQueueClose(t *testing.T) {
+	ctx := context.Background()
+	q := NewMemoryQueue(2)
+	_ = q.Enqueue(ctx, &QueuedEvent{ID: "a"})
+	q.Close()
+
+	if err := q.Enqueue(ctx, &QueuedEvent{ID: "b"}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Enqueue after Close = %v; want ErrQueueClosed", err)
+	}
+	if event, err := q.Dequeue(ctx); err != nil || event.ID != "a" {
+		t.Errorf("Dequeue = %v, %v; want queued event a", event, err)
+	}
+	if _, err := q.Dequeue(ctx); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Dequeue on drained queue = %v; want ErrQueueClosed", err)
+	}
+}
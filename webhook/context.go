@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/observability"
+)
+
+// Meta describes the HTTP delivery of a webhook event
+type Meta struct {
+	// Header holds the request headers
+	Header http.Header
+	// ReceivedAt is when the handler received the delivery
+	ReceivedAt time.Time
+	// RemoteAddr is the network address of the sender
+	RemoteAddr string
+	// Body is the raw request body, before any envelope is removed
+	Body []byte
+	// Attempt counts deliveries of the same event seen by this handler, starting at 1.
+	// It resets once the event is acknowledged or the process restarts.
+	Attempt int
+}
+
+// OnPixMovementCtx sets a context-aware handler for PIX movement events, replacing OnPixMovement
+func OnPixMovementCtx(fn func(context.Context, *PixMovementEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onPixMovement = nil
+		h.onPixMovementCtx = fn
+	}
+}
+
+// OnScheduledPixCtx sets a context-aware handler for scheduled PIX events, replacing OnScheduledPix
+func OnScheduledPixCtx(fn func(context.Context, *ScheduledPixEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onScheduledPix = nil
+		h.onScheduledPixCtx = fn
+	}
+}
+
+// OnPrecautionaryBlockCtx sets a context-aware handler for precautionary block events,
+// replacing OnPrecautionaryBlock
+func OnPrecautionaryBlockCtx(fn func(context.Context, *PrecautionaryBlockEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onPrecautionaryBlock = nil
+		h.onPrecautionaryBlockCtx = fn
+	}
+}
+
+// OnRetainedValueCtx sets a context-aware handler for retained value events, replacing OnRetainedValue
+func OnRetainedValueCtx(fn func(context.Context, *RetainedValueEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onRetainedValue = nil
+		h.onRetainedValueCtx = fn
+	}
+}
+
+// OnAutomaticPixCtx sets a context-aware handler for automatic PIX events, replacing OnAutomaticPix
+func OnAutomaticPixCtx(fn func(context.Context, *AutomaticPixEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onAutomaticPix = nil
+		h.onAutomaticPixCtx = fn
+	}
+}
+
+// OnClaimNotificationCtx sets a context-aware handler for PIX key claim notification events,
+// replacing OnClaimNotification
+func OnClaimNotificationCtx(fn func(context.Context, *ClaimNotificationEvent, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onClaimNotification = nil
+		h.onClaimNotificationCtx = fn
+	}
+}
+
+// OnUnknownCtx sets a context-aware fallback for event types without a built-in
+// callback, replacing OnUnknown
+func OnUnknownCtx(fn func(context.Context, EventType, []byte, Meta) error) HandlerOption {
+	return func(h *Handler) {
+		h.onUnknown = nil
+		h.onUnknownCtx = fn
+	}
+}
+
+// WithTracerProvider sets the tracer provider for webhook server spans.
+// Defaults to the global provider.
+func WithTracerProvider(tp trace.TracerProvider) HandlerOption {
+	return func(h *Handler) {
+		h.tracerProvider = tp
+	}
+}
+
+// WithPropagator sets the propagator used to extract trace context from deliveries.
+// Defaults to W3C Trace Context and Baggage.
+func WithPropagator(p propagation.TextMapPropagator) HandlerOption {
+	return func(h *Handler) {
+		h.propagator = p
+	}
+}
+
+// defaultPropagator extracts W3C traceparent/tracestate and baggage headers
+var defaultPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// deliver decodes the payload and invokes the context-aware callback, or the plain one if set
+func deliver[E any](ctx context.Context, body []byte, meta Meta, name string,
+	fn func(*E) error, fnCtx func(context.Context, *E, Meta) error) error {
+	if fn == nil && fnCtx == nil {
+		return nil
+	}
+	var event E
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to parse %s event: %w", name, err)
+	}
+	if fnCtx != nil {
+		return fnCtx(ctx, &event, meta)
+	}
+	return fn(&event)
+}
+
+// startSpan extracts the W3C trace context from header and starts a span for the event
+func (h *Handler) startSpan(ctx context.Context, header http.Header, eventType EventType, kind trace.SpanKind,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tp := h.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	ctx = h.textMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+	tracer := tp.Tracer(observability.TracerName, trace.WithInstrumentationVersion(observability.TracerVersion))
+	return tracer.Start(ctx, "webhook "+string(eventType),
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attribute.String("webhook.event_type", string(eventType))),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// textMapPropagator returns the configured propagator or the W3C default
+func (h *Handler) textMapPropagator() propagation.TextMapPropagator {
+	if h.propagator == nil {
+		return defaultPropagator
+	}
+	return h.propagator
+}
+
+// endSpan records the response status and error on the span and ends it
+func endSpan(span trace.Span, statusCode int, err error) {
+	span.SetAttributes(attribute.Int("http.status_code", statusCode))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// maxTrackedAttempts bounds the number of unacknowledged events whose attempts are counted
+const maxTrackedAttempts = 10000
+
+// attemptTracker counts deliveries of unacknowledged events
+type attemptTracker struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// next records a delivery of the event and returns its attempt number
+func (t *attemptTracker) next(eventKey string) int {
+	if eventKey == "" {
+		return 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.counts == nil || len(t.counts) >= maxTrackedAttempts {
+		t.counts = make(map[string]int)
+	}
+	t.counts[eventKey]++
+	return t.counts[eventKey]
+}
+
+// done forgets an acknowledged event
+func (t *attemptTracker) done(eventKey string) {
+	if eventKey == "" {
+		return
+	}
+	t.mu.Lock()
+	delete(t.counts, eventKey)
+	t.mu.Unlock()
+}
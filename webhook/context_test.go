@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextCallbackMeta(t *testing.T) {
+	type ctxKey struct{}
+	var attempts []int
+	var gotMeta Meta
+	h := NewHandler(OnPixMovementCtx(func(ctx context.Context, _ *PixMovementEvent, meta Meta) error {
+		if ctx.Value(ctxKey{}) != "request" {
+			t.Error("callback context does not derive from the request context")
+		}
+		gotMeta = meta
+		attempts = append(attempts, meta.Attempt)
+		if len(attempts) == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	}))
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook/movimento_pix", bytes.NewBufferString(testBody))
+		req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "request"))
+		req.Header.Set("X-Request-Id", "abc")
+		req.RemoteAddr = "203.0.113.10:4000"
+		rec := httptest.NewRecorder()
+		h.HandlePixMovement(rec, req)
+		return rec.Code
+	}
+
+	if code := send(); code != http.StatusInternalServerError {
+		t.Fatalf("first delivery: status = %d; want 500", code)
+	}
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("second delivery: status = %d; want 200", code)
+	}
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("third delivery: status = %d; want 200", code)
+	}
+
+	if want := []int{1, 2, 1}; len(attempts) != 3 || attempts[0] != want[0] || attempts[1] != want[1] || attempts[2] != want[2] {
+		t.Errorf("attempts = %v; want %v", attempts, want)
+	}
+	if gotMeta.Header.Get("X-Request-Id") != "abc" || gotMeta.RemoteAddr != "203.0.113.10:4000" ||
+		string(gotMeta.Body) != testBody || gotMeta.ReceivedAt.IsZero() {
+		t.Errorf("unexpected meta %+v", gotMeta)
+	}
+}
+
+func TestContextCallbackReplacesPlainCallback(t *testing.T) {
+	var plain, withCtx int
+	h := NewHandler(
+		OnRetainedValue(func(*RetainedValueEvent) error { plain++; return nil }),
+		OnRetainedValueCtx(func(context.Context, *RetainedValueEvent, Meta) error { withCtx++; return nil }),
+	)
+
+	rec := httptest.NewRecorder()
+	h.HandleRetainedValue(rec, httptest.NewRequest(http.MethodPost, "/webhook/valor_retido", bytes.NewBufferString(`{}`)))
+	if rec.Code != http.StatusOK || plain != 0 || withCtx != 1 {
+		t.Errorf("status = %d, plain = %d, ctx = %d; want 200, 0, 1", rec.Code, plain, withCtx)
+	}
+}
+
+func TestOnUnknownCtx(t *testing.T) {
+	var gotPayload, gotBody string
+	h := NewHandler(OnUnknownCtx(func(_ context.Context, _ EventType, payload []byte, meta Meta) error {
+		gotPayload, gotBody = string(payload), string(meta.Body)
+		return nil
+	}))
+
+	body := `{"type":"novo_evento","data":{"id":1}}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want 200", rec.Code)
+	}
+	if gotPayload != `{"id":1}` || gotBody != body {
+		t.Errorf("payload = %s, body = %s", gotPayload, gotBody)
+	}
+}
+
+func TestServerSpanContinuesIncomingTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var callbackSpan trace.SpanContext
+	h := NewHandler(
+		WithTracerProvider(tp),
+		OnPixMovementCtx(func(ctx context.Context, _ *PixMovementEvent, _ Meta) error {
+			callbackSpan = trace.SpanContextFromContext(ctx)
+			return errors.New("database unavailable")
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/movimento_pix", bytes.NewBufferString(testBody))
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	h.HandlePixMovement(rec, req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans; want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "webhook movimento_pix" || span.SpanKind != trace.SpanKindServer {
+		t.Errorf("span = %q (%v); want server span \"webhook movimento_pix\"", span.Name, span.SpanKind)
+	}
+	if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s; want incoming trace", span.SpanContext.TraceID())
+	}
+	if span.Parent.SpanID().String() != "00f067aa0ba902b7" || !span.Parent.IsRemote() {
+		t.Errorf("parent = %s; want remote 00f067aa0ba902b7", span.Parent.SpanID())
+	}
+	if callbackSpan.SpanID() != span.SpanContext.SpanID() {
+		t.Error("callback context does not carry the server span")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v; want Error", span.Status.Code)
+	}
+}
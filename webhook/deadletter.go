@@ -0,0 +1,396 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sentinel errors for dead-letter operations
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrNoDeadLetterStore  = errors.New("no dead-letter store configured")
+)
+
+// DeadLetter is a webhook event whose callback failed
+type DeadLetter struct {
+	// ID identifies the dead letter: the event key when available, a random ID otherwise
+	ID string `json:"id"`
+	// Type is the event type
+	Type EventType `json:"type"`
+	// Payload is the event payload passed to callbacks
+	Payload []byte `json:"payload"`
+	// EventKey is the idempotency key of the event, if any
+	EventKey string `json:"eventKey,omitempty"`
+	// Header holds the headers of the last failed delivery
+	Header http.Header `json:"header,omitempty"`
+	// RemoteAddr is the network address of the sender
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	// Body is the raw request body
+	Body []byte `json:"body,omitempty"`
+	// Error is the message of the last callback error
+	Error string `json:"error"`
+	// Attempts is the number of failed attempts, including replays
+	Attempts int `json:"attempts"`
+	// ReceivedAt is when the event was first received
+	ReceivedAt time.Time `json:"receivedAt"`
+	// FailedAt is when the last attempt failed
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// DeadLetterStore keeps failed webhook events for inspection and replay.
+// Implementations must be safe for concurrent use.
+type DeadLetterStore interface {
+	// Put stores a dead letter, replacing any with the same ID
+	Put(ctx context.Context, letter *DeadLetter) error
+	// Get returns a dead letter or ErrDeadLetterNotFound
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+	// List returns all dead letters, oldest first
+	List(ctx context.Context) ([]*DeadLetter, error)
+	// Delete removes a dead letter; deleting a missing ID is not an error
+	Delete(ctx context.Context, id string) error
+}
+
+// WithDeadLetterStore records events whose callback failed in store so that they
+// can be replayed with Replay or ReplayAll. Synchronous deliveries are recorded on
+// every failure (keyed by event key, so redeliveries update the same entry) and
+// removed once a redelivery succeeds; asynchronous events are recorded after their
+// last retry.
+func WithDeadLetterStore(store DeadLetterStore) HandlerOption {
+	return func(h *Handler) {
+		h.deadLetters = store
+	}
+}
+
+// recordDeadLetter stores a failed event, logging storage errors
+func (h *Handler) recordDeadLetter(ctx context.Context, letter *DeadLetter) {
+	if h.deadLetters == nil {
+		return
+	}
+	if letter.ID == "" {
+		letter.ID = letter.EventKey
+	}
+	if letter.ID == "" {
+		letter.ID = newQueuedEventID()
+	}
+	if previous, err := h.deadLetters.Get(ctx, letter.ID); err == nil {
+		letter.ReceivedAt = previous.ReceivedAt
+	}
+	if err := h.deadLetters.Put(ctx, letter); err != nil {
+		h.logger.Error("Failed to store dead letter", "type", letter.Type, "id", letter.ID, "error", err)
+	}
+}
+
+// clearDeadLetter removes the dead letter of an event that was processed successfully
+func (h *Handler) clearDeadLetter(ctx context.Context, eventKey string) {
+	if h.deadLetters == nil || eventKey == "" {
+		return
+	}
+	if err := h.deadLetters.Delete(ctx, eventKey); err != nil {
+		h.logger.Error("Failed to delete dead letter", "id", eventKey, "error", err)
+	}
+}
+
+// Replay dispatches a dead letter through the registered callbacks. On success the
+// event is marked as processed and removed from the store; on failure its attempt
+// count and error are updated and the callback error is returned. A letter whose event
+// was processed by a redelivery in the meantime is removed without dispatching it.
+func (h *Handler) Replay(ctx context.Context, id string) error {
+	if h.deadLetters == nil {
+		return ErrNoDeadLetterStore
+	}
+	letter, err := h.deadLetters.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return h.replay(ctx, letter)
+}
+
+// ReplayAll replays every dead letter accepted by filter (all of them if filter is nil)
+// and returns how many succeeded, along with the joined errors of those that failed
+func (h *Handler) ReplayAll(ctx context.Context, filter func(*DeadLetter) bool) (int, error) {
+	if h.deadLetters == nil {
+		return 0, ErrNoDeadLetterStore
+	}
+	letters, err := h.deadLetters.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	var errs []error
+	for _, letter := range letters {
+		if err := ctx.Err(); err != nil {
+			return replayed, errors.Join(append(errs, err)...)
+		}
+		if filter != nil && !filter(letter) {
+			continue
+		}
+		if err := h.replay(ctx, letter); err != nil {
+			errs = append(errs, fmt.Errorf("dead letter %s: %w", letter.ID, err))
+			continue
+		}
+		replayed++
+	}
+	return replayed, errors.Join(errs...)
+}
+
+// replay re-dispatches a single dead letter
+func (h *Handler) replay(ctx context.Context, letter *DeadLetter) (err error) {
+	attempt := letter.Attempts + 1
+	ctx, span := h.startSpan(ctx, letter.Header, letter.Type, trace.SpanKindInternal,
+		attribute.String("webhook.event_key", letter.EventKey),
+		attribute.String("webhook.dead_letter_id", letter.ID),
+		attribute.Int("webhook.attempt", attempt),
+	)
+	defer func() {
+		statusCode := http.StatusOK
+		if err != nil {
+			statusCode = http.StatusInternalServerError
+		}
+		endSpan(span, statusCode, err)
+	}()
+
+	meta := Meta{
+		Header:     letter.Header,
+		ReceivedAt: letter.ReceivedAt,
+		RemoteAddr: letter.RemoteAddr,
+		Body:       letter.Body,
+		Attempt:    attempt,
+	}
+
+	// A redelivery may have processed the event since it was dead-lettered, or be
+	// processing it right now
+	duplicate, claimed, err := h.checkEvent(letter.EventKey, true)
+	if err != nil {
+		h.logger.Error("Idempotency check failed", "type", letter.Type, "id", letter.ID, "error", err)
+		return err
+	}
+	if duplicate {
+		h.logger.Info("Dead letter already processed", "type", letter.Type, "id", letter.ID)
+		return h.deadLetters.Delete(ctx, letter.ID)
+	}
+
+	if err := h.dispatch(ctx, letter.Type, letter.Payload, meta); err != nil {
+		h.finishEvent(letter.Type, letter.EventKey, claimed, err)
+		h.logger.Error("Dead letter replay failed", "type", letter.Type, "id", letter.ID, "attempt", attempt, "error", err)
+		failed := *letter
+		failed.Attempts = attempt
+		failed.Error = err.Error()
+		failed.FailedAt = time.Now()
+		if putErr := h.deadLetters.Put(ctx, &failed); putErr != nil {
+			h.logger.Error("Failed to store dead letter", "type", letter.Type, "id", letter.ID, "error", putErr)
+		}
+		return err
+	}
+
+	h.finishEvent(letter.Type, letter.EventKey, claimed, nil)
+	h.logger.Info("Dead letter replayed", "type", letter.Type, "id", letter.ID)
+	return h.deadLetters.Delete(ctx, letter.ID)
+}
+
+// MemoryDeadLetterStore is an in-memory DeadLetterStore
+type MemoryDeadLetterStore struct {
+	mu      sync.Mutex
+	letters map[string]*DeadLetter
+	order   []string
+}
+
+// NewMemoryDeadLetterStore creates an empty in-memory dead-letter store
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{letters: make(map[string]*DeadLetter)}
+}
+
+// Put stores a copy of the dead letter
+func (s *MemoryDeadLetterStore) Put(_ context.Context, letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(letter)
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) put(letter *DeadLetter) {
+	if _, ok := s.letters[letter.ID]; !ok {
+		s.order = append(s.order, letter.ID)
+	}
+	stored := *letter
+	s.letters[letter.ID] = &stored
+}
+
+// Get returns a copy of the dead letter
+func (s *MemoryDeadLetterStore) Get(_ context.Context, id string) (*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	stored := *letter
+	return &stored, nil
+}
+
+// List returns copies of all dead letters in insertion order
+func (s *MemoryDeadLetterStore) List(context.Context) ([]*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	letters := make([]*DeadLetter, 0, len(s.order))
+	for _, id := range s.order {
+		stored := *s.letters[id]
+		letters = append(letters, &stored)
+	}
+	return letters, nil
+}
+
+// Delete removes a dead letter
+func (s *MemoryDeadLetterStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delete(id)
+	return nil
+}
+
+// delete removes a dead letter, reporting whether it existed
+func (s *MemoryDeadLetterStore) delete(id string) bool {
+	if _, ok := s.letters[id]; !ok {
+		return false
+	}
+	delete(s.letters, id)
+	s.order = slices.DeleteFunc(s.order, func(v string) bool { return v == id })
+	return true
+}
+
+// FileDeadLetterStore is a DeadLetterStore backed by an append-only JSON-lines file.
+// Each Put appends the dead letter and each Delete appends a tombstone; the file is
+// compacted when opened.
+type FileDeadLetterStore struct {
+	path   string
+	memory *MemoryDeadLetterStore
+	mu     sync.Mutex
+}
+
+// deadLetterRecord is a line of a FileDeadLetterStore
+type deadLetterRecord struct {
+	*DeadLetter
+	Deleted string `json:"deleted,omitempty"`
+}
+
+// NewFileDeadLetterStore opens or creates the JSON-lines file at path
+func NewFileDeadLetterStore(path string) (*FileDeadLetterStore, error) {
+	s := &FileDeadLetterStore{path: path, memory: NewMemoryDeadLetterStore()}
+
+	f, err := os.Open(path) //nolint:gosec // path is provided by the application
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create dead-letter directory: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+	default:
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			var record deadLetterRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				return nil, fmt.Errorf("invalid dead-letter record on line %d: %w", line, err)
+			}
+			switch {
+			case record.Deleted != "":
+				s.memory.delete(record.Deleted)
+			case record.DeadLetter != nil && record.ID != "":
+				s.memory.put(record.DeadLetter)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read dead-letter file: %w", err)
+		}
+	}
+
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compact rewrites the file with the current dead letters only
+func (s *FileDeadLetterStore) compact() error {
+	letters, _ := s.memory.List(context.Background())
+	var data []byte
+	for _, letter := range letters {
+		line, err := json.Marshal(deadLetterRecord{DeadLetter: letter})
+		if err != nil {
+			return fmt.Errorf("failed to encode dead letter: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if err := writeFileSync(s.path, data); err != nil {
+		return fmt.Errorf("failed to write dead-letter file: %w", err)
+	}
+	return nil
+}
+
+// append writes a record to the end of the file
+func (s *FileDeadLetterStore) append(record deadLetterRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec // path is provided by the application
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return f.Close()
+}
+
+// Put appends the dead letter to the file
+func (s *FileDeadLetterStore) Put(ctx context.Context, letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(deadLetterRecord{DeadLetter: letter}); err != nil {
+		return err
+	}
+	return s.memory.Put(ctx, letter)
+}
+
+// Get returns a dead letter
+func (s *FileDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	return s.memory.Get(ctx, id)
+}
+
+// List returns all dead letters, oldest first
+func (s *FileDeadLetterStore) List(ctx context.Context) ([]*DeadLetter, error) {
+	return s.memory.List(ctx)
+}
+
+// Delete appends a tombstone for an existing dead letter
+func (s *FileDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.memory.Get(ctx, id); errors.Is(err, ErrDeadLetterNotFound) {
+		return nil
+	}
+	if err := s.append(deadLetterRecord{Deleted: id}); err != nil {
+		return err
+	}
+	return s.memory.Delete(ctx, id)
+}
@@ -0,0 +1,245 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeadLetterRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore()
+	idempotency := NewInMemoryIdempotencyStore(time.Hour)
+	fail := true
+	var replayAttempt int
+	h := NewHandler(
+		WithDeadLetterStore(store),
+		WithIdempotencyStore(idempotency),
+		OnPixMovementCtx(func(_ context.Context, _ *PixMovementEvent, meta Meta) error {
+			replayAttempt = meta.Attempt
+			if fail {
+				return errors.New("ledger bug")
+			}
+			return nil
+		}),
+	)
+
+	postEvent(h, testBody)
+	postEvent(h, testBody)
+
+	letters, _ := store.List(ctx)
+	if len(letters) != 1 {
+		t.Fatalf("got %d dead letters; want 1 updated entry", len(letters))
+	}
+	letter := letters[0]
+	if letter.ID != "movimento_pix:E123:" || letter.Type != EventTypePixMovement || letter.Attempts != 2 ||
+		letter.Error != "ledger bug" || string(letter.Body) != testBody {
+		t.Errorf("unexpected dead letter %+v", letter)
+	}
+
+	if err := h.Replay(ctx, letter.ID); err == nil {
+		t.Fatal("expected replay error while the bug is present")
+	}
+	if letter, _ = store.Get(ctx, letter.ID); letter.Attempts != 3 {
+		t.Errorf("Attempts after failed replay = %d; want 3", letter.Attempts)
+	}
+
+	fail = false
+	if err := h.Replay(ctx, letter.ID); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if replayAttempt != 4 {
+		t.Errorf("replay Meta.Attempt = %d; want 4", replayAttempt)
+	}
+	if _, err := store.Get(ctx, letter.ID); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("dead letter still stored after successful replay: %v", err)
+	}
+	if processed, _ := idempotency.Exists(letter.ID); !processed {
+		t.Error("replayed event not marked as processed")
+	}
+	if err := h.Replay(ctx, letter.ID); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("Replay of missing letter = %v; want ErrDeadLetterNotFound", err)
+	}
+}
+
+func TestDeadLetterClearedBySuccessfulRedelivery(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	fail := true
+	h := NewHandler(
+		WithDeadLetterStore(store),
+		OnPixMovement(func(*PixMovementEvent) error {
+			if fail {
+				return errors.New("temporary failure")
+			}
+			return nil
+		}),
+	)
+
+	postEvent(h, testBody)
+	fail = false
+	postEvent(h, testBody)
+
+	if letters, _ := store.List(context.Background()); len(letters) != 0 {
+		t.Errorf("got %d dead letters after successful redelivery; want 0", len(letters))
+	}
+}
+
+func TestReplayOfRedeliveredEvent(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore()
+	fail := true
+	var credited int
+	h := NewHandler(
+		WithDeadLetterStore(store),
+		WithIdempotencyStore(NewInMemoryIdempotencyStore(time.Hour)),
+		OnPixMovement(func(*PixMovementEvent) error {
+			if fail {
+				return errors.New("temporary failure")
+			}
+			credited++
+			return nil
+		}),
+	)
+
+	postEvent(h, testBody)
+	letters, _ := store.List(ctx)
+	if len(letters) != 1 {
+		t.Fatalf("got %d dead letters; want 1", len(letters))
+	}
+
+	// The redelivery succeeds while an operator still holds the letter for replay
+	fail = false
+	postEvent(h, testBody)
+	_ = store.Put(ctx, letters[0])
+
+	if err := h.Replay(ctx, letters[0].ID); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if credited != 1 {
+		t.Errorf("callback succeeded %d times; want 1", credited)
+	}
+	if _, err := store.Get(ctx, letters[0].ID); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("dead letter of a processed event still stored: %v", err)
+	}
+}
+
+func TestReplayAll(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore()
+	var replayed []string
+	h := NewHandler(
+		WithDeadLetterStore(store),
+		OnPixMovement(func(e *PixMovementEvent) error {
+			if e.EndToEnd == "E-broken" {
+				return errors.New("still broken")
+			}
+			replayed = append(replayed, e.EndToEnd)
+			return nil
+		}),
+	)
+
+	for _, id := range []string{"a", "b", "c"} {
+		_ = store.Put(ctx, &DeadLetter{ID: id, Type: EventTypePixMovement, Payload: []byte(`{"endToEnd":"E-` + id + `"}`)})
+	}
+	_ = store.Put(ctx, &DeadLetter{ID: "broken", Type: EventTypePixMovement, Payload: []byte(`{"endToEnd":"E-broken"}`)})
+
+	n, err := h.ReplayAll(ctx, func(l *DeadLetter) bool { return l.ID != "b" })
+	if n != 2 || err == nil || !strings.Contains(err.Error(), "dead letter broken") {
+		t.Errorf("ReplayAll = %d, %v; want 2 and an error for broken", n, err)
+	}
+	if strings.Join(replayed, ",") != "E-a,E-c" {
+		t.Errorf("replayed %v; want [E-a E-c]", replayed)
+	}
+
+	letters, _ := store.List(ctx)
+	if len(letters) != 2 || letters[0].ID != "b" || letters[1].ID != "broken" {
+		t.Errorf("remaining dead letters = %v; want b and broken", letters)
+	}
+
+	if _, err := NewHandler().ReplayAll(ctx, nil); !errors.Is(err, ErrNoDeadLetterStore) {
+		t.Errorf("ReplayAll without store = %v; want ErrNoDeadLetterStore", err)
+	}
+}
+
+func TestAsyncDeadLetter(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	h := NewHandler(
+		WithAsync(NewMemoryQueue(10), AsyncConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		WithDeadLetterStore(store),
+		OnPixMovement(func(*PixMovementEvent) error { return errors.New("ledger bug") }),
+	)
+
+	postEvent(h, testBody)
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	letters, _ := store.List(context.Background())
+	if len(letters) != 1 || letters[0].Attempts != 2 {
+		t.Errorf("dead letters = %+v; want one with 2 attempts", letters)
+	}
+}
+
+func TestAsyncDeadLetterClearedBySuccessfulRedelivery(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	newHandler := func(callbackErr error) *Handler {
+		return NewHandler(
+			WithAsync(NewMemoryQueue(10), AsyncConfig{MaxAttempts: 1}),
+			WithDeadLetterStore(store),
+			OnPixMovement(func(*PixMovementEvent) error { return callbackErr }),
+		)
+	}
+
+	for _, callbackErr := range []error{errors.New("temporary failure"), nil} {
+		h := newHandler(callbackErr)
+		postEvent(h, testBody)
+		if err := h.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+		letters, _ := store.List(context.Background())
+		if want := map[bool]int{true: 1, false: 0}[callbackErr != nil]; len(letters) != want {
+			t.Errorf("callback error %v: got %d dead letters; want %d", callbackErr, len(letters), want)
+		}
+	}
+}
+
+func TestFileDeadLetterStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dlq", "dead-letters.jsonl")
+	store, err := NewFileDeadLetterStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = store.Put(ctx, &DeadLetter{ID: "a", Type: EventTypePixMovement, Payload: []byte(`{}`), Attempts: 1})
+	_ = store.Put(ctx, &DeadLetter{ID: "b", Type: EventTypeRetainedValue, Payload: []byte(`{}`), Attempts: 1})
+	_ = store.Put(ctx, &DeadLetter{ID: "a", Type: EventTypePixMovement, Payload: []byte(`{}`), Attempts: 2})
+	if err := store.Delete(ctx, "b"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Delete of missing letter = %v; want nil", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Errorf("file has %d lines; want 4 appended records", lines)
+	}
+
+	reopened, err := NewFileDeadLetterStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	letters, _ := reopened.List(ctx)
+	if len(letters) != 1 || letters[0].ID != "a" || letters[0].Attempts != 2 {
+		t.Fatalf("reopened letters = %+v; want a with 2 attempts", letters)
+	}
+	data, _ = os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("compacted file has %d lines; want 1", lines)
+	}
+}
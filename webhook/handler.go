@@ -1,25 +1,53 @@
 package webhook
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"runtime/debug"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Handler handles incoming webhook events
 type Handler struct {
 	logger               *slog.Logger
 	idempotencyStore     IdempotencyStore
+	eventKeyFuncs        map[EventType]EventKeyFunc
 	panicRecovery        bool
+	signature            *signatureVerifier
+	allowedCertificates  map[string]struct{}
+	allowedIPEntries     []string
+	allowedIPs           []netip.Prefix
 	onPixMovement        func(*PixMovementEvent) error
 	onScheduledPix       func(*ScheduledPixEvent) error
 	onPrecautionaryBlock func(*PrecautionaryBlockEvent) error
 	onRetainedValue      func(*RetainedValueEvent) error
 	onAutomaticPix       func(*AutomaticPixEvent) error
 	onClaimNotification  func(*ClaimNotificationEvent) error
+	onUnknown            func(EventType, []byte) error
+
+	onPixMovementCtx        func(context.Context, *PixMovementEvent, Meta) error
+	onScheduledPixCtx       func(context.Context, *ScheduledPixEvent, Meta) error
+	onPrecautionaryBlockCtx func(context.Context, *PrecautionaryBlockEvent, Meta) error
+	onRetainedValueCtx      func(context.Context, *RetainedValueEvent, Meta) error
+	onAutomaticPixCtx       func(context.Context, *AutomaticPixEvent, Meta) error
+	onClaimNotificationCtx  func(context.Context, *ClaimNotificationEvent, Meta) error
+	onUnknownCtx            func(context.Context, EventType, []byte, Meta) error
+
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+	attempts       attemptTracker
+	async          *asyncProcessor
+	deadLetters    DeadLetterStore
 }
 
 // NewHandler creates a new webhook handler
@@ -30,6 +58,15 @@ func NewHandler(opts ...HandlerOption) *Handler {
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.signature != nil {
+		if err := h.signature.validate(); err != nil {
+			panic(err)
+		}
+	}
+	h.parseAllowedIPs()
+	if h.async != nil {
+		h.startAsync()
+	}
 	return h
 }
 
@@ -47,6 +84,7 @@ func WithLogger(logger *slog.Logger) HandlerOption {
 func OnPixMovement(fn func(*PixMovementEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onPixMovement = fn
+		h.onPixMovementCtx = nil
 	}
 }
 
@@ -54,6 +92,7 @@ func OnPixMovement(fn func(*PixMovementEvent) error) HandlerOption {
 func OnScheduledPix(fn func(*ScheduledPixEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onScheduledPix = fn
+		h.onScheduledPixCtx = nil
 	}
 }
 
@@ -61,6 +100,7 @@ func OnScheduledPix(fn func(*ScheduledPixEvent) error) HandlerOption {
 func OnPrecautionaryBlock(fn func(*PrecautionaryBlockEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onPrecautionaryBlock = fn
+		h.onPrecautionaryBlockCtx = nil
 	}
 }
 
@@ -68,6 +108,7 @@ func OnPrecautionaryBlock(fn func(*PrecautionaryBlockEvent) error) HandlerOption
 func OnRetainedValue(fn func(*RetainedValueEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onRetainedValue = fn
+		h.onRetainedValueCtx = nil
 	}
 }
 
@@ -75,6 +116,7 @@ func OnRetainedValue(fn func(*RetainedValueEvent) error) HandlerOption {
 func OnAutomaticPix(fn func(*AutomaticPixEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onAutomaticPix = fn
+		h.onAutomaticPixCtx = nil
 	}
 }
 
@@ -82,11 +124,14 @@ func OnAutomaticPix(fn func(*AutomaticPixEvent) error) HandlerOption {
 func OnClaimNotification(fn func(*ClaimNotificationEvent) error) HandlerOption {
 	return func(h *Handler) {
 		h.onClaimNotification = fn
+		h.onClaimNotificationCtx = nil
 	}
 }
 
 // WithIdempotencyStore sets the idempotency store for duplicate event detection.
-// Use InMemoryIdempotencyStore for single-instance or implement IdempotencyStore
+// Each delivery is keyed per event type (see WithEventKeyFunc); duplicates are
+// acknowledged with 200 without invoking callbacks, and keys are only stored
+// after the callback succeeds. Use InMemoryIdempotencyStore for single-instance or implement IdempotencyStore
 // interface for Redis/database backends in distributed deployments.
 func WithIdempotencyStore(store IdempotencyStore) HandlerOption {
 	return func(h *Handler) {
@@ -158,6 +203,13 @@ func (h *Handler) handleEvent(w http.ResponseWriter, r *http.Request, eventType
 		return
 	}
 
+	receivedAt := time.Now()
+
+	if err := h.verifyPeer(r); err != nil {
+		h.rejectUnverified(w, r, eventType, err)
+		return
+	}
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		h.logger.Error("Failed to read request body", "error", err)
@@ -166,98 +218,193 @@ func (h *Handler) handleEvent(w http.ResponseWriter, r *http.Request, eventType
 	}
 	defer r.Body.Close()
 
-	h.logger.Debug("Received webhook", "type", eventType, "body", string(body))
-
-	var handlerErr error
-	switch eventType {
-	case EventTypePixMovement:
-		handlerErr = h.processPixMovement(body)
-	case EventTypeScheduledPix:
-		handlerErr = h.processScheduledPix(body)
-	case EventTypePrecautionaryBlock:
-		handlerErr = h.processPrecautionaryBlock(body)
-	case EventTypeRetainedValue:
-		handlerErr = h.processRetainedValue(body)
-	case EventTypeAutomaticPix:
-		handlerErr = h.processAutomaticPix(body)
-	case EventTypeClaimNotification:
-		handlerErr = h.processClaimNotification(body)
-	default:
-		http.Error(w, "Unknown event type", http.StatusBadRequest)
+	replayKey, err := h.verifyBody(r, body)
+	if err != nil {
+		h.rejectUnverified(w, r, eventType, err)
 		return
 	}
+	// Unless the delivery is acknowledged, let the sender retry with the same signature
+	acknowledged := false
+	if replayKey != "" {
+		defer func() {
+			if !acknowledged {
+				h.signature.release(replayKey)
+			}
+		}()
+	}
 
-	if handlerErr != nil {
-		h.logger.Error("Handler error", "type", eventType, "error", handlerErr)
-		http.Error(w, handlerErr.Error(), http.StatusInternalServerError)
+	// Deliveries to a shared endpoint carry their type in the body
+	payload := body
+	if eventType == "" {
+		eventType, payload, err = unwrapEnvelope(body)
+		if err != nil {
+			h.logger.Error("Failed to resolve event type", "error", err)
+			http.Error(w, "Invalid event envelope", http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.logger.Debug("Received webhook", "type", eventType, "body", string(payload))
+
+	if !isKnownEventType(eventType) && h.onUnknown == nil && h.onUnknownCtx == nil {
+		h.logger.Warn("Unknown webhook event type", "type", eventType)
+		http.Error(w, "Unknown event type", http.StatusBadRequest)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	acknowledged = h.processDelivery(w, r, eventType, payload, body, receivedAt)
 }
 
-func (h *Handler) processPixMovement(body []byte) error {
-	if h.onPixMovement == nil {
-		return nil
+// processDelivery handles a verified delivery of a resolved event type within a server
+// span: duplicate detection, then queueing or invoking the callback. It reports whether
+// the delivery was acknowledged.
+func (h *Handler) processDelivery(w http.ResponseWriter, r *http.Request, eventType EventType,
+	payload, body []byte, receivedAt time.Time) bool {
+	ctx, span := h.startSpan(r.Context(), r.Header, eventType, trace.SpanKindServer,
+		attribute.String("http.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("client.address", r.RemoteAddr),
+	)
+	statusCode := http.StatusOK
+	var spanErr error
+	defer func() { endSpan(span, statusCode, spanErr) }()
+
+	fail := func(code int, message string, err error) bool {
+		statusCode, spanErr = code, err
+		http.Error(w, message, code)
+		return false
 	}
-	var event PixMovementEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse PIX movement event: %w", err)
+
+	eventKey, err := h.eventKey(eventType, payload)
+	if err != nil {
+		if h.idempotencyStore != nil {
+			h.logger.Error("Failed to derive event key", "type", eventType, "error", err)
+			return fail(http.StatusBadRequest, "Invalid event payload", err)
+		}
+		eventKey = ""
 	}
-	return h.onPixMovement(&event)
-}
+	span.SetAttributes(attribute.String("webhook.event_key", eventKey))
 
-func (h *Handler) processScheduledPix(body []byte) error {
-	if h.onScheduledPix == nil {
-		return nil
+	// Skip deliveries that were already processed successfully. Queued events are
+	// claimed by the worker, so only synchronous deliveries claim the key here.
+	duplicate, claimed, err := h.checkEvent(eventKey, h.async == nil)
+	if err != nil {
+		h.logger.Error("Idempotency check failed", "type", eventType, "event_key", eventKey, "error", err)
+		return fail(http.StatusInternalServerError, "Idempotency check failed", err)
 	}
-	var event ScheduledPixEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse scheduled PIX event: %w", err)
+	if duplicate {
+		h.logger.Info("Duplicate webhook skipped", "type", eventType, "event_key", eventKey)
+		span.SetAttributes(attribute.Bool("webhook.duplicate", true))
+		w.WriteHeader(http.StatusOK)
+		return true
 	}
-	return h.onScheduledPix(&event)
-}
 
-func (h *Handler) processPrecautionaryBlock(body []byte) error {
-	if h.onPrecautionaryBlock == nil {
-		return nil
+	if h.async != nil {
+		if err := h.enqueue(ctx, r, eventType, eventKey, payload, body, receivedAt); err != nil {
+			h.logger.Error("Failed to queue webhook", "type", eventType, "event_key", eventKey, "error", err)
+			return fail(http.StatusServiceUnavailable, "Service unavailable", err)
+		}
+		statusCode = http.StatusAccepted
+		w.WriteHeader(statusCode)
+		return true
 	}
-	var event PrecautionaryBlockEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse precautionary block event: %w", err)
+
+	meta := Meta{
+		Header:     r.Header,
+		ReceivedAt: receivedAt,
+		RemoteAddr: r.RemoteAddr,
+		Body:       body,
+		Attempt:    h.attempts.next(eventKey),
+	}
+	span.SetAttributes(attribute.Int("webhook.attempt", meta.Attempt))
+
+	handlerErr := h.dispatch(ctx, eventType, payload, meta)
+	// Mark as processed only after the callback succeeded so failed deliveries can be retried
+	h.finishEvent(eventType, eventKey, claimed, handlerErr)
+	if handlerErr != nil {
+		h.logger.Error("Handler error", "type", eventType, "attempt", meta.Attempt, "error", handlerErr)
+		h.recordDeadLetter(ctx, &DeadLetter{
+			ID:         eventKey,
+			Type:       eventType,
+			Payload:    payload,
+			EventKey:   eventKey,
+			Header:     r.Header,
+			RemoteAddr: r.RemoteAddr,
+			Body:       body,
+			Error:      handlerErr.Error(),
+			Attempts:   meta.Attempt,
+			ReceivedAt: receivedAt,
+			FailedAt:   time.Now(),
+		})
+		return fail(http.StatusInternalServerError, handlerErr.Error(), handlerErr)
 	}
-	return h.onPrecautionaryBlock(&event)
+	h.attempts.done(eventKey)
+	h.clearDeadLetter(ctx, eventKey)
+
+	w.WriteHeader(http.StatusOK)
+	return true
 }
 
-func (h *Handler) processRetainedValue(body []byte) error {
-	if h.onRetainedValue == nil {
-		return nil
+// checkEvent reports whether an event was already processed. With claim set and an
+// AtomicIdempotencyStore, the event is also marked as processed in the same operation;
+// claimed reports whether that happened, in which case finishEvent releases the claim
+// if processing fails.
+func (h *Handler) checkEvent(eventKey string, claim bool) (duplicate, claimed bool, err error) {
+	if h.idempotencyStore == nil || eventKey == "" {
+		return false, false, nil
 	}
-	var event RetainedValueEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse retained value event: %w", err)
+	if store, ok := h.idempotencyStore.(AtomicIdempotencyStore); ok && claim {
+		duplicate, err = store.CheckAndStore(eventKey)
+		return duplicate, err == nil && !duplicate, err
 	}
-	return h.onRetainedValue(&event)
+	duplicate, err = h.idempotencyStore.Exists(eventKey)
+	return duplicate, false, err
 }
 
-func (h *Handler) processAutomaticPix(body []byte) error {
-	if h.onAutomaticPix == nil {
-		return nil
+// finishEvent records the outcome of processing an event checked with checkEvent
+func (h *Handler) finishEvent(eventType EventType, eventKey string, claimed bool, processErr error) {
+	if h.idempotencyStore == nil || eventKey == "" {
+		return
 	}
-	var event AutomaticPixEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse automatic PIX event: %w", err)
+	switch {
+	case processErr != nil && claimed:
+		if err := h.idempotencyStore.(AtomicIdempotencyStore).Delete(eventKey); err != nil {
+			h.logger.Error("Failed to release event key", "type", eventType, "event_key", eventKey, "error", err)
+		}
+	case processErr == nil && !claimed:
+		if err := h.idempotencyStore.Store(eventKey); err != nil {
+			h.logger.Error("Failed to store event key", "type", eventType, "event_key", eventKey, "error", err)
+		}
 	}
-	return h.onAutomaticPix(&event)
 }
 
-func (h *Handler) processClaimNotification(body []byte) error {
-	if h.onClaimNotification == nil {
-		return nil
-	}
-	var event ClaimNotificationEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		return fmt.Errorf("failed to parse claim notification event: %w", err)
+// dispatch decodes the payload and invokes the callback registered for the event type
+func (h *Handler) dispatch(ctx context.Context, eventType EventType, body []byte, meta Meta) error {
+	switch eventType {
+	case EventTypePixMovement:
+		return deliver(ctx, body, meta, "PIX movement", h.onPixMovement, h.onPixMovementCtx)
+	case EventTypeScheduledPix:
+		return deliver(ctx, body, meta, "scheduled PIX", h.onScheduledPix, h.onScheduledPixCtx)
+	case EventTypePrecautionaryBlock:
+		return deliver(ctx, body, meta, "precautionary block", h.onPrecautionaryBlock, h.onPrecautionaryBlockCtx)
+	case EventTypeRetainedValue:
+		return deliver(ctx, body, meta, "retained value", h.onRetainedValue, h.onRetainedValueCtx)
+	case EventTypeAutomaticPix:
+		return deliver(ctx, body, meta, "automatic PIX", h.onAutomaticPix, h.onAutomaticPixCtx)
+	case EventTypeClaimNotification:
+		return deliver(ctx, body, meta, "claim notification", h.onClaimNotification, h.onClaimNotificationCtx)
+	default:
+		if h.onUnknownCtx != nil {
+			return h.onUnknownCtx(ctx, eventType, body, meta)
+		}
+		if h.onUnknown != nil {
+			return h.onUnknown(eventType, body)
+		}
+		return fmt.Errorf("unknown event type: %s", eventType)
 	}
-	return h.onClaimNotification(&event)
+}
+
+// isKnownEventType reports whether the event type has a built-in callback
+func isKnownEventType(eventType EventType) bool {
+	return slices.Contains(knownEventTypes, eventType)
 }
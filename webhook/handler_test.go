@@ -3,10 +3,14 @@ package webhook
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
 )
 
 func TestNewHandler(t *testing.T) {
@@ -99,7 +103,7 @@ func TestHandlePixMovement(t *testing.T) {
 	event := PixMovementEvent{
 		AccountID:       12345,
 		AuthorizationID: 67890,
-		Value:           100.50,
+		Value:           types.MustParseMoney("100.50"),
 		MovementType:    PixMovementTypeManual,
 		EndToEnd:        "E123456789",
 	}
@@ -134,7 +138,7 @@ func TestHandleScheduledPix(t *testing.T) {
 	event := ScheduledPixEvent{
 		AccountID: 12345,
 		Success:   true,
-		Value:     50.00,
+		Value:     50 * types.Real,
 		EndToEnd:  "E987654321",
 	}
 
@@ -165,7 +169,7 @@ func TestHandlePrecautionaryBlock(t *testing.T) {
 	event := PrecautionaryBlockEvent{
 		AccountID:                  12345,
 		Type:                       PrecautionaryBlockTypeBlock,
-		Value:                      1000.00,
+		Value:                      1000 * types.Real,
 		PrecautionaryTransactionID: 11111,
 	}
 
@@ -195,7 +199,7 @@ func TestHandleRetainedValue(t *testing.T) {
 
 	event := RetainedValueEvent{
 		AccountID:           12345,
-		Value:               500.00,
+		Value:               500 * types.Real,
 		OriginTransactionID: 99999,
 	}
 
@@ -226,7 +230,7 @@ func TestHandleAutomaticPix(t *testing.T) {
 	event := AutomaticPixEvent{
 		AccountID:        12345,
 		NotificationType: AutomaticPixAdesao,
-		Amount:           200.00,
+		Amount:           200 * types.Real,
 	}
 
 	body, _ := json.Marshal(event)
@@ -513,3 +517,164 @@ func TestPanicRecoveryDisabled(t *testing.T) {
 
 	handler.HandlePixMovement(rec, req)
 }
+
+// Idempotent Dispatch Tests
+
+func TestHandlerSkipsDuplicateDeliveries(t *testing.T) {
+	calls := 0
+	handler := NewHandler(
+		WithIdempotencyStore(NewInMemoryIdempotencyStore(time.Hour)),
+		OnPixMovement(func(e *PixMovementEvent) error {
+			calls++
+			return nil
+		}),
+	)
+
+	body, _ := json.Marshal(PixMovementEvent{AccountID: 12345, EndToEnd: "E123456789", MovementType: PixMovementTypeKey})
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/webhook/pix-movement", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.HandlePixMovement(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("delivery %d: expected status 200, got %d", i, rec.Code)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected callback to run once, ran %d times", calls)
+	}
+}
+
+func TestHandlerDoesNotStoreFailedDeliveries(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Hour)
+	calls := 0
+	handler := NewHandler(
+		WithIdempotencyStore(store),
+		OnPixMovement(func(e *PixMovementEvent) error {
+			calls++
+			if calls == 1 {
+				return errors.New("ledger unavailable")
+			}
+			return nil
+		}),
+	)
+
+	body, _ := json.Marshal(PixMovementEvent{AccountID: 12345, EndToEnd: "E123456789"})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/pix-movement", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.HandlePixMovement(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook/pix-movement", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	handler.HandlePixMovement(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on redelivery, got %d", rec.Code)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected callback to run twice, ran %d times", calls)
+	}
+	if exists, _ := store.Exists("movimento_pix:E123456789:"); !exists {
+		t.Error("expected event key to be stored after success")
+	}
+}
+
+func TestHandlerClaimStatusChangesAreDistinct(t *testing.T) {
+	calls := 0
+	handler := NewHandler(
+		WithIdempotencyStore(NewInMemoryIdempotencyStore(time.Hour)),
+		OnClaimNotification(func(e *ClaimNotificationEvent) error {
+			calls++
+			return nil
+		}),
+	)
+
+	for _, status := range []ClaimStatus{ClaimStatusOpen, ClaimStatusConfirmed, ClaimStatusConfirmed} {
+		body, _ := json.Marshal(ClaimNotificationEvent{ClaimID: "claim-123", ClaimStatus: status})
+		req := httptest.NewRequest(http.MethodPost, "/webhook/claim-notification", bytes.NewReader(body))
+		handler.HandleClaimNotification(httptest.NewRecorder(), req)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected callback to run twice, ran %d times", calls)
+	}
+}
+
+func TestWithEventKeyFunc(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Hour)
+	handler := NewHandler(
+		WithIdempotencyStore(store),
+		WithEventKeyFunc(EventTypeRetainedValue, func(body []byte) (string, error) {
+			return "custom", nil
+		}),
+	)
+
+	body, _ := json.Marshal(RetainedValueEvent{AccountID: 12345, OriginTransactionID: 99999})
+	req := httptest.NewRequest(http.MethodPost, "/webhook/retained-value", bytes.NewReader(body))
+	handler.HandleRetainedValue(httptest.NewRecorder(), req)
+
+	if exists, _ := store.Exists("valor_retido:custom"); !exists {
+		t.Error("expected custom event key to be stored")
+	}
+
+	// Key errors are logged, not echoed to the sender
+	handler = NewHandler(
+		WithIdempotencyStore(store),
+		WithEventKeyFunc(EventTypeRetainedValue, func(body []byte) (string, error) {
+			return "", errors.New("internal key detail")
+		}),
+	)
+	rec := httptest.NewRecorder()
+	handler.HandleRetainedValue(rec, httptest.NewRequest(http.MethodPost, "/webhook/retained-value", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest || strings.Contains(rec.Body.String(), "internal key detail") {
+		t.Errorf("status = %d, body = %q; want 400 without the key error", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDefaultEventKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType EventType
+		event     any
+		expected  string
+	}{
+		{"pix movement", EventTypePixMovement, PixMovementEvent{EndToEnd: "E1", MovementType: PixMovementTypeRefund}, "movimento_pix:E1:DEVOLUCAO"},
+		{"scheduled pix", EventTypeScheduledPix, ScheduledPixEvent{TransactionID: 42}, "pix_agendado_executado:42"},
+		{"precautionary block", EventTypePrecautionaryBlock, PrecautionaryBlockEvent{PrecautionaryTransactionID: 7, Type: PrecautionaryBlockTypeUnblock}, "notifica_bloqueio_cautelar:7:UNBLOCK"},
+		{"retained value", EventTypeRetainedValue, RetainedValueEvent{OriginTransactionID: 9}, "valor_retido:9"},
+		{"automatic pix", EventTypeAutomaticPix, AutomaticPixEvent{RecurrenceID: "RR1", NotificationType: AutomaticPixAdesao}, "notificacao_pix_automatico:RR1:ADESAO_PIX_AUTOMATICO"},
+		{"claim", EventTypeClaimNotification, ClaimNotificationEvent{ClaimID: "c1", ClaimStatus: ClaimStatusCompleted}, "notifica_reivindicacao:c1:COMPLETED"},
+	}
+
+	handler := NewHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.event)
+			key, err := handler.eventKey(tt.eventType, body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key != tt.expected {
+				t.Errorf("expected key %q, got %q", tt.expected, key)
+			}
+		})
+	}
+}
+
+func TestEventKeyFallsBackToBodyHash(t *testing.T) {
+	handler := NewHandler()
+	body := []byte(`{"accountId":12345,"value":10}`)
+
+	first, err := handler.eventKey(EventTypePixMovement, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := handler.eventKey(EventTypePixMovement, body)
+	if first == "" || first != second {
+		t.Errorf("expected stable non-empty hash key, got %q and %q", first, second)
+	}
+}
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyMaxEntries is the default maximum number of event IDs kept by an
+// InMemoryIdempotencyStore
+const DefaultIdempotencyMaxEntries = 100_000
+
+// InMemoryIdempotencyStore is an in-memory implementation of AtomicIdempotencyStore.
+// It is safe for concurrent use, expires entries after the TTL and, once it holds
+// the maximum number of entries, evicts the least recently used ones.
+// Suitable for single-instance deployments. For distributed systems, use Redis or database.
+type InMemoryIdempotencyStore struct {
+	mu         sync.Mutex
+	seen       map[string]*list.Element
+	lru        *list.List // front is most recently used
+	ttl        time.Duration
+	maxEntries int
+	cleanup    time.Duration
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// idempotencyEntry is an element of the store's LRU list
+type idempotencyEntry struct {
+	id       string
+	storedAt time.Time
+}
+
+// InMemoryStoreOption configures an InMemoryIdempotencyStore
+type InMemoryStoreOption func(*InMemoryIdempotencyStore)
+
+// WithMaxEntries bounds the number of stored event IDs (DefaultIdempotencyMaxEntries
+// by default). Zero or a negative value removes the bound.
+func WithMaxEntries(n int) InMemoryStoreOption {
+	return func(s *InMemoryIdempotencyStore) {
+		s.maxEntries = n
+	}
+}
+
+// WithCleanupInterval starts a background goroutine that removes expired entries
+// every interval. Call Close to stop it. If given more than once, the last interval wins.
+func WithCleanupInterval(interval time.Duration) InMemoryStoreOption {
+	return func(s *InMemoryIdempotencyStore) {
+		s.cleanup = interval
+	}
+}
+
+// NewInMemoryIdempotencyStore creates a new in-memory idempotency store
+func NewInMemoryIdempotencyStore(ttl time.Duration, opts ...InMemoryStoreOption) *InMemoryIdempotencyStore {
+	s := &InMemoryIdempotencyStore{
+		seen:       make(map[string]*list.Element),
+		lru:        list.New(),
+		ttl:        ttl,
+		maxEntries: DefaultIdempotencyMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.cleanup > 0 {
+		s.stop = make(chan struct{})
+		go s.janitor(s.cleanup)
+	}
+	return s
+}
+
+// Exists checks if an event ID has already been processed
+func (s *InMemoryIdempotencyStore) Exists(eventID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exists(eventID, time.Now()), nil
+}
+
+// Store marks an event ID as processed
+func (s *InMemoryIdempotencyStore) Store(eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(eventID, time.Now())
+	return nil
+}
+
+// CheckAndStore marks an event ID as processed, reporting whether it already was
+func (s *InMemoryIdempotencyStore) CheckAndStore(eventID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.exists(eventID, now) {
+		return true, nil
+	}
+	s.store(eventID, now)
+	return false, nil
+}
+
+// Delete removes an event ID
+func (s *InMemoryIdempotencyStore) Delete(eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.seen[eventID]; ok {
+		s.remove(elem)
+	}
+	return nil
+}
+
+// Len returns the number of stored event IDs, including expired ones not yet cleaned up
+func (s *InMemoryIdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.seen)
+}
+
+// Cleanup removes expired entries from the store
+func (s *InMemoryIdempotencyStore) Cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, elem := range s.seen {
+		if now.Sub(elem.Value.(*idempotencyEntry).storedAt) >= s.ttl {
+			s.remove(elem)
+		}
+	}
+}
+
+// Close stops the background cleanup goroutine, if any
+func (s *InMemoryIdempotencyStore) Close() error {
+	s.closeOnce.Do(func() {
+		if s.stop != nil {
+			close(s.stop)
+		}
+	})
+	return nil
+}
+
+// exists reports whether eventID is stored and not expired, refreshing its recency
+func (s *InMemoryIdempotencyStore) exists(eventID string, now time.Time) bool {
+	elem, ok := s.seen[eventID]
+	if !ok {
+		return false
+	}
+	if now.Sub(elem.Value.(*idempotencyEntry).storedAt) >= s.ttl {
+		s.remove(elem)
+		return false
+	}
+	s.lru.MoveToFront(elem)
+	return true
+}
+
+// store records eventID, evicting the least recently used entries beyond the bound
+func (s *InMemoryIdempotencyStore) store(eventID string, now time.Time) {
+	if elem, ok := s.seen[eventID]; ok {
+		elem.Value.(*idempotencyEntry).storedAt = now
+		s.lru.MoveToFront(elem)
+		return
+	}
+	s.seen[eventID] = s.lru.PushFront(&idempotencyEntry{id: eventID, storedAt: now})
+	for s.maxEntries > 0 && len(s.seen) > s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+}
+
+// remove deletes an entry from the map and the LRU list
+func (s *InMemoryIdempotencyStore) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.seen, elem.Value.(*idempotencyEntry).id)
+}
+
+// janitor periodically removes expired entries until Close is called
+func (s *InMemoryIdempotencyStore) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Cleanup()
+		case <-s.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInMemoryIdempotencyStoreLRU(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Hour, WithMaxEntries(2))
+
+	_ = store.Store("a")
+	_ = store.Store("b")
+	if exists, _ := store.Exists("a"); !exists { // "a" becomes most recently used
+		t.Fatal("expected a to exist")
+	}
+	_ = store.Store("c") // evicts "b"
+
+	for id, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if exists, _ := store.Exists(id); exists != want {
+			t.Errorf("Exists(%s) = %v; want %v", id, exists, want)
+		}
+	}
+	if store.Len() != 2 {
+		t.Errorf("Len() = %d; want 2", store.Len())
+	}
+}
+
+func TestInMemoryIdempotencyStoreCheckAndStore(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Hour)
+
+	var firsts atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if exists, _ := store.CheckAndStore("event"); !exists {
+				firsts.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if firsts.Load() != 1 {
+		t.Errorf("CheckAndStore reported a new event %d times; want 1", firsts.Load())
+	}
+
+	_ = store.Delete("event")
+	if exists, _ := store.CheckAndStore("event"); exists {
+		t.Error("expected deleted event to be stored again")
+	}
+}
+
+func TestInMemoryIdempotencyStoreJanitor(t *testing.T) {
+	// Repeating the option starts a single janitor with the last interval
+	store := NewInMemoryIdempotencyStore(time.Millisecond,
+		WithCleanupInterval(time.Hour), WithCleanupInterval(2*time.Millisecond))
+	defer store.Close()
+
+	_ = store.Store("event")
+	deadline := time.Now().Add(time.Second)
+	for store.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("janitor did not remove the expired entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+}
+
+func TestInMemoryIdempotencyStoreConcurrentAccess(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Minute, WithMaxEntries(100))
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				id := fmt.Sprintf("event-%d", (i*200+j)%300)
+				_, _ = store.Exists(id)
+				_ = store.Store(id)
+				if j%50 == 0 {
+					store.Cleanup()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if store.Len() > 100 {
+		t.Errorf("Len() = %d; want at most 100", store.Len())
+	}
+}
+
+func TestHandlerClaimsEventsAtomically(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	h := NewHandler(
+		WithIdempotencyStore(NewInMemoryIdempotencyStore(time.Hour)),
+		OnPixMovement(func(*PixMovementEvent) error {
+			calls.Add(1)
+			<-release
+			return nil
+		}),
+	)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- postEvent(h, testBody)
+		}()
+	}
+	// Duplicates are answered while the first delivery is still running
+	for range 9 {
+		if code := <-codes; code != http.StatusOK {
+			t.Errorf("duplicate delivery: status = %d; want 200", code)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("callback ran %d times; want 1", calls.Load())
+	}
+}
+
+func TestHandlerReleasesClaimOnFailure(t *testing.T) {
+	store := NewInMemoryIdempotencyStore(time.Hour)
+	h := NewHandler(
+		WithIdempotencyStore(store),
+		OnPixMovement(func(*PixMovementEvent) error { return errors.New("ledger unavailable") }),
+	)
+
+	if code := postEvent(h, testBody); code != http.StatusInternalServerError {
+		t.Fatalf("status = %d; want 500", code)
+	}
+	if exists, _ := store.Exists("movimento_pix:E123:"); exists {
+		t.Error("failed delivery left its event key claimed")
+	}
+}
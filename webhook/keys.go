@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// EventKeyFunc derives a deterministic idempotency key from a raw webhook payload.
+// Returning an empty key disables duplicate detection for that delivery.
+type EventKeyFunc func(body []byte) (string, error)
+
+// WithEventKeyFunc overrides the idempotency key extractor for an event type.
+// The returned key is namespaced by event type before it reaches the store.
+func WithEventKeyFunc(eventType EventType, fn EventKeyFunc) HandlerOption {
+	return func(h *Handler) {
+		if h.eventKeyFuncs == nil {
+			h.eventKeyFuncs = make(map[EventType]EventKeyFunc)
+		}
+		h.eventKeyFuncs[eventType] = fn
+	}
+}
+
+// eventKey returns the namespaced idempotency key for an event payload
+func (h *Handler) eventKey(eventType EventType, body []byte) (string, error) {
+	fn, ok := h.eventKeyFuncs[eventType]
+	if !ok {
+		fn = defaultEventKeyFunc(eventType)
+	}
+
+	key, err := fn(body)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", nil
+	}
+	return string(eventType) + ":" + key, nil
+}
+
+// defaultEventKeyFunc returns the built-in key extractor for an event type
+func defaultEventKeyFunc(eventType EventType) EventKeyFunc {
+	switch eventType {
+	case EventTypePixMovement:
+		return PixMovementEventKey
+	case EventTypeScheduledPix:
+		return ScheduledPixEventKey
+	case EventTypePrecautionaryBlock:
+		return PrecautionaryBlockEventKey
+	case EventTypeRetainedValue:
+		return RetainedValueEventKey
+	case EventTypeAutomaticPix:
+		return AutomaticPixEventKey
+	case EventTypeClaimNotification:
+		return ClaimNotificationEventKey
+	default:
+		return BodyHashEventKey
+	}
+}
+
+// PixMovementEventKey keys PIX movements by EndToEnd ID and movement type
+func PixMovementEventKey(body []byte) (string, error) {
+	var event PixMovementEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse PIX movement event: %w", err)
+	}
+	if event.EndToEnd == "" {
+		return BodyHashEventKey(body)
+	}
+	return event.EndToEnd + ":" + string(event.MovementType), nil
+}
+
+// ScheduledPixEventKey keys scheduled PIX executions by EndToEnd ID, falling back to transaction ID
+func ScheduledPixEventKey(body []byte) (string, error) {
+	var event ScheduledPixEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse scheduled PIX event: %w", err)
+	}
+	switch {
+	case event.EndToEnd != "":
+		return event.EndToEnd, nil
+	case event.TransactionID != 0:
+		return strconv.FormatInt(event.TransactionID, 10), nil
+	default:
+		return BodyHashEventKey(body)
+	}
+}
+
+// PrecautionaryBlockEventKey keys precautionary blocks by transaction ID and block type
+func PrecautionaryBlockEventKey(body []byte) (string, error) {
+	var event PrecautionaryBlockEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse precautionary block event: %w", err)
+	}
+	if event.PrecautionaryTransactionID == 0 {
+		return BodyHashEventKey(body)
+	}
+	return strconv.FormatInt(event.PrecautionaryTransactionID, 10) + ":" + string(event.Type), nil
+}
+
+// RetainedValueEventKey keys retained values by origin transaction ID
+func RetainedValueEventKey(body []byte) (string, error) {
+	var event RetainedValueEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse retained value event: %w", err)
+	}
+	if event.OriginTransactionID == 0 {
+		return BodyHashEventKey(body)
+	}
+	return strconv.FormatInt(event.OriginTransactionID, 10), nil
+}
+
+// AutomaticPixEventKey keys automatic PIX notifications by recurrence ID and notification type
+func AutomaticPixEventKey(body []byte) (string, error) {
+	var event AutomaticPixEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse automatic PIX event: %w", err)
+	}
+	if event.RecurrenceID == "" {
+		return BodyHashEventKey(body)
+	}
+	return event.RecurrenceID + ":" + string(event.NotificationType), nil
+}
+
+// ClaimNotificationEventKey keys claim notifications by claim ID and claim status
+func ClaimNotificationEventKey(body []byte) (string, error) {
+	var event ClaimNotificationEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return "", fmt.Errorf("failed to parse claim notification event: %w", err)
+	}
+	if event.ClaimID == "" {
+		return BodyHashEventKey(body)
+	}
+	return event.ClaimID + ":" + string(event.ClaimStatus), nil
+}
+
+// BodyHashEventKey keys an event by the SHA-256 of its raw payload.
+// Used when a payload lacks a natural identifier.
+func BodyHashEventKey(body []byte) (string, error) {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// eventPathValue is the wildcard name used by Register for the event type path segment
+const eventPathValue = "event"
+
+// knownEventTypes lists the event types with built-in callbacks, in registration order
+var knownEventTypes = []EventType{
+	EventTypePixMovement,
+	EventTypeScheduledPix,
+	EventTypePrecautionaryBlock,
+	EventTypeRetainedValue,
+	EventTypeAutomaticPix,
+	EventTypeClaimNotification,
+}
+
+// OnUnknown sets the fallback for event types without a built-in callback.
+// It receives the event type and the raw payload; returning an error answers 500
+// so that the delivery is retried. Without a fallback, unknown events get 400.
+func OnUnknown(fn func(EventType, []byte) error) HandlerOption {
+	return func(h *Handler) {
+		h.onUnknown = fn
+		h.onUnknownCtx = nil
+	}
+}
+
+// ServeHTTP handles deliveries of any event type on a single endpoint.
+// The event type is taken from the last path segment (e.g. "/webhook/movimento_pix")
+// or, when the path does not name one, from an {"type": ..., "data": ...} envelope
+// in the body, in which case "data" is the event payload.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.handleEvent(w, r, routeEventType(r))
+}
+
+// Register mounts the handler on mux under prefix using method patterns:
+// "POST <prefix>/<event type>" for each built-in event type, "POST <prefix>/{event}"
+// for event types added later (see OnUnknown) and "POST <prefix>" for enveloped
+// deliveries. Other methods are answered with 405 by the mux. With an empty prefix
+// only the built-in event types and "POST /{$}" are mounted, so the handler does not
+// take over the rest of the mux; other event types must then use the envelope.
+func (h *Handler) Register(mux *http.ServeMux, prefix string) {
+	base := strings.Trim(prefix, "/")
+	if base != "" {
+		base = "/" + base
+	}
+
+	for _, eventType := range knownEventTypes {
+		mux.HandleFunc("POST "+base+"/"+string(eventType), func(w http.ResponseWriter, r *http.Request) {
+			h.handleEvent(w, r, eventType)
+		})
+	}
+	if base == "" {
+		mux.Handle("POST /{$}", h)
+		return
+	}
+	mux.Handle("POST "+base+"/{"+eventPathValue+"}", h)
+	mux.Handle("POST "+base, h)
+}
+
+// routeEventType returns the event type named by the request path, or "" if the
+// type must be read from the body
+func routeEventType(r *http.Request) EventType {
+	if value := r.PathValue(eventPathValue); value != "" {
+		return EventType(value)
+	}
+	if segment := EventType(path.Base(r.URL.Path)); isKnownEventType(segment) {
+		return segment
+	}
+	return ""
+}
+
+// errMissingEventType is returned for enveloped deliveries without a type
+var errMissingEventType = errors.New("missing event type")
+
+// unwrapEnvelope extracts the event type and payload from an enveloped delivery.
+// Without a "data" field the whole body is used as the payload.
+func unwrapEnvelope(body []byte) (EventType, []byte, error) {
+	var envelope struct {
+		Type EventType       `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", nil, err
+	}
+	if envelope.Type == "" {
+		return "", nil, errMissingEventType
+	}
+	if len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+		return envelope.Type, body, nil
+	}
+	return envelope.Type, envelope.Data, nil
+}
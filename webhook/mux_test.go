@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeHTTPRoutesByPathSuffix(t *testing.T) {
+	var got *PixMovementEvent
+	h := NewHandler(OnPixMovement(func(e *PixMovementEvent) error { got = e; return nil }))
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/movimento_pix", bytes.NewBufferString(`{"endToEnd":"E1"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want 200", rec.Code)
+	}
+	if got == nil || got.EndToEnd != "E1" {
+		t.Errorf("callback got %+v; want endToEnd E1", got)
+	}
+}
+
+func TestServeHTTPRoutesByEnvelope(t *testing.T) {
+	var got *ClaimNotificationEvent
+	h := NewHandler(OnClaimNotification(func(e *ClaimNotificationEvent) error { got = e; return nil }))
+
+	body := `{"type":"notifica_reivindicacao","data":{"claimId":"C1"}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want 200", rec.Code)
+	}
+	if got == nil || got.ClaimID != "C1" {
+		t.Errorf("callback got %+v; want claimId C1", got)
+	}
+
+	for _, body := range []string{`{"data":{}}`, `not json`} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d; want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestOnUnknown(t *testing.T) {
+	body := `{"type":"novo_evento","data":{"id":1}}`
+
+	rec := httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("without fallback: status = %d; want 400", rec.Code)
+	}
+
+	var gotType EventType
+	var gotPayload string
+	h := NewHandler(OnUnknown(func(eventType EventType, payload []byte) error {
+		gotType, gotPayload = eventType, string(payload)
+		return nil
+	}))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("with fallback: status = %d; want 200", rec.Code)
+	}
+	if gotType != "novo_evento" || gotPayload != `{"id":1}` {
+		t.Errorf("fallback got (%q, %s); want (novo_evento, {\"id\":1})", gotType, gotPayload)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	calls := make(map[EventType]int)
+	h := NewHandler(
+		OnPixMovement(func(*PixMovementEvent) error { calls[EventTypePixMovement]++; return nil }),
+		OnRetainedValue(func(*RetainedValueEvent) error { calls[EventTypeRetainedValue]++; return nil }),
+		OnUnknown(func(eventType EventType, _ []byte) error { calls[eventType]++; return nil }),
+	)
+
+	mux := http.NewServeMux()
+	h.Register(mux, "/webhook/")
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{http.MethodPost, "/webhook/movimento_pix", `{}`, http.StatusOK},
+		{http.MethodPost, "/webhook/valor_retido", `{}`, http.StatusOK},
+		{http.MethodPost, "/webhook/novo_evento", `{}`, http.StatusOK},
+		{http.MethodPost, "/webhook", `{"type":"movimento_pix","data":{}}`, http.StatusOK},
+		{http.MethodGet, "/webhook/movimento_pix", ``, http.StatusMethodNotAllowed},
+		{http.MethodPost, "/other/movimento_pix", `{}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body)))
+		if rec.Code != tt.want {
+			t.Errorf("%s %s: status = %d; want %d", tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+
+	want := map[EventType]int{EventTypePixMovement: 2, EventTypeRetainedValue: 1, "novo_evento": 1}
+	for eventType, n := range want {
+		if calls[eventType] != n {
+			t.Errorf("%s callback called %d times; want %d", eventType, calls[eventType], n)
+		}
+	}
+}
+
+func TestRegisterAtRoot(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHandler().Register(mux, "")
+
+	for _, target := range []string{"/movimento_pix", "/"} {
+		body := `{"type":"movimento_pix","data":{}}`
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body)))
+		if rec.Code != http.StatusOK {
+			t.Errorf("POST %s: status = %d; want 200", target, rec.Code)
+		}
+	}
+
+	// Other paths, even single-segment ones, are left to the rest of the mux
+	for _, target := range []string{"/api/accounts", "/login"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(`{}`)))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("POST %s: status = %d; want 404", target, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQueueCapacity is the default capacity of a MemoryQueue
+const DefaultQueueCapacity = 1000
+
+// Sentinel errors returned by EventQueue implementations
+var (
+	ErrQueueFull   = errors.New("event queue full")
+	ErrQueueClosed = errors.New("event queue closed")
+)
+
+// QueuedEvent is an accepted webhook delivery waiting to be processed asynchronously
+type QueuedEvent struct {
+	// ID uniquely identifies the queued delivery
+	ID string `json:"id"`
+	// Type is the resolved event type
+	Type EventType `json:"type"`
+	// Payload is the event payload passed to callbacks
+	Payload []byte `json:"payload"`
+	// EventKey is the idempotency key of the event, if any
+	EventKey string `json:"eventKey,omitempty"`
+	// Header holds the request headers, including the trace context of the delivery
+	Header http.Header `json:"header,omitempty"`
+	// RemoteAddr is the network address of the sender
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	// Body is the raw request body
+	Body []byte `json:"body,omitempty"`
+	// ReceivedAt is when the handler received the delivery
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// EventQueue stores accepted deliveries until a worker processes them.
+// Implementations must be safe for concurrent use.
+type EventQueue interface {
+	// Enqueue adds an event, returning ErrQueueFull if it cannot be accepted now
+	Enqueue(ctx context.Context, event *QueuedEvent) error
+	// Dequeue blocks until an event is available. After Close it returns the
+	// remaining events and then ErrQueueClosed.
+	Dequeue(ctx context.Context) (*QueuedEvent, error)
+	// Ack removes a dequeued event once it no longer needs processing
+	Ack(ctx context.Context, id string) error
+	// Close stops accepting new events
+	Close() error
+}
+
+// newQueuedEventID returns a random queued event ID
+func newQueuedEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MemoryQueue is a bounded in-memory EventQueue. Events are lost on restart;
+// use FileQueue or a database-backed implementation when that matters.
+type MemoryQueue struct {
+	events chan *QueuedEvent
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewMemoryQueue creates an in-memory queue holding up to capacity events
+// (DefaultQueueCapacity if zero)
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	if capacity <= 0 {
+		capacity = DefaultQueueCapacity
+	}
+	return &MemoryQueue{events: make(chan *QueuedEvent, capacity)}
+}
+
+// Enqueue adds an event without blocking
+func (q *MemoryQueue) Enqueue(_ context.Context, event *QueuedEvent) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.events <- event:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Dequeue waits for the next event
+func (q *MemoryQueue) Dequeue(ctx context.Context) (*QueuedEvent, error) {
+	select {
+	case event, ok := <-q.events:
+		if !ok {
+			return nil, ErrQueueClosed
+		}
+		return event, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Ack is a no-op: events leave a MemoryQueue when dequeued
+func (q *MemoryQueue) Ack(context.Context, string) error {
+	return nil
+}
+
+// Close stops accepting new events
+func (q *MemoryQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.events)
+	}
+	return nil
+}
+
+// Len returns the number of events waiting in the queue
+func (q *MemoryQueue) Len() int {
+	return len(q.events)
+}
+
+// FileQueue is an EventQueue that stores each event as a JSON file in a directory.
+// Events stay on disk until acknowledged, so deliveries accepted before a crash or
+// forced shutdown are processed again when the queue is reopened.
+type FileQueue struct {
+	dir      string
+	mu       sync.Mutex
+	pending  []string          // file names waiting to be dequeued, oldest first
+	inflight map[string]string // event ID -> file name
+	notify   chan struct{}
+	closed   bool
+	lastSeq  int64
+}
+
+// fileQueueExt is the extension of queued event files
+const fileQueueExt = ".json"
+
+// NewFileQueue opens a file-backed queue in dir, creating it if needed.
+// Events left by a previous process are queued again in arrival order.
+func NewFileQueue(dir string) (*FileQueue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create queue directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue directory: %w", err)
+	}
+
+	q := &FileQueue{
+		dir:      dir,
+		inflight: make(map[string]string),
+		notify:   make(chan struct{}, 1),
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), fileQueueExt) {
+			continue
+		}
+		q.pending = append(q.pending, entry.Name())
+	}
+	slices.Sort(q.pending)
+	if len(q.pending) > 0 {
+		q.signal()
+	}
+	return q, nil
+}
+
+// Enqueue writes the event to disk before returning
+func (q *FileQueue) Enqueue(_ context.Context, event *QueuedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode queued event: %w", err)
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrQueueClosed
+	}
+	seq := max(time.Now().UnixNano(), q.lastSeq+1)
+	q.lastSeq = seq
+	q.mu.Unlock()
+
+	name := fmt.Sprintf("%020d-%s%s", seq, event.ID, fileQueueExt)
+	path := filepath.Join(q.dir, name)
+	if err := writeFileSync(path, data); err != nil {
+		return fmt.Errorf("failed to write queued event: %w", err)
+	}
+
+	q.mu.Lock()
+	// Close may have run during the write, after Dequeue stopped draining
+	if q.closed {
+		q.mu.Unlock()
+		_ = os.Remove(path)
+		return ErrQueueClosed
+	}
+	q.pending = append(q.pending, name)
+	q.mu.Unlock()
+	q.signal()
+	return nil
+}
+
+// Dequeue waits for the next event on disk
+func (q *FileQueue) Dequeue(ctx context.Context) (*QueuedEvent, error) {
+	for {
+		q.mu.Lock()
+		if len(q.pending) > 0 {
+			name := q.pending[0]
+			q.pending = q.pending[1:]
+			more := len(q.pending) > 0
+			q.mu.Unlock()
+			if more {
+				q.signal()
+			}
+
+			event, err := q.read(name)
+			if err != nil {
+				return nil, err
+			}
+			q.mu.Lock()
+			q.inflight[event.ID] = name
+			q.mu.Unlock()
+			return event, nil
+		}
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return nil, ErrQueueClosed
+		}
+
+		select {
+		case <-q.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// read loads a queued event file. Unreadable files are renamed with a ".corrupt"
+// suffix so that they are not retried forever.
+func (q *FileQueue) read(name string) (*QueuedEvent, error) {
+	path := filepath.Join(q.dir, name)
+	data, err := os.ReadFile(path) //nolint:gosec // path is built from the queue directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queued event: %w", err)
+	}
+	var event QueuedEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.ID == "" {
+		_ = os.Rename(path, path+".corrupt")
+		return nil, fmt.Errorf("corrupt queued event %s: %w", name, err)
+	}
+	return &event, nil
+}
+
+// Ack deletes the event file
+func (q *FileQueue) Ack(_ context.Context, id string) error {
+	q.mu.Lock()
+	name, ok := q.inflight[id]
+	delete(q.inflight, id)
+	q.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(q.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove queued event: %w", err)
+	}
+	return nil
+}
+
+// Close stops accepting new events. Unacknowledged events stay on disk.
+func (q *FileQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.notify)
+	}
+	return nil
+}
+
+// Len returns the number of events waiting to be dequeued
+func (q *FileQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// signal wakes up a waiting Dequeue
+func (q *FileQueue) signal() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// writeFileSync atomically writes data to path, flushing it to disk
+func writeFileSync(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // path is built from the queue directory
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
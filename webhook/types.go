@@ -1,6 +1,10 @@
 package webhook
 
-import "time"
+import (
+	"time"
+
+	"github.com/henriqueatila/evertec-golang-sdk-conta-de-pagamento/types"
+)
 
 // EventType represents the type of webhook event
 type EventType string
@@ -54,20 +58,20 @@ const (
 
 // BankAccount represents bank account information in webhook payloads
 type BankAccount struct {
-	Name        string `json:"name,omitempty"`
-	Document    string `json:"document,omitempty"`
-	Bank        string `json:"bank,omitempty"`
-	Branch      string `json:"branch,omitempty"`
-	Account     string `json:"account,omitempty"`
-	AccountType string `json:"accountType,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Document    types.Document `json:"document,omitempty"`
+	Bank        string         `json:"bank,omitempty"`
+	Branch      string         `json:"branch,omitempty"`
+	Account     string         `json:"account,omitempty"`
+	AccountType string         `json:"accountType,omitempty"`
 }
 
 // PixMovementEvent represents a PIX movement webhook event
 type PixMovementEvent struct {
 	AccountID        int64           `json:"accountId"`
 	AuthorizationID  int64           `json:"authorizationId"`
-	Value            float64         `json:"value"`
-	BlockedValue     float64         `json:"blockedValue,omitempty"`
+	Value            types.Money     `json:"value"`
+	BlockedValue     types.Money     `json:"blockedValue,omitzero"`
 	MovementType     PixMovementType `json:"movementType"`
 	EndToEnd         string          `json:"endToEnd"`
 	EndToEndOriginal string          `json:"endToEndOriginal,omitempty"`
@@ -79,36 +83,36 @@ type PixMovementEvent struct {
 
 // ScheduledPixEvent represents a scheduled PIX execution webhook event
 type ScheduledPixEvent struct {
-	AccountID     int64   `json:"accountId"`
-	Success       bool    `json:"success"`
-	Value         float64 `json:"value"`
-	EndToEnd      string  `json:"endToEnd,omitempty"`
-	TransactionID int64   `json:"transactionId,omitempty"`
-	Branch        string  `json:"branch,omitempty"`
-	Account       string  `json:"account,omitempty"`
-	Document      string  `json:"document,omitempty"`
+	AccountID     int64          `json:"accountId"`
+	Success       bool           `json:"success"`
+	Value         types.Money    `json:"value"`
+	EndToEnd      string         `json:"endToEnd,omitempty"`
+	TransactionID int64          `json:"transactionId,omitempty"`
+	Branch        string         `json:"branch,omitempty"`
+	Account       string         `json:"account,omitempty"`
+	Document      types.Document `json:"document,omitempty"`
 }
 
 // PrecautionaryBlockEvent represents a precautionary block webhook event
 type PrecautionaryBlockEvent struct {
 	AccountID                  int64                  `json:"accountId"`
 	Type                       PrecautionaryBlockType `json:"type"`
-	Value                      float64                `json:"value"`
+	Value                      types.Money            `json:"value"`
 	PrecautionaryTransactionID int64                  `json:"precautionaryTransactionId"`
 }
 
 // RetainedValueEvent represents a retained value webhook event
 type RetainedValueEvent struct {
-	AccountID           int64   `json:"accountId"`
-	Value               float64 `json:"value"`
-	OriginTransactionID int64   `json:"originTransactionId"`
+	AccountID           int64       `json:"accountId"`
+	Value               types.Money `json:"value"`
+	OriginTransactionID int64       `json:"originTransactionId"`
 }
 
 // AutomaticPixEvent represents an automatic PIX notification webhook event
 type AutomaticPixEvent struct {
 	AccountID        int64                        `json:"accountId"`
 	NotificationType AutomaticPixNotificationType `json:"notificationType"`
-	Amount           float64                      `json:"amount,omitempty"`
+	Amount           types.Money                  `json:"amount,omitzero"`
 	ContractNumber   string                       `json:"contractNumber,omitempty"`
 	ReceiverName     string                       `json:"receiverName,omitempty"`
 	StartDate        *time.Time                   `json:"startDate,omitempty"`
@@ -136,13 +140,13 @@ const (
 
 // ClaimNotificationEvent represents a PIX key claim notification webhook event
 type ClaimNotificationEvent struct {
-	AccountID   int64       `json:"accountId"`
-	ClaimID     string      `json:"claimId"`
-	ClaimType   ClaimType   `json:"claimType"`
-	ClaimStatus ClaimStatus `json:"claimStatus"`
-	PixKey      string      `json:"pixKey"`
-	KeyType     string      `json:"keyType"`
-	Document    string      `json:"document,omitempty"`
+	AccountID   int64          `json:"accountId"`
+	ClaimID     string         `json:"claimId"`
+	ClaimType   ClaimType      `json:"claimType"`
+	ClaimStatus ClaimStatus    `json:"claimStatus"`
+	PixKey      string         `json:"pixKey"`
+	KeyType     string         `json:"keyType"`
+	Document    types.Document `json:"document,omitempty"`
 }
 
 // Event represents a generic webhook event
@@ -160,44 +164,14 @@ type IdempotencyStore interface {
 	Store(eventID string) error
 }
 
-// InMemoryIdempotencyStore is a simple in-memory implementation of IdempotencyStore.
-// Suitable for single-instance deployments. For distributed systems, use Redis or database.
-type InMemoryIdempotencyStore struct {
-	seen map[string]time.Time
-	ttl  time.Duration
-}
-
-// NewInMemoryIdempotencyStore creates a new in-memory idempotency store
-func NewInMemoryIdempotencyStore(ttl time.Duration) *InMemoryIdempotencyStore {
-	return &InMemoryIdempotencyStore{
-		seen: make(map[string]time.Time),
-		ttl:  ttl,
-	}
-}
-
-// Exists checks if an event ID has already been processed
-func (s *InMemoryIdempotencyStore) Exists(eventID string) (bool, error) {
-	if ts, ok := s.seen[eventID]; ok {
-		if time.Since(ts) < s.ttl {
-			return true, nil
-		}
-		delete(s.seen, eventID)
-	}
-	return false, nil
-}
-
-// Store marks an event ID as processed
-func (s *InMemoryIdempotencyStore) Store(eventID string) error {
-	s.seen[eventID] = time.Now()
-	return nil
-}
-
-// Cleanup removes expired entries from the store
-func (s *InMemoryIdempotencyStore) Cleanup() {
-	now := time.Now()
-	for id, ts := range s.seen {
-		if now.Sub(ts) >= s.ttl {
-			delete(s.seen, id)
-		}
-	}
+// AtomicIdempotencyStore is an IdempotencyStore that can check and mark an event
+// in a single operation. When the configured store implements it, the handler claims
+// each event before invoking callbacks, so concurrent deliveries of the same event are
+// processed once, and releases the claim if the callback fails.
+type AtomicIdempotencyStore interface {
+	IdempotencyStore
+	// CheckAndStore marks an event ID as processed, reporting whether it already was
+	CheckAndStore(eventID string) (bool, error)
+	// Delete removes an event ID so that the event can be processed again
+	Delete(eventID string) error
 }
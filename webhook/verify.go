@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"container/heap"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HMACAlgorithm identifies the hash function used for webhook signatures
+type HMACAlgorithm string
+
+const (
+	HMACSHA256 HMACAlgorithm = "sha256"
+	HMACSHA512 HMACAlgorithm = "sha512"
+)
+
+// DefaultSignatureTolerance is the default maximum age of a signed webhook timestamp
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// Sentinel errors for rejected deliveries, wrapped by VerificationError
+var (
+	ErrMissingSignature   = errors.New("missing signature")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrInvalidTimestamp   = errors.New("invalid signature timestamp")
+	ErrSignatureExpired   = errors.New("signature timestamp outside tolerance")
+	ErrReplayedSignature  = errors.New("signature already used")
+	ErrClientCertificate  = errors.New("client certificate not allowed")
+	ErrRemoteIPNotAllowed = errors.New("remote IP not allowed")
+)
+
+// VerificationError describes why a webhook delivery failed authenticity checks.
+// Such deliveries are answered with 401 Unauthorized.
+type VerificationError struct {
+	// Reason is a low-cardinality failure code (e.g. "invalid_signature", "ip_not_allowed")
+	Reason string
+	Err    error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("webhook verification failed (%s): %v", e.Reason, e.Err)
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// verificationReasons maps sentinel errors to VerificationError reasons
+var verificationReasons = map[error]string{
+	ErrMissingSignature:   "missing_signature",
+	ErrInvalidSignature:   "invalid_signature",
+	ErrInvalidTimestamp:   "invalid_timestamp",
+	ErrSignatureExpired:   "signature_expired",
+	ErrReplayedSignature:  "replayed_signature",
+	ErrClientCertificate:  "client_certificate",
+	ErrRemoteIPNotAllowed: "ip_not_allowed",
+}
+
+// newVerificationError wraps a sentinel verification error with its reason code
+func newVerificationError(err error) *VerificationError {
+	return &VerificationError{Reason: verificationReasons[err], Err: err}
+}
+
+// signatureVerifier checks HMAC signatures of webhook bodies
+type signatureVerifier struct {
+	hmac            bool // set by WithHMACSecret
+	header          string
+	secret          []byte
+	algo            HMACAlgorithm
+	newHash         func() hash.Hash
+	timestampHeader string
+	tolerance       time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	seen    map[string]time.Time // signature -> expiry, for replay protection
+	expires expiryQueue          // seen signatures by expiry, oldest first
+}
+
+// signatureExpiry is an entry of the replay-protection queue
+type signatureExpiry struct {
+	signature string
+	expiry    time.Time
+}
+
+// expiryQueue is a min-heap of signatures ordered by expiry
+type expiryQueue []signatureExpiry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].expiry.Before(q[j].expiry) }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *expiryQueue) Push(x any)        { *q = append(*q, x.(signatureExpiry)) }
+func (q *expiryQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// WithHMACSecret requires every delivery to carry an HMAC of the body in header.
+// The signature may be hex or base64 encoded and prefixed with the algorithm
+// (e.g. "sha256=ab12..."). Deliveries with a missing or wrong signature get 401.
+// Combine with WithSignatureTimestamp to bound signature age and reject replays.
+// NewHandler panics if algo is neither HMACSHA256 nor HMACSHA512.
+func WithHMACSecret(header string, secret []byte, algo HMACAlgorithm) HandlerOption {
+	return func(h *Handler) {
+		v := h.ensureSignatureVerifier()
+		v.hmac = true
+		v.header = header
+		v.secret = secret
+		v.algo = algo
+		switch algo {
+		case HMACSHA256:
+			v.newHash = sha256.New
+		case HMACSHA512:
+			v.newHash = sha512.New
+		default:
+			v.newHash = nil
+		}
+	}
+}
+
+// WithSignatureTimestamp requires a Unix timestamp (in seconds) in header and signs
+// "<timestamp>.<body>" instead of the body alone. Deliveries whose timestamp differs
+// from the current time by more than tolerance (DefaultSignatureTolerance if zero) are
+// rejected, and each signature is accepted only once within the tolerance window.
+// It requires WithHMACSecret; NewHandler panics otherwise, as the timestamp alone
+// would authenticate nothing.
+func WithSignatureTimestamp(header string, tolerance time.Duration) HandlerOption {
+	return func(h *Handler) {
+		if tolerance <= 0 {
+			tolerance = DefaultSignatureTolerance
+		}
+		v := h.ensureSignatureVerifier()
+		v.timestampHeader = header
+		v.tolerance = tolerance
+	}
+}
+
+// ensureSignatureVerifier returns the handler's signature verifier, creating it if needed
+func (h *Handler) ensureSignatureVerifier() *signatureVerifier {
+	if h.signature == nil {
+		h.signature = &signatureVerifier{now: time.Now, seen: make(map[string]time.Time)}
+	}
+	return h.signature
+}
+
+// validate reports signature options that would leave deliveries unauthenticated
+func (v *signatureVerifier) validate() error {
+	if !v.hmac {
+		return errors.New("webhook: WithSignatureTimestamp requires WithHMACSecret")
+	}
+	if v.header == "" {
+		return errors.New("webhook: WithHMACSecret requires a signature header")
+	}
+	if v.newHash == nil {
+		return fmt.Errorf("webhook: unknown HMAC algorithm %q", v.algo)
+	}
+	return nil
+}
+
+// verify checks the signature of body. When replay protection is active it returns
+// the recorded signature, which must be released if the delivery is not processed.
+func (v *signatureVerifier) verify(r *http.Request, body []byte) (string, error) {
+	provided := r.Header.Get(v.header)
+	if provided == "" {
+		return "", ErrMissingSignature
+	}
+
+	mac := hmac.New(v.newHash, v.secret)
+	var timestamp time.Time
+	if v.timestampHeader != "" {
+		raw := r.Header.Get(v.timestampHeader)
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "", ErrInvalidTimestamp
+		}
+		timestamp = time.Unix(seconds, 0)
+		if age := v.now().Sub(timestamp); age > v.tolerance || age < -v.tolerance {
+			return "", ErrSignatureExpired
+		}
+		mac.Write([]byte(raw + "."))
+	}
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	signature, ok := decodeSignature(provided, len(expected))
+	if !ok || !hmac.Equal(signature, expected) {
+		return "", ErrInvalidSignature
+	}
+
+	if v.timestampHeader == "" {
+		return "", nil
+	}
+	key := hex.EncodeToString(expected)
+	if err := v.checkReplay(key, timestamp.Add(v.tolerance)); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// checkReplay records a signature until expiry, failing if it was already seen.
+// Only expired signatures are visited, from the front of the expiry queue.
+func (v *signatureVerifier) checkReplay(signature string, expiry time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	for len(v.expires) > 0 && now.After(v.expires[0].expiry) {
+		// A released and recorded again signature has several entries; the first deletes it
+		e := heap.Pop(&v.expires).(signatureExpiry)
+		delete(v.seen, e.signature)
+	}
+	if _, ok := v.seen[signature]; ok {
+		return ErrReplayedSignature
+	}
+	v.seen[signature] = expiry
+	heap.Push(&v.expires, signatureExpiry{signature: signature, expiry: expiry})
+	return nil
+}
+
+// release forgets a recorded signature so that a redelivery of a failed event is accepted
+func (v *signatureVerifier) release(signature string) {
+	if signature == "" {
+		return
+	}
+	v.mu.Lock()
+	delete(v.seen, signature)
+	v.mu.Unlock()
+}
+
+// decodeSignature decodes a hex or base64 signature, ignoring an optional "<algo>=" prefix
+func decodeSignature(value string, size int) ([]byte, bool) {
+	for _, algo := range []HMACAlgorithm{HMACSHA256, HMACSHA512} {
+		prefix := string(algo) + "="
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = value[len(prefix):]
+			break
+		}
+	}
+	if b, err := hex.DecodeString(value); err == nil && len(b) == size {
+		return b, true
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if b, err := enc.DecodeString(value); err == nil && len(b) == size {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// WithAllowedClientCertificates only accepts deliveries whose TLS client certificate
+// matches one of the given SHA-256 fingerprints (hex, with or without colons).
+// Requests without a verified peer certificate are rejected with 401, so the server
+// must request client certificates (e.g. tls.RequireAndVerifyClientCert).
+func WithAllowedClientCertificates(fingerprints ...string) HandlerOption {
+	return func(h *Handler) {
+		if h.allowedCertificates == nil {
+			h.allowedCertificates = make(map[string]struct{})
+		}
+		for _, fp := range fingerprints {
+			h.allowedCertificates[normalizeFingerprint(fp)] = struct{}{}
+		}
+	}
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a DER-encoded certificate,
+// in the format accepted by WithAllowedClientCertificates
+func CertificateFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeFingerprint lowercases a fingerprint and strips separators
+func normalizeFingerprint(fp string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", " ", "").Replace(fp))
+}
+
+// verifyClientCertificate checks the request's peer certificate against the allowlist
+func (h *Handler) verifyClientCertificate(r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ErrClientCertificate
+	}
+	if _, ok := h.allowedCertificates[CertificateFingerprint(r.TLS.PeerCertificates[0].Raw)]; !ok {
+		return ErrClientCertificate
+	}
+	return nil
+}
+
+// WithAllowedIPs only accepts deliveries from the given IP addresses or CIDR ranges
+// (e.g. "203.0.113.10", "198.51.100.0/24"), matched against the request's RemoteAddr.
+// Invalid entries are logged and ignored.
+func WithAllowedIPs(entries ...string) HandlerOption {
+	return func(h *Handler) {
+		h.allowedIPEntries = append(h.allowedIPEntries, entries...)
+	}
+}
+
+// parseAllowedIPs converts the configured allowlist entries into prefixes
+func (h *Handler) parseAllowedIPs() {
+	for _, entry := range h.allowedIPEntries {
+		entry = strings.TrimSpace(entry)
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			h.allowedIPs = append(h.allowedIPs, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			h.logger.Error("Ignoring invalid allowed IP entry", "entry", entry, "error", err)
+			continue
+		}
+		h.allowedIPs = append(h.allowedIPs, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+}
+
+// verifyRemoteIP checks the request's remote address against the allowlist
+func (h *Handler) verifyRemoteIP(r *http.Request) error {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return ErrRemoteIPNotAllowed
+	}
+	addr = addr.Unmap()
+	for _, prefix := range h.allowedIPs {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return ErrRemoteIPNotAllowed
+}
+
+// verifyPeer runs the connection-level checks (IP allowlist and client certificate)
+func (h *Handler) verifyPeer(r *http.Request) error {
+	if h.allowedIPEntries != nil {
+		if err := h.verifyRemoteIP(r); err != nil {
+			return newVerificationError(err)
+		}
+	}
+	if h.allowedCertificates != nil {
+		if err := h.verifyClientCertificate(r); err != nil {
+			return newVerificationError(err)
+		}
+	}
+	return nil
+}
+
+// verifyBody runs the payload-level checks (HMAC signature) and returns the
+// replay-protection key to release if the delivery fails
+func (h *Handler) verifyBody(r *http.Request, body []byte) (string, error) {
+	if h.signature == nil {
+		return "", nil
+	}
+	key, err := h.signature.verify(r, body)
+	if err != nil {
+		return "", newVerificationError(err)
+	}
+	return key, nil
+}
+
+// rejectUnverified logs a failed verification and answers 401
+func (h *Handler) rejectUnverified(w http.ResponseWriter, r *http.Request, eventType EventType, err error) {
+	reason := ""
+	var verr *VerificationError
+	if errors.As(err, &verr) {
+		reason = verr.Reason
+	}
+	h.logger.Warn("Webhook verification failed",
+		"type", eventType,
+		"reason", reason,
+		"remote_addr", r.RemoteAddr,
+		"error", err,
+	)
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+}
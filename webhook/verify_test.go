@@ -0,0 +1,238 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("webhook-secret")
+
+const testBody = `{"idConta":1,"endToEnd":"E123","tipoMovimento":"RECEIVED"}`
+
+// sign computes a hex HMAC-SHA256 of the payload with testSecret
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// postPixMovement sends testBody to the handler with the given headers
+func postPixMovement(h *Handler, headers map[string]string, configure func(*http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook/movimento_pix", bytes.NewBufferString(testBody))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if configure != nil {
+		configure(req)
+	}
+	rec := httptest.NewRecorder()
+	h.HandlePixMovement(rec, req)
+	return rec
+}
+
+func TestHMACSignature(t *testing.T) {
+	var calls int
+	h := NewHandler(
+		WithHMACSecret("X-Signature", testSecret, HMACSHA256),
+		OnPixMovement(func(*PixMovementEvent) error { calls++; return nil }),
+	)
+
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(testBody))
+	raw := mac.Sum(nil)
+
+	tests := []struct {
+		name      string
+		signature string
+		want      int
+	}{
+		{"hex", hex.EncodeToString(raw), http.StatusOK},
+		{"prefixed hex", "sha256=" + hex.EncodeToString(raw), http.StatusOK},
+		{"base64", base64.StdEncoding.EncodeToString(raw), http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", sign("other body"), http.StatusUnauthorized},
+		{"garbage", "not-a-signature", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postPixMovement(h, map[string]string{"X-Signature": tt.signature}, nil)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d; want %d", rec.Code, tt.want)
+			}
+		})
+	}
+	if calls != 3 {
+		t.Errorf("callback called %d times; want 3", calls)
+	}
+}
+
+func TestHMACSignatureSHA512(t *testing.T) {
+	h := NewHandler(WithHMACSecret("X-Signature", testSecret, HMACSHA512))
+
+	mac := hmac.New(sha512.New, testSecret)
+	mac.Write([]byte(testBody))
+	rec := postPixMovement(h, map[string]string{"X-Signature": hex.EncodeToString(mac.Sum(nil))}, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d; want 200", rec.Code)
+	}
+
+	rec = postPixMovement(h, map[string]string{"X-Signature": sign(testBody)}, nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("SHA-256 signature accepted by SHA-512 handler: status = %d", rec.Code)
+	}
+}
+
+func TestSignatureOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []HandlerOption
+		want string
+	}{
+		{"timestamp without secret", []HandlerOption{WithSignatureTimestamp("X-Timestamp", 0)}, "requires WithHMACSecret"},
+		{"empty header", []HandlerOption{WithHMACSecret("", testSecret, HMACSHA256)}, "requires a signature header"},
+		{"unknown algorithm", []HandlerOption{WithHMACSecret("X-Signature", testSecret, "md5")}, `unknown HMAC algorithm "md5"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("NewHandler panicked with %v; want %q", err, tt.want)
+				}
+			}()
+			NewHandler(tt.opts...)
+		})
+	}
+}
+
+func TestSignatureTimestampAndReplay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fail := true
+	h := NewHandler(
+		WithHMACSecret("X-Signature", testSecret, HMACSHA256),
+		WithSignatureTimestamp("X-Timestamp", time.Minute),
+		OnPixMovement(func(*PixMovementEvent) error {
+			if fail {
+				return errors.New("temporary failure")
+			}
+			return nil
+		}),
+	)
+	h.signature.now = func() time.Time { return now }
+
+	signed := func(ts time.Time) map[string]string {
+		stamp := strconv.FormatInt(ts.Unix(), 10)
+		return map[string]string{"X-Timestamp": stamp, "X-Signature": sign(stamp + "." + testBody)}
+	}
+
+	if rec := postPixMovement(h, signed(now.Add(-2*time.Minute)), nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expired timestamp: status = %d; want 401", rec.Code)
+	}
+	if rec := postPixMovement(h, map[string]string{"X-Signature": sign(testBody)}, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("missing timestamp: status = %d; want 401", rec.Code)
+	}
+
+	headers := signed(now.Add(-30 * time.Second))
+	if rec := postPixMovement(h, headers, nil); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("failing callback: status = %d; want 500", rec.Code)
+	}
+
+	fail = false
+	if rec := postPixMovement(h, headers, nil); rec.Code != http.StatusOK {
+		t.Errorf("redelivery after failure: status = %d; want 200", rec.Code)
+	}
+	if rec := postPixMovement(h, headers, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("replayed delivery: status = %d; want 401", rec.Code)
+	}
+
+	// Signatures are forgotten once their timestamp leaves the tolerance window
+	now = now.Add(time.Minute)
+	if rec := postPixMovement(h, signed(now), nil); rec.Code != http.StatusOK {
+		t.Errorf("new delivery: status = %d; want 200", rec.Code)
+	}
+	if len(h.signature.seen) != 1 || len(h.signature.expires) != 1 {
+		t.Errorf("recorded signatures = %d (queue %d); want only the new one", len(h.signature.seen), len(h.signature.expires))
+	}
+}
+
+func TestAllowedIPs(t *testing.T) {
+	h := NewHandler(WithAllowedIPs("203.0.113.10", "198.51.100.0/24", "not-an-ip"))
+
+	tests := []struct {
+		remoteAddr string
+		want       int
+	}{
+		{"203.0.113.10:4000", http.StatusOK},
+		{"198.51.100.77:4000", http.StatusOK},
+		{"[::ffff:198.51.100.1]:4000", http.StatusOK},
+		{"203.0.113.11:4000", http.StatusUnauthorized},
+		{"garbage", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		rec := postPixMovement(h, nil, func(r *http.Request) { r.RemoteAddr = tt.remoteAddr })
+		if rec.Code != tt.want {
+			t.Errorf("RemoteAddr %s: status = %d; want %d", tt.remoteAddr, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestAllowedClientCertificates(t *testing.T) {
+	allowed := &x509.Certificate{Raw: []byte("allowed certificate")}
+	other := &x509.Certificate{Raw: []byte("other certificate")}
+
+	fingerprint := CertificateFingerprint(allowed.Raw)
+	colons := ""
+	for i := 0; i < len(fingerprint); i += 2 {
+		if i > 0 {
+			colons += ":"
+		}
+		colons += fingerprint[i : i+2]
+	}
+	h := NewHandler(WithAllowedClientCertificates(colons))
+
+	tests := []struct {
+		name string
+		tls  *tls.ConnectionState
+		want int
+	}{
+		{"allowed", &tls.ConnectionState{PeerCertificates: []*x509.Certificate{allowed}}, http.StatusOK},
+		{"other", &tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}, http.StatusUnauthorized},
+		{"no certificate", &tls.ConnectionState{}, http.StatusUnauthorized},
+		{"plain HTTP", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postPixMovement(h, nil, func(r *http.Request) { r.TLS = tt.tls })
+			if rec.Code != tt.want {
+				t.Errorf("status = %d; want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationError(t *testing.T) {
+	err := newVerificationError(ErrReplayedSignature)
+	if err.Reason != "replayed_signature" {
+		t.Errorf("Reason = %q; want replayed_signature", err.Reason)
+	}
+	if !errors.Is(err, ErrReplayedSignature) {
+		t.Error("expected VerificationError to wrap its sentinel")
+	}
+}